@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/buckets"
+	"github.com/fachschaftinformatik/web/internal/config"
+	"github.com/fachschaftinformatik/web/internal/database"
+	"github.com/fachschaftinformatik/web/internal/reconcile"
+)
+
+// runCommand runs a maintenance command instead of the server.
+func runCommand(cfg *config.Config, logger *log.Logger, name string, args []string) error {
+	switch name {
+	case "reconcile":
+		return runReconcile(cfg, logger, args)
+	case "rotate-keys":
+		return runRotateKeys(cfg, logger)
+	case "migrate":
+		return runMigrate(cfg, logger, args)
+	default:
+		return fmt.Errorf("unknown command %q, expected migrate, reconcile or rotate-keys", name)
+	}
+}
+
+// runReconcile compares the storage with the database once, see
+// reconcile.Run. Without -delete, orphans are only reported.
+func runReconcile(cfg *config.Config, logger *log.Logger, args []string) error {
+	flags := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	grace := flags.Duration("grace", time.Duration(cfg.OrphanGrace)*time.Hour, "only treat objects older than this as orphans")
+	del := flags.Bool("delete", false, "delete orphaned objects")
+	dryRun := flags.Bool("dry-run", false, "only report, without deleting objects or flagging rows")
+	if err := flags.Parse(args); errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	sqlDB, err := database.NewConnection(cfg.DatabaseUrl)
+	if err != nil {
+		return fmt.Errorf("database connection failed: %w", err)
+	}
+	defer sqlDB.Close()
+
+	store, err := buckets.New(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("storage creation failed: %w", err)
+	}
+
+	report, err := reconcile.Run(ctx, database.New(sqlDB), store, reconcile.Options{
+		Grace:  *grace,
+		Delete: *del,
+		DryRun: *dryRun,
+	})
+	if report != nil {
+		if *dryRun {
+			logger.Println("Dry run, nothing was changed.")
+		}
+		report.Log(logger)
+	}
+	return err
+}
+
+// runRotateKeys wraps all data keys with the first configured encryption
+// key. The other keys can be removed from the config once it has run.
+func runRotateKeys(cfg *config.Config, logger *log.Logger) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	store, err := buckets.New(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("storage creation failed: %w", err)
+	}
+	encrypted, ok := store.(*buckets.EncryptedStorage)
+	if !ok {
+		return errors.New("storage encryption is not configured")
+	}
+
+	rotated, err := encrypted.RotateKeys(ctx)
+	logger.Printf("Rewrapped %d data keys.", rotated)
+	return err
+}
+
+// runMigrate runs the embedded migrations as given by args: up, down, status,
+// redo or version.
+func runMigrate(cfg *config.Config, logger *log.Logger, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: migrate up|down|status|redo|version")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	m, err := database.NewMigrator(cfg.DatabaseUrl, logger)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch args[0] {
+	case "up":
+		return m.Up(ctx)
+	case "down":
+		return m.Down(ctx)
+	case "status":
+		return m.Status(ctx)
+	case "redo":
+		return m.Redo(ctx)
+	case "version":
+		return m.Version(ctx)
+	default:
+		return fmt.Errorf("unknown migrate command %q, expected up, down, status, redo or version", args[0])
+	}
+}
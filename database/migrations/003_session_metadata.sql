@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE sessions ADD COLUMN publicid   TEXT NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN user_agent TEXT NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN ip         TEXT NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN device     TEXT NOT NULL DEFAULT '';
+
+-- The session id doubles as the cookie value, so it must never leave the
+-- server. Sessions are addressed through a separate random handle instead.
+UPDATE sessions SET publicid = lower(hex(randomblob(16))) WHERE publicid = '';
+
+CREATE UNIQUE INDEX idx_sessions_publicid ON sessions(publicid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_sessions_publicid;
+ALTER TABLE sessions DROP COLUMN device;
+ALTER TABLE sessions DROP COLUMN ip;
+ALTER TABLE sessions DROP COLUMN user_agent;
+ALTER TABLE sessions DROP COLUMN publicid;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Sessions and verification tokens are now stored as SHA-256 hashes of the
+-- value handed to the client. Existing rows hold raw values that can no
+-- longer be matched, so they are dropped: users simply log in again, and
+-- unverified users receive a fresh verification mail on their next login.
+DELETE FROM sessions;
+UPDATE users SET verification_token = NULL WHERE verification_token IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM sessions;
+UPDATE users SET verification_token = NULL WHERE verification_token IS NOT NULL;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE api_tokens (
+  id         TEXT PRIMARY KEY,
+  userid     TEXT NOT NULL
+               REFERENCES users(id)
+               ON DELETE CASCADE ON UPDATE CASCADE,
+  name       TEXT NOT NULL CHECK (length(name) BETWEEN 1 AND 64),
+  -- SHA-256 of the secret shown to the user exactly once
+  tokenhash  TEXT NOT NULL UNIQUE,
+  -- Space separated list, e.g. 'exams:read exams:write'
+  scopes     TEXT NOT NULL,
+  created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  last_used  TEXT,
+  expires_at TEXT NOT NULL
+) STRICT;
+
+CREATE INDEX idx_api_tokens_user       ON api_tokens(userid);
+CREATE INDEX idx_api_tokens_expires_at ON api_tokens(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE api_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE verification_policies (
+  -- Email domain, or '*' for every domain without a policy of its own
+  domain        TEXT PRIMARY KEY,
+  kind          TEXT NOT NULL CHECK (kind IN ('semester','rolling','never')),
+  -- Comma separated 'MM-DD' cut-off dates of semester policies
+  boundaries    TEXT NOT NULL DEFAULT '',
+  -- Length of rolling policies
+  duration_days INTEGER,
+  created_at    TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  updated_at    TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  CHECK (kind != 'semester' OR boundaries != ''),
+  CHECK (kind != 'rolling' OR duration_days > 0)
+) STRICT;
+
+CREATE TRIGGER trg_verification_policies_update
+AFTER UPDATE ON verification_policies
+FOR EACH ROW
+BEGIN
+  UPDATE verification_policies
+     SET updated_at = strftime('%Y-%m-%dT%H:%M:%fZ','now')
+   WHERE domain = OLD.domain;
+END;
+
+-- Previously hard-coded: students re-verify every semester, members of the
+-- student council stay verified.
+INSERT INTO verification_policies (domain, kind, boundaries) VALUES
+('studmail.w-hs.de',        'semester', '03-01,10-01'),
+('fachschaftinformatik.de', 'never',    '');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE verification_policies;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- One row per reminder sent, keyed by the verification period it refers to,
+-- so that a user is reminded at most once per period even across restarts.
+CREATE TABLE verification_reminders (
+  userid         TEXT NOT NULL
+                   REFERENCES users(id)
+                   ON DELETE CASCADE ON UPDATE CASCADE,
+  verified_until TEXT NOT NULL,
+  sent_at        TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  PRIMARY KEY (userid, verified_until)
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE verification_reminders;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Posts, comments and exams of deleted accounts are reassigned to this
+-- placeholder, since their userid references are ON DELETE RESTRICT. It is
+-- inactive and its password hash matches no scheme, so nobody can log in.
+INSERT INTO users (id, email, name, password, role, active, verified, programid)
+SELECT '00000000-0000-0000-0000-000000000000', 'deleted@invalid', 'Gelöschter Account',
+       '!', 'user', 0, 0, MIN(id)
+FROM programs;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM users WHERE id = '00000000-0000-0000-0000-000000000000';
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE email_outbox ADD COLUMN text_body TEXT NOT NULL DEFAULT '';
+ALTER TABLE email_outbox ADD COLUMN unsubscribe TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE email_outbox DROP COLUMN unsubscribe;
+ALTER TABLE email_outbox DROP COLUMN text_body;
+-- +goose StatementEnd
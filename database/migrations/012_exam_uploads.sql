@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Uploads that were presigned but not finalized yet. Finalizing one turns it
+-- into a row of exams, the sweeper deletes the object of those that expire.
+CREATE TABLE exam_uploads (
+  id          TEXT PRIMARY KEY,
+  userid      TEXT NOT NULL
+                REFERENCES users(id)
+                ON DELETE CASCADE ON UPDATE CASCADE,
+  programid   INTEGER NOT NULL,
+  version     TEXT NOT NULL,
+  exam_date   TEXT NOT NULL,
+  accesskey   TEXT NOT NULL UNIQUE,
+  mime_type   TEXT NOT NULL CHECK (mime_type IN ('application/pdf')),
+  nbytes      INTEGER NOT NULL CHECK (nbytes > 0),
+  checksum    TEXT NOT NULL,
+  created_at  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  expires_at  TEXT NOT NULL,
+  FOREIGN KEY (programid, version) REFERENCES program_versions(programid, name) ON DELETE CASCADE ON UPDATE CASCADE
+) STRICT;
+
+CREATE INDEX idx_exam_uploads_expires ON exam_uploads(expires_at);
+CREATE INDEX idx_exam_uploads_user ON exam_uploads(userid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE exam_uploads;
+-- +goose StatementEnd
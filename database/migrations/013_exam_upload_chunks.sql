@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Chunks received for resumable uploads. They are stored as separate objects
+-- until finalizing the upload composes them into one.
+CREATE TABLE exam_upload_chunks (
+  uploadid    TEXT NOT NULL
+                REFERENCES exam_uploads(id)
+                ON DELETE CASCADE ON UPDATE CASCADE,
+  idx         INTEGER NOT NULL CHECK (idx >= 0),
+  nbytes      INTEGER NOT NULL CHECK (nbytes > 0),
+  checksum    TEXT NOT NULL,
+  received_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  PRIMARY KEY (uploadid, idx)
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE exam_upload_chunks;
+-- +goose StatementEnd
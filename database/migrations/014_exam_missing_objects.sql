@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Set by the storage reconciliation when the object of an exam is gone.
+ALTER TABLE exams ADD COLUMN missing_since TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE exams DROP COLUMN missing_since;
+-- +goose StatementEnd
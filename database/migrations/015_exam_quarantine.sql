@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Uploads the malware scanner flagged. Their objects are moved below
+-- quarantine/ until an admin releases or deletes them.
+CREATE TABLE exam_quarantine (
+  id              TEXT PRIMARY KEY,
+  userid          TEXT NOT NULL
+                    REFERENCES users(id)
+                    ON DELETE CASCADE ON UPDATE CASCADE,
+  programid       INTEGER NOT NULL,
+  version         TEXT NOT NULL,
+  exam_date       TEXT NOT NULL,
+  accesskey       TEXT NOT NULL UNIQUE,
+  mime_type       TEXT NOT NULL CHECK (mime_type IN ('application/pdf')),
+  nbytes          INTEGER NOT NULL CHECK (nbytes > 0),
+  checksum        TEXT NOT NULL,
+  signature       TEXT NOT NULL,
+  quarantined_at  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  FOREIGN KEY (programid, version) REFERENCES program_versions(programid, name) ON DELETE CASCADE ON UPDATE CASCADE
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE exam_quarantine;
+-- +goose StatementEnd
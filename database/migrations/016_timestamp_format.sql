@@ -0,0 +1,279 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The defaults of comments.updated_at, exams.uploaded_at and the sessions
+-- timestamps, and the trigger of comments, used '%Y-m-%d' instead of
+-- '%Y-%m-%d', which stores a literal 'm' instead of the month. The month of
+-- those values is lost, everything else is kept: the month is taken to be
+-- the latest one that results in a valid date no later than what is known
+-- about the row.
+
+-- The trigger would overwrite the repaired values.
+DROP TRIGGER trg_comments_update;
+
+CREATE TEMP TABLE timestamp_repairs (
+  broken TEXT NOT NULL,
+  fixed  TEXT NOT NULL
+);
+
+INSERT INTO timestamp_repairs (broken, fixed)
+WITH RECURSIVE months(n) AS (
+  SELECT 1 UNION ALL SELECT n + 1 FROM months WHERE n < 12
+),
+broken(value) AS (
+  SELECT updated_at FROM comments WHERE updated_at GLOB '????-m-*'
+  UNION SELECT uploaded_at FROM exams WHERE uploaded_at GLOB '????-m-*'
+  UNION SELECT created_at FROM sessions WHERE created_at GLOB '????-m-*'
+  UNION SELECT last_seen FROM sessions WHERE last_seen GLOB '????-m-*'
+),
+candidates(broken, fixed) AS (
+  SELECT value, substr(value, 1, 5) || printf('%02d', n) || substr(value, 7)
+    FROM broken, months
+)
+SELECT broken, fixed FROM candidates
+ WHERE date(julianday(fixed)) = substr(fixed, 1, 10);
+
+-- Comments are never edited, so the update happened no earlier than the
+-- creation of the comment.
+UPDATE comments
+   SET updated_at = coalesce((
+         SELECT max(fixed) FROM timestamp_repairs
+          WHERE broken = comments.updated_at
+            AND fixed >= comments.created_at
+            AND fixed <= strftime('%Y-%m-%dT%H:%M:%fZ','now')
+       ), created_at)
+ WHERE updated_at GLOB '????-m-*';
+
+UPDATE exams
+   SET uploaded_at = coalesce((
+         SELECT max(fixed) FROM timestamp_repairs
+          WHERE broken = exams.uploaded_at
+            AND fixed <= strftime('%Y-%m-%dT%H:%M:%fZ','now')
+       ), substr(uploaded_at, 1, 4) || '-01-01T00:00:00.000Z')
+ WHERE uploaded_at GLOB '????-m-*';
+
+-- expires_at was written by the server in local time, possibly with an
+-- offset, so it is normalized before it is compared.
+UPDATE sessions
+   SET expires_at = strftime('%Y-%m-%dT%H:%M:%fZ', expires_at);
+
+UPDATE sessions
+   SET last_seen = coalesce((
+         SELECT max(fixed) FROM timestamp_repairs
+          WHERE broken = sessions.last_seen
+            AND fixed <= min(sessions.expires_at, strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+       ), min(expires_at, strftime('%Y-%m-%dT%H:%M:%fZ','now')))
+ WHERE last_seen GLOB '????-m-*';
+
+UPDATE sessions
+   SET created_at = coalesce((
+         SELECT max(fixed) FROM timestamp_repairs
+          WHERE broken = sessions.created_at
+            AND fixed <= sessions.last_seen
+       ), last_seen)
+ WHERE created_at GLOB '????-m-*';
+
+DROP TABLE timestamp_repairs;
+
+-- The tables are rebuilt to fix the defaults and to only accept timestamps
+-- in the format written by strftime('%Y-%m-%dT%H:%M:%fZ', ...).
+CREATE TABLE comments_new (
+  id         TEXT PRIMARY KEY,
+  postid     TEXT NOT NULL
+               REFERENCES posts(id)
+               ON DELETE CASCADE ON UPDATE CASCADE,
+  userid     TEXT NOT NULL
+               REFERENCES users(id)
+               ON DELETE RESTRICT ON UPDATE CASCADE,
+  body       TEXT NOT NULL,
+  created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+               CHECK (created_at = strftime('%Y-%m-%dT%H:%M:%fZ', created_at)),
+  updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+               CHECK (updated_at = strftime('%Y-%m-%dT%H:%M:%fZ', updated_at))
+) STRICT;
+
+INSERT INTO comments_new (id, postid, userid, body, created_at, updated_at)
+SELECT id, postid, userid, body,
+       strftime('%Y-%m-%dT%H:%M:%fZ', created_at),
+       strftime('%Y-%m-%dT%H:%M:%fZ', updated_at)
+  FROM comments;
+
+DROP TABLE comments;
+ALTER TABLE comments_new RENAME TO comments;
+
+CREATE INDEX idx_comments_post        ON comments(postid);
+CREATE INDEX idx_comments_user        ON comments(userid);
+CREATE INDEX idx_comments_created_at  ON comments(created_at DESC);
+
+CREATE TRIGGER trg_comments_update
+AFTER UPDATE ON comments
+FOR EACH ROW
+BEGIN
+  UPDATE comments
+     SET updated_at = strftime('%Y-%m-%dT%H:%M:%fZ','now')
+   WHERE id = OLD.id;
+END;
+
+CREATE TABLE exams_new (
+  id            TEXT PRIMARY KEY,
+  userid        TEXT NOT NULL
+                  REFERENCES users(id)
+                  ON DELETE RESTRICT ON UPDATE CASCADE,
+  programid     INTEGER NOT NULL,
+  version       TEXT NOT NULL,
+  exam_date     TEXT NOT NULL,
+  uploaded_at   TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+                  CHECK (uploaded_at = strftime('%Y-%m-%dT%H:%M:%fZ', uploaded_at)),
+  accesskey     TEXT NOT NULL UNIQUE,
+  mime_type     TEXT NOT NULL CHECK (mime_type IN ('application/pdf')),
+  nbytes        INTEGER NOT NULL,
+  checksum      TEXT NOT NULL,
+  -- Set by the storage reconciliation when the object of an exam is gone.
+  missing_since TEXT,
+  -- Enforce that the (programid, version) tuple actually exists in the valid versions table
+  FOREIGN KEY (programid, version) REFERENCES program_versions(programid, name) ON DELETE RESTRICT ON UPDATE CASCADE
+) STRICT;
+
+INSERT INTO exams_new (id, userid, programid, version, exam_date, uploaded_at, accesskey, mime_type, nbytes, checksum, missing_since)
+SELECT id, userid, programid, version, exam_date,
+       strftime('%Y-%m-%dT%H:%M:%fZ', uploaded_at),
+       accesskey, mime_type, nbytes, checksum, missing_since
+  FROM exams;
+
+DROP TABLE exams;
+ALTER TABLE exams_new RENAME TO exams;
+
+CREATE INDEX idx_exams_date ON exams(programid, exam_date DESC);
+CREATE INDEX idx_exams_user ON exams(userid);
+
+CREATE TRIGGER trg_exams_set_update
+AFTER UPDATE ON exams
+FOR EACH ROW
+BEGIN
+  UPDATE exams
+     SET uploaded_at = uploaded_at,
+         accesskey   = accesskey
+   WHERE id = OLD.id;
+END;
+
+CREATE TABLE sessions_new (
+  id         TEXT PRIMARY KEY,
+  userid     TEXT NOT NULL
+               REFERENCES users(id)
+               ON DELETE CASCADE ON UPDATE CASCADE,
+  created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+               CHECK (created_at = strftime('%Y-%m-%dT%H:%M:%fZ', created_at)),
+  last_seen  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+               CHECK (last_seen = strftime('%Y-%m-%dT%H:%M:%fZ', last_seen)),
+  expires_at TEXT NOT NULL
+               CHECK (expires_at = strftime('%Y-%m-%dT%H:%M:%fZ', expires_at)),
+  publicid   TEXT NOT NULL DEFAULT '',
+  user_agent TEXT NOT NULL DEFAULT '',
+  ip         TEXT NOT NULL DEFAULT '',
+  device     TEXT NOT NULL DEFAULT ''
+) STRICT;
+
+INSERT INTO sessions_new (id, userid, created_at, last_seen, expires_at, publicid, user_agent, ip, device)
+SELECT id, userid,
+       strftime('%Y-%m-%dT%H:%M:%fZ', created_at),
+       strftime('%Y-%m-%dT%H:%M:%fZ', last_seen),
+       expires_at,
+       publicid, user_agent, ip, device
+  FROM sessions;
+
+DROP TABLE sessions;
+ALTER TABLE sessions_new RENAME TO sessions;
+
+CREATE INDEX idx_sessions_user        ON sessions(userid);
+CREATE INDEX idx_sessions_expires_at  ON sessions(expires_at);
+CREATE UNIQUE INDEX idx_sessions_publicid ON sessions(publicid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Only the constraints are removed, the repaired timestamps are kept.
+CREATE TABLE comments_old (
+  id         TEXT PRIMARY KEY,
+  postid     TEXT NOT NULL
+               REFERENCES posts(id)
+               ON DELETE CASCADE ON UPDATE CASCADE,
+  userid     TEXT NOT NULL
+               REFERENCES users(id)
+               ON DELETE RESTRICT ON UPDATE CASCADE,
+  body       TEXT NOT NULL,
+  created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+) STRICT;
+
+INSERT INTO comments_old SELECT id, postid, userid, body, created_at, updated_at FROM comments;
+DROP TABLE comments;
+ALTER TABLE comments_old RENAME TO comments;
+
+CREATE INDEX idx_comments_post        ON comments(postid);
+CREATE INDEX idx_comments_user        ON comments(userid);
+CREATE INDEX idx_comments_created_at  ON comments(created_at DESC);
+
+CREATE TRIGGER trg_comments_update
+AFTER UPDATE ON comments
+FOR EACH ROW
+BEGIN
+  UPDATE comments
+     SET updated_at = strftime('%Y-%m-%dT%H:%M:%fZ','now')
+   WHERE id = OLD.id;
+END;
+
+CREATE TABLE exams_old (
+  id            TEXT PRIMARY KEY,
+  userid        TEXT NOT NULL
+                  REFERENCES users(id)
+                  ON DELETE RESTRICT ON UPDATE CASCADE,
+  programid     INTEGER NOT NULL,
+  version       TEXT NOT NULL,
+  exam_date     TEXT NOT NULL,
+  uploaded_at   TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  accesskey     TEXT NOT NULL UNIQUE,
+  mime_type     TEXT NOT NULL CHECK (mime_type IN ('application/pdf')),
+  nbytes        INTEGER NOT NULL,
+  checksum      TEXT NOT NULL,
+  missing_since TEXT,
+  FOREIGN KEY (programid, version) REFERENCES program_versions(programid, name) ON DELETE RESTRICT ON UPDATE CASCADE
+) STRICT;
+
+INSERT INTO exams_old SELECT id, userid, programid, version, exam_date, uploaded_at, accesskey, mime_type, nbytes, checksum, missing_since FROM exams;
+DROP TABLE exams;
+ALTER TABLE exams_old RENAME TO exams;
+
+CREATE INDEX idx_exams_date ON exams(programid, exam_date DESC);
+CREATE INDEX idx_exams_user ON exams(userid);
+
+CREATE TRIGGER trg_exams_set_update
+AFTER UPDATE ON exams
+FOR EACH ROW
+BEGIN
+  UPDATE exams
+     SET uploaded_at = uploaded_at,
+         accesskey   = accesskey
+   WHERE id = OLD.id;
+END;
+
+CREATE TABLE sessions_old (
+  id         TEXT PRIMARY KEY,
+  userid     TEXT NOT NULL
+               REFERENCES users(id)
+               ON DELETE CASCADE ON UPDATE CASCADE,
+  created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  last_seen  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  expires_at TEXT NOT NULL,
+  publicid   TEXT NOT NULL DEFAULT '',
+  user_agent TEXT NOT NULL DEFAULT '',
+  ip         TEXT NOT NULL DEFAULT '',
+  device     TEXT NOT NULL DEFAULT ''
+) STRICT;
+
+INSERT INTO sessions_old SELECT id, userid, created_at, last_seen, expires_at, publicid, user_agent, ip, device FROM sessions;
+DROP TABLE sessions;
+ALTER TABLE sessions_old RENAME TO sessions;
+
+CREATE INDEX idx_sessions_user        ON sessions(userid);
+CREATE INDEX idx_sessions_expires_at  ON sessions(expires_at);
+CREATE UNIQUE INDEX idx_sessions_publicid ON sessions(publicid);
+-- +goose StatementEnd
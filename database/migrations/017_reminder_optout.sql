@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Re-verification reminders are notification mails, which users can opt out
+-- of through the List-Unsubscribe link in every reminder.
+ALTER TABLE users ADD COLUMN reminders INTEGER NOT NULL DEFAULT 1 CHECK (reminders IN (0,1));
+-- Hash of the token in the link of the latest reminder.
+ALTER TABLE users ADD COLUMN unsubscribe_token TEXT;
+CREATE UNIQUE INDEX idx_users_unsubscribe_token ON users(unsubscribe_token);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_users_unsubscribe_token;
+ALTER TABLE users DROP COLUMN unsubscribe_token;
+ALTER TABLE users DROP COLUMN reminders;
+-- +goose StatementEnd
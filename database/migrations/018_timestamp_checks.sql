@@ -0,0 +1,579 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The server wrote expiry times, verified_until, missing_since and the next
+-- attempt of outgoing mails with time.RFC3339, without milliseconds and
+-- possibly with an offset, so they did not compare correctly as text with
+-- timestamps written by strftime('%Y-%m-%dT%H:%M:%fZ', ...). The values are
+-- normalized and the tables rebuilt to only accept that format, like in
+-- 016_timestamp_format.sql. The creation and update times of users, posts
+-- and verification policies were only written by the database, but get the
+-- same CHECK, so that the server reads every timestamp as database.Time.
+--
+-- Dropping users would delete or block on the rows referencing it, so this
+-- relies on the migrator running with foreign keys off.
+
+-- Values that cannot be parsed are replaced by the current time, so that
+-- tokens, uploads and verifications with a broken expiry expire rather
+-- than last forever.
+CREATE TABLE api_tokens_new (
+  id         TEXT PRIMARY KEY,
+  userid     TEXT NOT NULL
+               REFERENCES users(id)
+               ON DELETE CASCADE ON UPDATE CASCADE,
+  name       TEXT NOT NULL CHECK (length(name) BETWEEN 1 AND 64),
+  -- SHA-256 of the secret shown to the user exactly once
+  tokenhash  TEXT NOT NULL UNIQUE,
+  -- Space separated list, e.g. 'exams:read exams:write'
+  scopes     TEXT NOT NULL,
+  created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+               CHECK (created_at = strftime('%Y-%m-%dT%H:%M:%fZ', created_at)),
+  last_used  TEXT
+               CHECK (last_used = strftime('%Y-%m-%dT%H:%M:%fZ', last_used)),
+  expires_at TEXT NOT NULL
+               CHECK (expires_at = strftime('%Y-%m-%dT%H:%M:%fZ', expires_at))
+) STRICT;
+
+INSERT INTO api_tokens_new (id, userid, name, tokenhash, scopes, created_at, last_used, expires_at)
+SELECT id, userid, name, tokenhash, scopes,
+       strftime('%Y-%m-%dT%H:%M:%fZ', created_at),
+       strftime('%Y-%m-%dT%H:%M:%fZ', last_used),
+       coalesce(strftime('%Y-%m-%dT%H:%M:%fZ', expires_at), strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+  FROM api_tokens;
+
+DROP TABLE api_tokens;
+ALTER TABLE api_tokens_new RENAME TO api_tokens;
+
+CREATE INDEX idx_api_tokens_user       ON api_tokens(userid);
+CREATE INDEX idx_api_tokens_expires_at ON api_tokens(expires_at);
+
+CREATE TABLE email_outbox_new (
+  id              TEXT PRIMARY KEY,
+  recipient       TEXT NOT NULL,
+  subject         TEXT NOT NULL,
+  -- Cleared once sent, since it may contain verification links
+  body            TEXT NOT NULL,
+  status          TEXT NOT NULL DEFAULT 'pending'
+                    CHECK (status IN ('pending','sending','sent','dead')),
+  attempts        INTEGER NOT NULL DEFAULT 0,
+  last_error      TEXT,
+  next_attempt_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+                    CHECK (next_attempt_at = strftime('%Y-%m-%dT%H:%M:%fZ', next_attempt_at)),
+  created_at      TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+                    CHECK (created_at = strftime('%Y-%m-%dT%H:%M:%fZ', created_at)),
+  sent_at         TEXT
+                    CHECK (sent_at = strftime('%Y-%m-%dT%H:%M:%fZ', sent_at)),
+  text_body       TEXT NOT NULL DEFAULT '',
+  unsubscribe     TEXT NOT NULL DEFAULT ''
+) STRICT;
+
+INSERT INTO email_outbox_new (id, recipient, subject, body, status, attempts, last_error, next_attempt_at, created_at, sent_at, text_body, unsubscribe)
+SELECT id, recipient, subject, body, status, attempts, last_error,
+       coalesce(strftime('%Y-%m-%dT%H:%M:%fZ', next_attempt_at), strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+       strftime('%Y-%m-%dT%H:%M:%fZ', created_at),
+       strftime('%Y-%m-%dT%H:%M:%fZ', sent_at),
+       text_body, unsubscribe
+  FROM email_outbox;
+
+DROP TABLE email_outbox;
+ALTER TABLE email_outbox_new RENAME TO email_outbox;
+
+CREATE INDEX idx_email_outbox_due        ON email_outbox(status, next_attempt_at);
+CREATE INDEX idx_email_outbox_created_at ON email_outbox(created_at DESC);
+
+CREATE TABLE exam_quarantine_new (
+  id              TEXT PRIMARY KEY,
+  userid          TEXT NOT NULL
+                    REFERENCES users(id)
+                    ON DELETE CASCADE ON UPDATE CASCADE,
+  programid       INTEGER NOT NULL,
+  version         TEXT NOT NULL,
+  exam_date       TEXT NOT NULL,
+  accesskey       TEXT NOT NULL UNIQUE,
+  mime_type       TEXT NOT NULL CHECK (mime_type IN ('application/pdf')),
+  nbytes          INTEGER NOT NULL CHECK (nbytes > 0),
+  checksum        TEXT NOT NULL,
+  signature       TEXT NOT NULL,
+  quarantined_at  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+                    CHECK (quarantined_at = strftime('%Y-%m-%dT%H:%M:%fZ', quarantined_at)),
+  FOREIGN KEY (programid, version) REFERENCES program_versions(programid, name) ON DELETE CASCADE ON UPDATE CASCADE
+) STRICT;
+
+INSERT INTO exam_quarantine_new (id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum, signature, quarantined_at)
+SELECT id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum, signature,
+       coalesce(strftime('%Y-%m-%dT%H:%M:%fZ', quarantined_at), strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+  FROM exam_quarantine;
+
+DROP TABLE exam_quarantine;
+ALTER TABLE exam_quarantine_new RENAME TO exam_quarantine;
+
+CREATE TABLE exam_uploads_new (
+  id          TEXT PRIMARY KEY,
+  userid      TEXT NOT NULL
+                REFERENCES users(id)
+                ON DELETE CASCADE ON UPDATE CASCADE,
+  programid   INTEGER NOT NULL,
+  version     TEXT NOT NULL,
+  exam_date   TEXT NOT NULL,
+  accesskey   TEXT NOT NULL UNIQUE,
+  mime_type   TEXT NOT NULL CHECK (mime_type IN ('application/pdf')),
+  nbytes      INTEGER NOT NULL CHECK (nbytes > 0),
+  checksum    TEXT NOT NULL,
+  created_at  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+                CHECK (created_at = strftime('%Y-%m-%dT%H:%M:%fZ', created_at)),
+  expires_at  TEXT NOT NULL
+                CHECK (expires_at = strftime('%Y-%m-%dT%H:%M:%fZ', expires_at)),
+  FOREIGN KEY (programid, version) REFERENCES program_versions(programid, name) ON DELETE CASCADE ON UPDATE CASCADE
+) STRICT;
+
+INSERT INTO exam_uploads_new (id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum, created_at, expires_at)
+SELECT id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum,
+       strftime('%Y-%m-%dT%H:%M:%fZ', created_at),
+       coalesce(strftime('%Y-%m-%dT%H:%M:%fZ', expires_at), strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+  FROM exam_uploads;
+
+DROP TABLE exam_uploads;
+ALTER TABLE exam_uploads_new RENAME TO exam_uploads;
+
+CREATE INDEX idx_exam_uploads_expires ON exam_uploads(expires_at);
+CREATE INDEX idx_exam_uploads_user ON exam_uploads(userid);
+
+CREATE TABLE exams_new (
+  id            TEXT PRIMARY KEY,
+  userid        TEXT NOT NULL
+                  REFERENCES users(id)
+                  ON DELETE RESTRICT ON UPDATE CASCADE,
+  programid     INTEGER NOT NULL,
+  version       TEXT NOT NULL,
+  exam_date     TEXT NOT NULL,
+  uploaded_at   TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+                  CHECK (uploaded_at = strftime('%Y-%m-%dT%H:%M:%fZ', uploaded_at)),
+  accesskey     TEXT NOT NULL UNIQUE,
+  mime_type     TEXT NOT NULL CHECK (mime_type IN ('application/pdf')),
+  nbytes        INTEGER NOT NULL,
+  checksum      TEXT NOT NULL,
+  -- Set by the storage reconciliation when the object of an exam is gone.
+  missing_since TEXT
+                  CHECK (missing_since = strftime('%Y-%m-%dT%H:%M:%fZ', missing_since)),
+  -- Enforce that the (programid, version) tuple actually exists in the valid versions table
+  FOREIGN KEY (programid, version) REFERENCES program_versions(programid, name) ON DELETE RESTRICT ON UPDATE CASCADE
+) STRICT;
+
+-- A missing_since that cannot be parsed starts the grace period anew.
+INSERT INTO exams_new (id, userid, programid, version, exam_date, uploaded_at, accesskey, mime_type, nbytes, checksum, missing_since)
+SELECT id, userid, programid, version, exam_date, uploaded_at, accesskey, mime_type, nbytes, checksum,
+       CASE WHEN missing_since IS NOT NULL
+            THEN coalesce(strftime('%Y-%m-%dT%H:%M:%fZ', missing_since), strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+       END
+  FROM exams;
+
+DROP TABLE exams;
+ALTER TABLE exams_new RENAME TO exams;
+
+CREATE INDEX idx_exams_date ON exams(programid, exam_date DESC);
+CREATE INDEX idx_exams_user ON exams(userid);
+
+CREATE TRIGGER trg_exams_set_update
+AFTER UPDATE ON exams
+FOR EACH ROW
+BEGIN
+  UPDATE exams
+     SET uploaded_at = uploaded_at,
+         accesskey   = accesskey
+   WHERE id = OLD.id;
+END;
+
+CREATE TABLE posts_new (
+  id         TEXT PRIMARY KEY,
+  userid     TEXT NOT NULL
+               REFERENCES users(id)
+               ON DELETE RESTRICT ON UPDATE CASCADE,
+  title      TEXT NOT NULL,
+  body       TEXT NOT NULL,
+  created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+               CHECK (created_at = strftime('%Y-%m-%dT%H:%M:%fZ', created_at)),
+  updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+               CHECK (updated_at = strftime('%Y-%m-%dT%H:%M:%fZ', updated_at)),
+  deleted    TEXT
+               CHECK (deleted = strftime('%Y-%m-%dT%H:%M:%fZ', deleted))
+) STRICT;
+
+-- A deleted that cannot be parsed keeps the post deleted.
+INSERT INTO posts_new (id, userid, title, body, created_at, updated_at, deleted)
+SELECT id, userid, title, body,
+       coalesce(strftime('%Y-%m-%dT%H:%M:%fZ', created_at), strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+       coalesce(strftime('%Y-%m-%dT%H:%M:%fZ', updated_at), strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+       CASE WHEN deleted IS NOT NULL
+            THEN coalesce(strftime('%Y-%m-%dT%H:%M:%fZ', deleted), strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+       END
+  FROM posts;
+
+DROP TABLE posts;
+ALTER TABLE posts_new RENAME TO posts;
+
+CREATE INDEX idx_posts_user       ON posts(userid);
+CREATE INDEX idx_posts_created_at ON posts(created_at DESC);
+
+CREATE TRIGGER trg_posts_update
+AFTER UPDATE ON posts
+FOR EACH ROW
+BEGIN
+  UPDATE posts
+     SET updated_at = strftime('%Y-%m-%dT%H:%M:%fZ','now')
+   WHERE id = OLD.id;
+END;
+
+CREATE TABLE users_new (
+  id                 TEXT PRIMARY KEY,
+  email              TEXT NOT NULL,
+  name               TEXT NOT NULL,
+  password           TEXT NOT NULL,
+  role               TEXT NOT NULL DEFAULT 'user' CHECK (role IN ('user','editor','admin')),
+  active             INTEGER NOT NULL DEFAULT 0 CHECK (active IN (0,1)),
+  verified           INTEGER NOT NULL DEFAULT 0 CHECK (verified IN (0,1)),
+  verified_at        TEXT
+                       CHECK (verified_at = strftime('%Y-%m-%dT%H:%M:%fZ', verified_at)),
+  verified_until     TEXT
+                       CHECK (verified_until = strftime('%Y-%m-%dT%H:%M:%fZ', verified_until)),
+  programid          INTEGER NOT NULL
+                       REFERENCES programs(id)
+                       ON DELETE RESTRICT ON UPDATE CASCADE,
+  created_at         TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+                       CHECK (created_at = strftime('%Y-%m-%dT%H:%M:%fZ', created_at)),
+  updated_at         TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+                       CHECK (updated_at = strftime('%Y-%m-%dT%H:%M:%fZ', updated_at)),
+  verification_token TEXT,
+  locale             TEXT NOT NULL DEFAULT 'de' CHECK (locale IN ('de','en')),
+  reminders          INTEGER NOT NULL DEFAULT 1 CHECK (reminders IN (0,1)),
+  unsubscribe_token  TEXT,
+  CHECK (verified = 0 OR verified_at IS NOT NULL),
+  CHECK (verified_at IS NULL OR verified_until IS NULL OR verified_until >= verified_at)
+) STRICT;
+
+-- verified_until is kept no earlier than verified_at, as the table requires.
+INSERT INTO users_new (id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token, locale, reminders, unsubscribe_token)
+SELECT id, email, name, password, role, active, verified,
+       strftime('%Y-%m-%dT%H:%M:%fZ', verified_at),
+       CASE WHEN verified_until IS NOT NULL
+            THEN max(coalesce(strftime('%Y-%m-%dT%H:%M:%fZ', verified_until), strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+                     coalesce(strftime('%Y-%m-%dT%H:%M:%fZ', verified_at), ''))
+       END,
+       programid,
+       coalesce(strftime('%Y-%m-%dT%H:%M:%fZ', created_at), strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+       coalesce(strftime('%Y-%m-%dT%H:%M:%fZ', updated_at), strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+       verification_token, locale, reminders, unsubscribe_token
+  FROM users;
+
+DROP TABLE users;
+ALTER TABLE users_new RENAME TO users;
+
+CREATE UNIQUE INDEX users_email_unique ON users(lower(email));
+CREATE INDEX idx_users_program         ON users(programid);
+CREATE INDEX idx_users_verified_until  ON users(verified_until);
+CREATE INDEX idx_users_verification_token ON users(verification_token);
+CREATE UNIQUE INDEX idx_users_unsubscribe_token ON users(unsubscribe_token);
+
+CREATE TRIGGER trg_users_update
+AFTER UPDATE ON users
+FOR EACH ROW
+BEGIN
+  UPDATE users
+     SET updated_at = strftime('%Y-%m-%dT%H:%M:%fZ','now')
+   WHERE id = OLD.id;
+END;
+
+CREATE TABLE verification_policies_new (
+  -- Email domain, or '*' for every domain without a policy of its own
+  domain        TEXT PRIMARY KEY,
+  kind          TEXT NOT NULL CHECK (kind IN ('semester','rolling','never')),
+  -- Comma separated 'MM-DD' cut-off dates of semester policies
+  boundaries    TEXT NOT NULL DEFAULT '',
+  -- Length of rolling policies
+  duration_days INTEGER,
+  created_at    TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+                  CHECK (created_at = strftime('%Y-%m-%dT%H:%M:%fZ', created_at)),
+  updated_at    TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+                  CHECK (updated_at = strftime('%Y-%m-%dT%H:%M:%fZ', updated_at)),
+  CHECK (kind != 'semester' OR boundaries != ''),
+  CHECK (kind != 'rolling' OR duration_days > 0)
+) STRICT;
+
+INSERT INTO verification_policies_new (domain, kind, boundaries, duration_days, created_at, updated_at)
+SELECT domain, kind, boundaries, duration_days,
+       coalesce(strftime('%Y-%m-%dT%H:%M:%fZ', created_at), strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+       coalesce(strftime('%Y-%m-%dT%H:%M:%fZ', updated_at), strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+  FROM verification_policies;
+
+DROP TABLE verification_policies;
+ALTER TABLE verification_policies_new RENAME TO verification_policies;
+
+CREATE TRIGGER trg_verification_policies_update
+AFTER UPDATE ON verification_policies
+FOR EACH ROW
+BEGIN
+  UPDATE verification_policies
+     SET updated_at = strftime('%Y-%m-%dT%H:%M:%fZ','now')
+   WHERE domain = OLD.domain;
+END;
+
+-- Reminders are matched with users.verified_until by equality, so their
+-- verified_until is normalized the same way. Values that differed only in
+-- their format become one reminder.
+CREATE TABLE verification_reminders_new (
+  userid         TEXT NOT NULL
+                   REFERENCES users(id)
+                   ON DELETE CASCADE ON UPDATE CASCADE,
+  verified_until TEXT NOT NULL
+                   CHECK (verified_until = strftime('%Y-%m-%dT%H:%M:%fZ', verified_until)),
+  sent_at        TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+                   CHECK (sent_at = strftime('%Y-%m-%dT%H:%M:%fZ', sent_at)),
+  PRIMARY KEY (userid, verified_until)
+) STRICT;
+
+INSERT OR IGNORE INTO verification_reminders_new (userid, verified_until, sent_at)
+SELECT userid,
+       strftime('%Y-%m-%dT%H:%M:%fZ', verified_until),
+       strftime('%Y-%m-%dT%H:%M:%fZ', sent_at)
+  FROM verification_reminders
+ WHERE strftime('%Y-%m-%dT%H:%M:%fZ', verified_until) IS NOT NULL;
+
+DROP TABLE verification_reminders;
+ALTER TABLE verification_reminders_new RENAME TO verification_reminders;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Only the constraints are removed, the normalized timestamps are kept.
+
+CREATE TABLE api_tokens_old (
+  id         TEXT PRIMARY KEY,
+  userid     TEXT NOT NULL
+               REFERENCES users(id)
+               ON DELETE CASCADE ON UPDATE CASCADE,
+  name       TEXT NOT NULL CHECK (length(name) BETWEEN 1 AND 64),
+  tokenhash  TEXT NOT NULL UNIQUE,
+  scopes     TEXT NOT NULL,
+  created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  last_used  TEXT,
+  expires_at TEXT NOT NULL
+) STRICT;
+
+INSERT INTO api_tokens_old SELECT id, userid, name, tokenhash, scopes, created_at, last_used, expires_at FROM api_tokens;
+DROP TABLE api_tokens;
+ALTER TABLE api_tokens_old RENAME TO api_tokens;
+
+CREATE INDEX idx_api_tokens_user       ON api_tokens(userid);
+CREATE INDEX idx_api_tokens_expires_at ON api_tokens(expires_at);
+
+CREATE TABLE email_outbox_old (
+  id              TEXT PRIMARY KEY,
+  recipient       TEXT NOT NULL,
+  subject         TEXT NOT NULL,
+  body            TEXT NOT NULL,
+  status          TEXT NOT NULL DEFAULT 'pending'
+                    CHECK (status IN ('pending','sending','sent','dead')),
+  attempts        INTEGER NOT NULL DEFAULT 0,
+  last_error      TEXT,
+  next_attempt_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  created_at      TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  sent_at         TEXT,
+  text_body       TEXT NOT NULL DEFAULT '',
+  unsubscribe     TEXT NOT NULL DEFAULT ''
+) STRICT;
+
+INSERT INTO email_outbox_old SELECT id, recipient, subject, body, status, attempts, last_error, next_attempt_at, created_at, sent_at, text_body, unsubscribe FROM email_outbox;
+DROP TABLE email_outbox;
+ALTER TABLE email_outbox_old RENAME TO email_outbox;
+
+CREATE INDEX idx_email_outbox_due        ON email_outbox(status, next_attempt_at);
+CREATE INDEX idx_email_outbox_created_at ON email_outbox(created_at DESC);
+
+CREATE TABLE exam_quarantine_old (
+  id              TEXT PRIMARY KEY,
+  userid          TEXT NOT NULL
+                    REFERENCES users(id)
+                    ON DELETE CASCADE ON UPDATE CASCADE,
+  programid       INTEGER NOT NULL,
+  version         TEXT NOT NULL,
+  exam_date       TEXT NOT NULL,
+  accesskey       TEXT NOT NULL UNIQUE,
+  mime_type       TEXT NOT NULL CHECK (mime_type IN ('application/pdf')),
+  nbytes          INTEGER NOT NULL CHECK (nbytes > 0),
+  checksum        TEXT NOT NULL,
+  signature       TEXT NOT NULL,
+  quarantined_at  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  FOREIGN KEY (programid, version) REFERENCES program_versions(programid, name) ON DELETE CASCADE ON UPDATE CASCADE
+) STRICT;
+
+INSERT INTO exam_quarantine_old SELECT id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum, signature, quarantined_at FROM exam_quarantine;
+DROP TABLE exam_quarantine;
+ALTER TABLE exam_quarantine_old RENAME TO exam_quarantine;
+
+CREATE TABLE exam_uploads_old (
+  id          TEXT PRIMARY KEY,
+  userid      TEXT NOT NULL
+                REFERENCES users(id)
+                ON DELETE CASCADE ON UPDATE CASCADE,
+  programid   INTEGER NOT NULL,
+  version     TEXT NOT NULL,
+  exam_date   TEXT NOT NULL,
+  accesskey   TEXT NOT NULL UNIQUE,
+  mime_type   TEXT NOT NULL CHECK (mime_type IN ('application/pdf')),
+  nbytes      INTEGER NOT NULL CHECK (nbytes > 0),
+  checksum    TEXT NOT NULL,
+  created_at  TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  expires_at  TEXT NOT NULL,
+  FOREIGN KEY (programid, version) REFERENCES program_versions(programid, name) ON DELETE CASCADE ON UPDATE CASCADE
+) STRICT;
+
+INSERT INTO exam_uploads_old SELECT id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum, created_at, expires_at FROM exam_uploads;
+DROP TABLE exam_uploads;
+ALTER TABLE exam_uploads_old RENAME TO exam_uploads;
+
+CREATE INDEX idx_exam_uploads_expires ON exam_uploads(expires_at);
+CREATE INDEX idx_exam_uploads_user ON exam_uploads(userid);
+
+CREATE TABLE exams_old (
+  id            TEXT PRIMARY KEY,
+  userid        TEXT NOT NULL
+                  REFERENCES users(id)
+                  ON DELETE RESTRICT ON UPDATE CASCADE,
+  programid     INTEGER NOT NULL,
+  version       TEXT NOT NULL,
+  exam_date     TEXT NOT NULL,
+  uploaded_at   TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now'))
+                  CHECK (uploaded_at = strftime('%Y-%m-%dT%H:%M:%fZ', uploaded_at)),
+  accesskey     TEXT NOT NULL UNIQUE,
+  mime_type     TEXT NOT NULL CHECK (mime_type IN ('application/pdf')),
+  nbytes        INTEGER NOT NULL,
+  checksum      TEXT NOT NULL,
+  missing_since TEXT,
+  FOREIGN KEY (programid, version) REFERENCES program_versions(programid, name) ON DELETE RESTRICT ON UPDATE CASCADE
+) STRICT;
+
+INSERT INTO exams_old SELECT id, userid, programid, version, exam_date, uploaded_at, accesskey, mime_type, nbytes, checksum, missing_since FROM exams;
+DROP TABLE exams;
+ALTER TABLE exams_old RENAME TO exams;
+
+CREATE INDEX idx_exams_date ON exams(programid, exam_date DESC);
+CREATE INDEX idx_exams_user ON exams(userid);
+
+CREATE TRIGGER trg_exams_set_update
+AFTER UPDATE ON exams
+FOR EACH ROW
+BEGIN
+  UPDATE exams
+     SET uploaded_at = uploaded_at,
+         accesskey   = accesskey
+   WHERE id = OLD.id;
+END;
+
+CREATE TABLE posts_old (
+  id         TEXT PRIMARY KEY,
+  userid     TEXT NOT NULL
+               REFERENCES users(id)
+               ON DELETE RESTRICT ON UPDATE CASCADE,
+  title      TEXT NOT NULL,
+  body       TEXT NOT NULL,
+  created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  deleted    TEXT
+) STRICT;
+
+INSERT INTO posts_old SELECT id, userid, title, body, created_at, updated_at, deleted FROM posts;
+DROP TABLE posts;
+ALTER TABLE posts_old RENAME TO posts;
+
+CREATE INDEX idx_posts_user       ON posts(userid);
+CREATE INDEX idx_posts_created_at ON posts(created_at DESC);
+
+CREATE TRIGGER trg_posts_update
+AFTER UPDATE ON posts
+FOR EACH ROW
+BEGIN
+  UPDATE posts
+     SET updated_at = strftime('%Y-%m-%dT%H:%M:%fZ','now')
+   WHERE id = OLD.id;
+END;
+
+CREATE TABLE users_old (
+  id                 TEXT PRIMARY KEY,
+  email              TEXT NOT NULL,
+  name               TEXT NOT NULL,
+  password           TEXT NOT NULL,
+  role               TEXT NOT NULL DEFAULT 'user' CHECK (role IN ('user','editor','admin')),
+  active             INTEGER NOT NULL DEFAULT 0 CHECK (active IN (0,1)),
+  verified           INTEGER NOT NULL DEFAULT 0 CHECK (verified IN (0,1)),
+  verified_at        TEXT,
+  verified_until     TEXT,
+  programid          INTEGER NOT NULL
+                       REFERENCES programs(id)
+                       ON DELETE RESTRICT ON UPDATE CASCADE,
+  created_at         TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  updated_at         TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  verification_token TEXT,
+  locale             TEXT NOT NULL DEFAULT 'de' CHECK (locale IN ('de','en')),
+  reminders          INTEGER NOT NULL DEFAULT 1 CHECK (reminders IN (0,1)),
+  unsubscribe_token  TEXT,
+  CHECK (verified = 0 OR verified_at IS NOT NULL),
+  CHECK (verified_at IS NULL OR verified_until IS NULL OR verified_until >= verified_at)
+) STRICT;
+
+INSERT INTO users_old SELECT id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token, locale, reminders, unsubscribe_token FROM users;
+DROP TABLE users;
+ALTER TABLE users_old RENAME TO users;
+
+CREATE UNIQUE INDEX users_email_unique ON users(lower(email));
+CREATE INDEX idx_users_program         ON users(programid);
+CREATE INDEX idx_users_verified_until  ON users(verified_until);
+CREATE INDEX idx_users_verification_token ON users(verification_token);
+CREATE UNIQUE INDEX idx_users_unsubscribe_token ON users(unsubscribe_token);
+
+CREATE TRIGGER trg_users_update
+AFTER UPDATE ON users
+FOR EACH ROW
+BEGIN
+  UPDATE users
+     SET updated_at = strftime('%Y-%m-%dT%H:%M:%fZ','now')
+   WHERE id = OLD.id;
+END;
+
+CREATE TABLE verification_policies_old (
+  -- Email domain, or '*' for every domain without a policy of its own
+  domain        TEXT PRIMARY KEY,
+  kind          TEXT NOT NULL CHECK (kind IN ('semester','rolling','never')),
+  -- Comma separated 'MM-DD' cut-off dates of semester policies
+  boundaries    TEXT NOT NULL DEFAULT '',
+  -- Length of rolling policies
+  duration_days INTEGER,
+  created_at    TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  updated_at    TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  CHECK (kind != 'semester' OR boundaries != ''),
+  CHECK (kind != 'rolling' OR duration_days > 0)
+) STRICT;
+
+INSERT INTO verification_policies_old SELECT domain, kind, boundaries, duration_days, created_at, updated_at FROM verification_policies;
+DROP TABLE verification_policies;
+ALTER TABLE verification_policies_old RENAME TO verification_policies;
+
+CREATE TRIGGER trg_verification_policies_update
+AFTER UPDATE ON verification_policies
+FOR EACH ROW
+BEGIN
+  UPDATE verification_policies
+     SET updated_at = strftime('%Y-%m-%dT%H:%M:%fZ','now')
+   WHERE domain = OLD.domain;
+END;
+
+CREATE TABLE verification_reminders_old (
+  userid         TEXT NOT NULL
+                   REFERENCES users(id)
+                   ON DELETE CASCADE ON UPDATE CASCADE,
+  verified_until TEXT NOT NULL,
+  sent_at        TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+  PRIMARY KEY (userid, verified_until)
+) STRICT;
+
+INSERT INTO verification_reminders_old SELECT userid, verified_until, sent_at FROM verification_reminders;
+DROP TABLE verification_reminders;
+ALTER TABLE verification_reminders_old RENAME TO verification_reminders;
+-- +goose StatementEnd
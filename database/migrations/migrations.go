@@ -0,0 +1,8 @@
+// Package migrations embeds the goose migrations of the database, so that
+// the server does not depend on the working directory to find them.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,31 @@
+-- name: CreateAPIToken :one
+INSERT INTO api_tokens (id, userid, name, tokenhash, scopes, expires_at)
+VALUES (
+  sqlc.arg(id), sqlc.arg(userid), sqlc.arg(name),
+  sqlc.arg(tokenhash), sqlc.arg(scopes), sqlc.arg(expires_at)
+)
+RETURNING *;
+
+-- name: GetAPITokenByHash :one
+SELECT * FROM api_tokens
+WHERE tokenhash = sqlc.arg(tokenhash)
+LIMIT 1;
+
+-- name: ListUserAPITokens :many
+SELECT * FROM api_tokens
+WHERE userid = sqlc.arg(userid)
+ORDER BY created_at DESC;
+
+-- name: TouchAPIToken :exec
+UPDATE api_tokens
+SET last_used = strftime('%Y-%m-%dT%H:%M:%fZ','now')
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteUserAPIToken :execrows
+DELETE FROM api_tokens
+WHERE id = sqlc.arg(id)
+  AND userid = sqlc.arg(userid);
+
+-- name: DeleteExpiredAPITokens :exec
+DELETE FROM api_tokens
+WHERE expires_at < strftime('%Y-%m-%dT%H:%M:%fZ','now');
@@ -0,0 +1,9 @@
+-- name: ListUserComments :many
+SELECT * FROM comments
+WHERE userid = sqlc.arg(userid)
+ORDER BY created_at;
+
+-- name: ReassignUserComments :exec
+UPDATE comments
+SET userid = sqlc.arg(new_userid)
+WHERE userid = sqlc.arg(userid);
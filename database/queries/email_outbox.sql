@@ -0,0 +1,65 @@
+-- name: EnqueueEmail :one
+INSERT INTO email_outbox (id, recipient, subject, body, text_body, unsubscribe)
+VALUES (
+  sqlc.arg(id), sqlc.arg(recipient), sqlc.arg(subject),
+  sqlc.arg(body), sqlc.arg(text_body), sqlc.arg(unsubscribe)
+)
+RETURNING *;
+
+-- name: ClaimDueEmails :many
+UPDATE email_outbox
+SET status = 'sending',
+    attempts = attempts + 1
+WHERE id IN (
+  SELECT id FROM email_outbox
+  WHERE status = 'pending'
+    AND next_attempt_at <= strftime('%Y-%m-%dT%H:%M:%fZ','now')
+  ORDER BY next_attempt_at
+  LIMIT sqlc.arg(limit)
+)
+RETURNING *;
+
+-- name: ResetSendingEmails :exec
+UPDATE email_outbox
+SET status = 'pending'
+WHERE status = 'sending';
+
+-- name: MarkEmailSent :exec
+UPDATE email_outbox
+SET status = 'sent',
+    body = '',
+    text_body = '',
+    last_error = NULL,
+    sent_at = strftime('%Y-%m-%dT%H:%M:%fZ','now')
+WHERE id = sqlc.arg(id);
+
+-- name: MarkEmailFailed :exec
+UPDATE email_outbox
+SET status = sqlc.arg(status),
+    last_error = sqlc.arg(last_error),
+    next_attempt_at = sqlc.arg(next_attempt_at)
+WHERE id = sqlc.arg(id);
+
+-- name: ListEmailOutbox :many
+SELECT * FROM email_outbox
+WHERE (status = sqlc.narg(status) OR sqlc.narg(status) IS NULL)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit);
+
+-- name: RequeueEmail :one
+UPDATE email_outbox
+SET status = 'pending',
+    attempts = 0,
+    next_attempt_at = strftime('%Y-%m-%dT%H:%M:%fZ','now')
+WHERE id = sqlc.arg(id)
+  AND status IN ('pending','dead')
+RETURNING *;
+
+-- name: GetEmail :one
+SELECT * FROM email_outbox
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteFinishedEmails :exec
+DELETE FROM email_outbox
+WHERE (status = 'sent' AND sent_at < sqlc.arg(before))
+   OR (status = 'dead' AND created_at < sqlc.arg(before));
@@ -0,0 +1,23 @@
+-- name: QuarantineExamUpload :one
+INSERT INTO exam_quarantine (
+  id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum, signature
+) VALUES (
+  sqlc.arg(id), sqlc.arg(userid), sqlc.arg(programid), sqlc.arg(version), sqlc.arg(exam_date),
+  sqlc.arg(accesskey), sqlc.arg(mime_type), sqlc.arg(nbytes), sqlc.arg(checksum), sqlc.arg(signature)
+)
+RETURNING *;
+
+-- name: ListQuarantinedExams :many
+SELECT * FROM exam_quarantine
+ORDER BY quarantined_at DESC;
+
+-- name: GetQuarantinedExam :one
+SELECT * FROM exam_quarantine
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteQuarantinedExam :exec
+DELETE FROM exam_quarantine
+WHERE id = sqlc.arg(id);
+
+-- name: ListQuarantineObjects :many
+SELECT accesskey FROM exam_quarantine;
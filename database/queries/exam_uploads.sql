@@ -0,0 +1,51 @@
+-- name: CreateExamUpload :one
+INSERT INTO exam_uploads (
+  id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum, expires_at
+) VALUES (
+  sqlc.arg(id), sqlc.arg(userid), sqlc.arg(programid), sqlc.arg(version), sqlc.arg(exam_date),
+  sqlc.arg(accesskey), sqlc.arg(mime_type), sqlc.arg(nbytes), sqlc.arg(checksum), sqlc.arg(expires_at)
+)
+RETURNING *;
+
+-- name: GetExamUpload :one
+SELECT * FROM exam_uploads
+WHERE id = sqlc.arg(id) AND userid = sqlc.arg(userid);
+
+-- name: DeleteExamUpload :exec
+DELETE FROM exam_uploads
+WHERE id = sqlc.arg(id);
+
+-- name: ListExpiredExamUploads :many
+SELECT * FROM exam_uploads
+WHERE expires_at < sqlc.arg(before)
+ORDER BY expires_at
+LIMIT sqlc.arg(limit);
+
+-- name: CountUserExamUploads :one
+SELECT COUNT(*) FROM exam_uploads
+WHERE userid = sqlc.arg(userid) AND expires_at >= sqlc.arg(now);
+
+-- name: ExtendExamUpload :exec
+UPDATE exam_uploads
+SET expires_at = sqlc.arg(expires_at)
+WHERE id = sqlc.arg(id);
+
+-- name: UpsertExamUploadChunk :exec
+INSERT INTO exam_upload_chunks (uploadid, idx, nbytes, checksum)
+VALUES (sqlc.arg(uploadid), sqlc.arg(idx), sqlc.arg(nbytes), sqlc.arg(checksum))
+ON CONFLICT (uploadid, idx) DO UPDATE
+SET nbytes = excluded.nbytes,
+    checksum = excluded.checksum,
+    received_at = strftime('%Y-%m-%dT%H:%M:%fZ','now');
+
+-- name: ListExamUploadChunks :many
+SELECT * FROM exam_upload_chunks
+WHERE uploadid = sqlc.arg(uploadid)
+ORDER BY idx;
+
+-- name: DeleteExamUploadChunks :exec
+DELETE FROM exam_upload_chunks
+WHERE uploadid = sqlc.arg(uploadid);
+
+-- name: ListExamUploadObjects :many
+SELECT id, accesskey FROM exam_uploads;
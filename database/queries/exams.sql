@@ -0,0 +1,35 @@
+-- name: ListUserExams :many
+SELECT * FROM exams
+WHERE userid = sqlc.arg(userid)
+ORDER BY uploaded_at;
+
+-- name: ReassignUserExams :exec
+UPDATE exams
+SET userid = sqlc.arg(new_userid)
+WHERE userid = sqlc.arg(userid);
+
+-- name: CreateExam :one
+INSERT INTO exams (
+  id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum
+) VALUES (
+  sqlc.arg(id), sqlc.arg(userid), sqlc.arg(programid), sqlc.arg(version), sqlc.arg(exam_date),
+  sqlc.arg(accesskey), sqlc.arg(mime_type), sqlc.arg(nbytes), sqlc.arg(checksum)
+)
+RETURNING *;
+
+-- name: GetExam :one
+SELECT * FROM exams
+WHERE id = sqlc.arg(id);
+
+-- name: ListExamObjects :many
+SELECT id, accesskey, missing_since FROM exams;
+
+-- name: MarkExamMissing :exec
+UPDATE exams
+SET missing_since = COALESCE(missing_since, sqlc.arg(now))
+WHERE id = sqlc.arg(id);
+
+-- name: ClearExamMissing :exec
+UPDATE exams
+SET missing_since = NULL
+WHERE id = sqlc.arg(id);
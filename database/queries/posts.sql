@@ -0,0 +1,9 @@
+-- name: ListUserPosts :many
+SELECT * FROM posts
+WHERE userid = sqlc.arg(userid)
+ORDER BY created_at;
+
+-- name: ReassignUserPosts :exec
+UPDATE posts
+SET userid = sqlc.arg(new_userid)
+WHERE userid = sqlc.arg(userid);
@@ -1,6 +1,13 @@
 -- name: CreateSession :one
-INSERT INTO sessions (id, userid, expires_at)
-VALUES (sqlc.arg(id), sqlc.arg(userid), sqlc.arg(expires_at))
+INSERT INTO sessions (
+  id, publicid, userid, user_agent, ip, device, created_at, last_seen, expires_at
+) VALUES (
+  sqlc.arg(id), sqlc.arg(publicid), sqlc.arg(userid),
+  sqlc.arg(user_agent), sqlc.arg(ip), sqlc.arg(device),
+  strftime('%Y-%m-%dT%H:%M:%fZ','now'),
+  strftime('%Y-%m-%dT%H:%M:%fZ','now'),
+  sqlc.arg(expires_at)
+)
 RETURNING *;
 
 -- name: GetSession :one
@@ -24,9 +31,25 @@ RETURNING *;
 -- name: DeleteSession :exec
 DELETE FROM sessions WHERE id = sqlc.arg(id);
 
+-- name: ListUserSessions :many
+SELECT * FROM sessions
+WHERE userid = sqlc.arg(userid)
+  AND expires_at >= strftime('%Y-%m-%dT%H:%M:%fZ','now')
+ORDER BY last_seen DESC;
+
+-- name: DeleteUserSession :execrows
+DELETE FROM sessions
+WHERE publicid = sqlc.arg(publicid)
+  AND userid = sqlc.arg(userid);
+
 -- name: DeleteUserSessions :exec
 DELETE FROM sessions WHERE userid = sqlc.arg(userid);
 
+-- name: DeleteOtherUserSessions :exec
+DELETE FROM sessions
+WHERE userid = sqlc.arg(userid)
+  AND id != sqlc.arg(id);
+
 -- name: DeleteExpiredSessions :exec
 DELETE FROM sessions
 WHERE expires_at < strftime('%Y-%m-%dT%H:%M:%fZ','now');
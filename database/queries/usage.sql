@@ -0,0 +1,29 @@
+-- name: GetUserStorageUsage :one
+-- Pending uploads reserve their announced size until they are finalized or
+-- expire.
+SELECT
+  CAST((SELECT COALESCE(SUM(nbytes), 0) FROM exams WHERE exams.userid = sqlc.arg(userid)) AS INTEGER) AS stored_bytes,
+  CAST((SELECT COALESCE(SUM(nbytes), 0) FROM exam_uploads
+        WHERE exam_uploads.userid = sqlc.arg(userid) AND expires_at >= sqlc.arg(now)) AS INTEGER) AS reserved_bytes;
+
+-- name: GetTotalStorageUsage :one
+SELECT
+  CAST((SELECT COALESCE(SUM(nbytes), 0) FROM exams) AS INTEGER) AS stored_bytes,
+  CAST((SELECT COUNT(*) FROM exams) AS INTEGER) AS objects,
+  CAST((SELECT COALESCE(SUM(nbytes), 0) FROM exam_uploads
+        WHERE expires_at >= sqlc.arg(now)) AS INTEGER) AS reserved_bytes;
+
+-- name: ListStorageUsageByType :many
+SELECT mime_type, COUNT(*) AS objects, CAST(SUM(nbytes) AS INTEGER) AS nbytes
+FROM exams
+GROUP BY mime_type
+ORDER BY nbytes DESC;
+
+-- name: ListTopStorageUsers :many
+SELECT users.id, users.name, users.email, users.role,
+       COUNT(*) AS objects, CAST(SUM(exams.nbytes) AS INTEGER) AS nbytes
+FROM exams
+JOIN users ON users.id = exams.userid
+GROUP BY users.id
+ORDER BY nbytes DESC
+LIMIT sqlc.arg(limit);
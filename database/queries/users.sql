@@ -1,13 +1,14 @@
 -- name: CreateUser :one
 INSERT INTO users (
-  id, email, name, password, role, active, verified, programid, verification_token
+  id, email, name, password, role, active, verified, programid, verification_token, locale
 ) VALUES (
   sqlc.arg(id), sqlc.arg(email), sqlc.arg(name), sqlc.arg(password),
   COALESCE(sqlc.arg(role), 'user'),
   COALESCE(sqlc.arg(active), 0),
   0,
   sqlc.arg(programid),
-  sqlc.arg(verification_token)
+  sqlc.arg(verification_token),
+  sqlc.arg(locale)
 )
 RETURNING *;
 
@@ -67,15 +68,77 @@ SET verified_until = sqlc.arg(verified_until)
 WHERE id = sqlc.arg(id)
 RETURNING *;
 
--- name: SweepExpiredVerifications :exec
+-- name: SweepExpiredVerifications :many
 UPDATE users
 SET verified = 0
 WHERE verified = 1
   AND verified_until IS NOT NULL
-  AND verified_until < strftime('%Y-%m-%dT%H:%M:%fZ','now');
+  AND verified_until < strftime('%Y-%m-%dT%H:%M:%fZ','now')
+RETURNING id;
 
--- name: ListUsers :many
+-- name: SearchUsers :many
 SELECT *
 FROM users
-ORDER BY created_at DESC
-LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+WHERE (CAST(sqlc.narg(query) AS TEXT) IS NULL
+       OR instr(lower(name), lower(sqlc.narg(query))) > 0
+       OR instr(lower(email), lower(sqlc.narg(query))) > 0)
+  AND (role = sqlc.narg(role) OR sqlc.narg(role) IS NULL)
+  AND (programid = sqlc.narg(programid) OR sqlc.narg(programid) IS NULL)
+  AND (verified = sqlc.narg(verified) OR sqlc.narg(verified) IS NULL)
+  AND (active = sqlc.narg(active) OR sqlc.narg(active) IS NULL)
+  AND (verified_until >= sqlc.narg(verified_until_after) OR sqlc.narg(verified_until_after) IS NULL)
+  AND (verified_until < sqlc.narg(verified_until_before) OR sqlc.narg(verified_until_before) IS NULL)
+  AND (CAST(sqlc.narg(after_created_at) AS TEXT) IS NULL
+       OR created_at < sqlc.narg(after_created_at)
+       OR (created_at = sqlc.narg(after_created_at) AND id < sqlc.narg(after_id)))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: CountUsers :one
+SELECT COUNT(*)
+FROM users
+WHERE (CAST(sqlc.narg(query) AS TEXT) IS NULL
+       OR instr(lower(name), lower(sqlc.narg(query))) > 0
+       OR instr(lower(email), lower(sqlc.narg(query))) > 0)
+  AND (role = sqlc.narg(role) OR sqlc.narg(role) IS NULL)
+  AND (programid = sqlc.narg(programid) OR sqlc.narg(programid) IS NULL)
+  AND (verified = sqlc.narg(verified) OR sqlc.narg(verified) IS NULL)
+  AND (active = sqlc.narg(active) OR sqlc.narg(active) IS NULL)
+  AND (verified_until >= sqlc.narg(verified_until_after) OR sqlc.narg(verified_until_after) IS NULL)
+  AND (verified_until < sqlc.narg(verified_until_before) OR sqlc.narg(verified_until_before) IS NULL);
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET password = sqlc.arg(password)
+WHERE id = sqlc.arg(id);
+
+-- name: CountActiveAdmins :one
+SELECT COUNT(*)
+FROM users
+WHERE role = 'admin'
+  AND active = 1;
+
+-- name: DeleteUser :exec
+DELETE FROM users WHERE id = sqlc.arg(id);
+
+-- name: UpdateUserLocale :one
+UPDATE users
+SET locale = sqlc.arg(locale)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: UpdateUserReminders :one
+UPDATE users
+SET reminders = sqlc.arg(reminders)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: UpdateUserUnsubscribeToken :exec
+UPDATE users
+SET unsubscribe_token = sqlc.arg(unsubscribe_token)
+WHERE id = sqlc.arg(id);
+
+-- name: UnsubscribeUser :execrows
+UPDATE users
+SET reminders = 0
+WHERE unsubscribe_token = sqlc.arg(unsubscribe_token);
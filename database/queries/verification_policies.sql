@@ -0,0 +1,18 @@
+-- name: ListVerificationPolicies :many
+SELECT * FROM verification_policies
+ORDER BY domain;
+
+-- name: UpsertVerificationPolicy :one
+INSERT INTO verification_policies (domain, kind, boundaries, duration_days)
+VALUES (
+  sqlc.arg(domain), sqlc.arg(kind), sqlc.arg(boundaries), sqlc.narg(duration_days)
+)
+ON CONFLICT (domain) DO UPDATE
+SET kind = excluded.kind,
+    boundaries = excluded.boundaries,
+    duration_days = excluded.duration_days
+RETURNING *;
+
+-- name: DeleteVerificationPolicy :execrows
+DELETE FROM verification_policies
+WHERE domain = sqlc.arg(domain);
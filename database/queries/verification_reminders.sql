@@ -0,0 +1,25 @@
+-- name: ListUsersDueForReminder :many
+SELECT u.*
+FROM users u
+WHERE u.verified = 1
+  AND u.active = 1
+  AND u.reminders = 1
+  AND u.verified_until IS NOT NULL
+  AND u.verified_until >= strftime('%Y-%m-%dT%H:%M:%fZ','now')
+  AND u.verified_until <= sqlc.arg(remind_before)
+  AND NOT EXISTS (
+    SELECT 1
+    FROM verification_reminders r
+    WHERE r.userid = u.id
+      AND r.verified_until = u.verified_until
+  )
+ORDER BY u.verified_until;
+
+-- name: ClaimVerificationReminder :execrows
+INSERT INTO verification_reminders (userid, verified_until)
+VALUES (sqlc.arg(userid), sqlc.arg(verified_until))
+ON CONFLICT (userid, verified_until) DO NOTHING;
+
+-- name: DeletePastVerificationReminders :exec
+DELETE FROM verification_reminders
+WHERE verified_until < strftime('%Y-%m-%dT%H:%M:%fZ','now');
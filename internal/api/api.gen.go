@@ -22,15 +22,61 @@ import (
 )
 
 const (
+	BearerAuthScopes = "bearerAuth.Scopes"
 	CookieAuthScopes = "cookieAuth.Scopes"
 )
 
+// Defines values for ApiTokenScope.
+const (
+	ExamsRead   ApiTokenScope = "exams:read"
+	ExamsWrite  ApiTokenScope = "exams:write"
+	ProfileRead ApiTokenScope = "profile:read"
+	UsersRead   ApiTokenScope = "users:read"
+	UsersWrite  ApiTokenScope = "users:write"
+)
+
+// Defines values for ExamUploadMethod.
+const (
+	PUT ExamUploadMethod = "PUT"
+)
+
+// Defines values for ExamUploadRequestMimeType.
+const (
+	Applicationpdf ExamUploadRequestMimeType = "application/pdf"
+)
+
+// Defines values for Locale.
+const (
+	De Locale = "de"
+	En Locale = "en"
+)
+
+// Defines values for OutboxStatus.
+const (
+	Dead    OutboxStatus = "dead"
+	Pending OutboxStatus = "pending"
+	Sending OutboxStatus = "sending"
+	Sent    OutboxStatus = "sent"
+)
+
+// Defines values for ProfileUpdateReminders.
+const (
+	ProfileUpdateRemindersN0 ProfileUpdateReminders = 0
+	ProfileUpdateRemindersN1 ProfileUpdateReminders = 1
+)
+
 // Defines values for UserActive.
 const (
 	UserActiveN0 UserActive = 0
 	UserActiveN1 UserActive = 1
 )
 
+// Defines values for UserReminders.
+const (
+	UserRemindersN0 UserReminders = 0
+	UserRemindersN1 UserReminders = 1
+)
+
 // Defines values for UserRole.
 const (
 	UserRoleAdmin  UserRole = "admin"
@@ -44,12 +90,318 @@ const (
 	UserVerifiedN1 UserVerified = 1
 )
 
+// Defines values for UserStorageUsageRole.
+const (
+	UserStorageUsageRoleAdmin  UserStorageUsageRole = "admin"
+	UserStorageUsageRoleEditor UserStorageUsageRole = "editor"
+	UserStorageUsageRoleUser   UserStorageUsageRole = "user"
+)
+
+// Defines values for UserUpdateActive.
+const (
+	UserUpdateActiveN0 UserUpdateActive = 0
+	UserUpdateActiveN1 UserUpdateActive = 1
+)
+
+// Defines values for UserUpdateRole.
+const (
+	UserUpdateRoleAdmin  UserUpdateRole = "admin"
+	UserUpdateRoleEditor UserUpdateRole = "editor"
+	UserUpdateRoleUser   UserUpdateRole = "user"
+)
+
+// Defines values for UserUpdateVerified.
+const (
+	UserUpdateVerifiedN0 UserUpdateVerified = 0
+	UserUpdateVerifiedN1 UserUpdateVerified = 1
+)
+
+// Defines values for VerificationPolicyKind.
+const (
+	Never    VerificationPolicyKind = "never"
+	Rolling  VerificationPolicyKind = "rolling"
+	Semester VerificationPolicyKind = "semester"
+)
+
+// Defines values for UserFilterActive.
+const (
+	UserFilterActiveN0 UserFilterActive = 0
+	UserFilterActiveN1 UserFilterActive = 1
+)
+
+// Defines values for UserFilterRole.
+const (
+	UserFilterRoleAdmin  UserFilterRole = "admin"
+	UserFilterRoleEditor UserFilterRole = "editor"
+	UserFilterRoleUser   UserFilterRole = "user"
+)
+
+// Defines values for UserFilterVerified.
+const (
+	UserFilterVerifiedN0 UserFilterVerified = 0
+	UserFilterVerifiedN1 UserFilterVerified = 1
+)
+
+// Defines values for GetEmailTemplatesNamePreviewParamsName.
+const (
+	Reverification GetEmailTemplatesNamePreviewParamsName = "reverification"
+	Verification   GetEmailTemplatesNamePreviewParamsName = "verification"
+)
+
+// Defines values for GetUsersParamsRole.
+const (
+	GetUsersParamsRoleAdmin  GetUsersParamsRole = "admin"
+	GetUsersParamsRoleEditor GetUsersParamsRole = "editor"
+	GetUsersParamsRoleUser   GetUsersParamsRole = "user"
+)
+
+// Defines values for GetUsersParamsVerified.
+const (
+	GetUsersParamsVerifiedN0 GetUsersParamsVerified = 0
+	GetUsersParamsVerifiedN1 GetUsersParamsVerified = 1
+)
+
+// Defines values for GetUsersParamsActive.
+const (
+	GetUsersParamsActiveN0 GetUsersParamsActive = 0
+	GetUsersParamsActiveN1 GetUsersParamsActive = 1
+)
+
+// Defines values for GetUsersExportParamsRole.
+const (
+	GetUsersExportParamsRoleAdmin  GetUsersExportParamsRole = "admin"
+	GetUsersExportParamsRoleEditor GetUsersExportParamsRole = "editor"
+	GetUsersExportParamsRoleUser   GetUsersExportParamsRole = "user"
+)
+
+// Defines values for GetUsersExportParamsVerified.
+const (
+	GetUsersExportParamsVerifiedN0 GetUsersExportParamsVerified = 0
+	GetUsersExportParamsVerifiedN1 GetUsersExportParamsVerified = 1
+)
+
+// Defines values for GetUsersExportParamsActive.
+const (
+	GetUsersExportParamsActiveN0 GetUsersExportParamsActive = 0
+	GetUsersExportParamsActiveN1 GetUsersExportParamsActive = 1
+)
+
+// AccountDelete defines model for AccountDelete.
+type AccountDelete struct {
+	Password string `json:"password"`
+}
+
+// AccountExport defines model for AccountExport.
+type AccountExport struct {
+	ApiTokens  []ApiToken        `json:"api_tokens"`
+	Comments   []ExportedComment `json:"comments"`
+	Exams      []ExportedExam    `json:"exams"`
+	ExportedAt time.Time         `json:"exported_at"`
+	Posts      []ExportedPost    `json:"posts"`
+	Sessions   []Session         `json:"sessions"`
+	User       User              `json:"user"`
+}
+
+// ApiToken defines model for ApiToken.
+type ApiToken struct {
+	CreatedAt time.Time       `json:"created_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	Id        string          `json:"id"`
+	LastUsed  *time.Time      `json:"last_used"`
+	Name      string          `json:"name"`
+	Scopes    []ApiTokenScope `json:"scopes"`
+}
+
+// ApiTokenCreate defines model for ApiTokenCreate.
+type ApiTokenCreate struct {
+	ExpiresInDays *int            `json:"expires_in_days,omitempty"`
+	Name          string          `json:"name"`
+	Scopes        []ApiTokenScope `json:"scopes"`
+}
+
+// ApiTokenCreated defines model for ApiTokenCreated.
+type ApiTokenCreated struct {
+	CreatedAt time.Time       `json:"created_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	Id        string          `json:"id"`
+	LastUsed  *time.Time      `json:"last_used"`
+	Name      string          `json:"name"`
+	Scopes    []ApiTokenScope `json:"scopes"`
+
+	// Token Secret to send as `Authorization: Bearer <token>`
+	Token string `json:"token"`
+}
+
+// ApiTokenScope defines model for ApiTokenScope.
+type ApiTokenScope string
+
+// CapturedMail defines model for CapturedMail.
+type CapturedMail struct {
+	From string `json:"from"`
+	Id   string `json:"id"`
+
+	// Raw The complete message as sent
+	Raw        string    `json:"raw"`
+	ReceivedAt time.Time `json:"received_at"`
+	Subject    string    `json:"subject"`
+	To         []string  `json:"to"`
+}
+
+// ContentTypeUsage defines model for ContentTypeUsage.
+type ContentTypeUsage struct {
+	MimeType string `json:"mime_type"`
+	Nbytes   int    `json:"nbytes"`
+	Objects  int    `json:"objects"`
+}
+
+// EmailPreview defines model for EmailPreview.
+type EmailPreview struct {
+	Html    string `json:"html"`
+	Locale  Locale `json:"locale"`
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+}
+
 // Error defines model for Error.
 type Error struct {
-	Error   string `json:"error"`
+	Error string `json:"error"`
+
+	// Message Human readable, in the language requested by `Accept-Language` (German by default).
 	Message string `json:"message"`
 }
 
+// Exam defines model for Exam.
+type Exam struct {
+	Checksum   string    `json:"checksum"`
+	ExamDate   string    `json:"exam_date"`
+	Id         string    `json:"id"`
+	MimeType   string    `json:"mime_type"`
+	Nbytes     int       `json:"nbytes"`
+	Programid  int       `json:"programid"`
+	UploadedAt time.Time `json:"uploaded_at"`
+	Userid     string    `json:"userid"`
+	Version    string    `json:"version"`
+}
+
+// ExamUpload defines model for ExamUpload.
+type ExamUpload struct {
+	// ChunkSize Size of the chunks of a chunked upload.
+	ChunkSize int `json:"chunk_size"`
+
+	// Chunks Number of chunks of a chunked upload.
+	Chunks int `json:"chunks"`
+
+	// ExpiresAt End of the validity of `url`.
+	ExpiresAt  time.Time `json:"expires_at"`
+	FinalizeBy time.Time `json:"finalize_by"`
+
+	// Headers Headers the upload request has to send.
+	Headers map[string]string `json:"headers"`
+	Id      string            `json:"id"`
+	Method  ExamUploadMethod  `json:"method"`
+	Url     string            `json:"url"`
+}
+
+// ExamUploadMethod defines model for ExamUpload.Method.
+type ExamUploadMethod string
+
+// ExamUploadRequest defines model for ExamUploadRequest.
+type ExamUploadRequest struct {
+	// Checksum Hex encoded SHA-256 of the file.
+	Checksum string `json:"checksum"`
+
+	// ExamDate YYYY-MM-DD
+	ExamDate  string                    `json:"exam_date"`
+	MimeType  ExamUploadRequestMimeType `json:"mime_type"`
+	Nbytes    int                       `json:"nbytes"`
+	Programid int                       `json:"programid"`
+	Version   string                    `json:"version"`
+}
+
+// ExamUploadRequestMimeType defines model for ExamUploadRequest.MimeType.
+type ExamUploadRequestMimeType string
+
+// ExamUploadStatus defines model for ExamUploadStatus.
+type ExamUploadStatus struct {
+	ChunkSize  int       `json:"chunk_size"`
+	Chunks     int       `json:"chunks"`
+	FinalizeBy time.Time `json:"finalize_by"`
+	Id         string    `json:"id"`
+	Nbytes     int       `json:"nbytes"`
+
+	// Received Indexes of the chunks received so far.
+	Received []int `json:"received"`
+}
+
+// ExportedComment defines model for ExportedComment.
+type ExportedComment struct {
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at"`
+	Id        string `json:"id"`
+	Postid    string `json:"postid"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ExportedExam Metadata only, the file itself can be downloaded as usual.
+type ExportedExam struct {
+	Checksum   string `json:"checksum"`
+	ExamDate   string `json:"exam_date"`
+	Id         string `json:"id"`
+	MimeType   string `json:"mime_type"`
+	Nbytes     int64  `json:"nbytes"`
+	Programid  int    `json:"programid"`
+	UploadedAt string `json:"uploaded_at"`
+	Version    string `json:"version"`
+}
+
+// ExportedPost Timestamps of exported content are passed through as stored.
+type ExportedPost struct {
+	Body      string  `json:"body"`
+	CreatedAt string  `json:"created_at"`
+	Deleted   *string `json:"deleted"`
+	Id        string  `json:"id"`
+	Title     string  `json:"title"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+// Locale defines model for Locale.
+type Locale string
+
+// OutboxMessage defines model for OutboxMessage.
+type OutboxMessage struct {
+	Attempts      int        `json:"attempts"`
+	CreatedAt     time.Time  `json:"created_at"`
+	Id            string     `json:"id"`
+	LastError     *string    `json:"last_error"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	Recipient     string     `json:"recipient"`
+	SentAt        *time.Time `json:"sent_at"`
+
+	// Status `dead` messages failed too often and are only retried when requeued.
+	// Sent and dead messages are deleted after 30 days.
+	Status  OutboxStatus `json:"status"`
+	Subject string       `json:"subject"`
+}
+
+// OutboxStatus `dead` messages failed too often and are only retried when requeued.
+// Sent and dead messages are deleted after 30 days.
+type OutboxStatus string
+
+// PresignedURL defines model for PresignedURL.
+type PresignedURL struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Url       string    `json:"url"`
+}
+
+// ProfileUpdate Only the given properties are changed.
+type ProfileUpdate struct {
+	Locale    *Locale                 `json:"locale,omitempty"`
+	Reminders *ProfileUpdateReminders `json:"reminders,omitempty"`
+}
+
+// ProfileUpdateReminders defines model for ProfileUpdate.Reminders.
+type ProfileUpdateReminders int
+
 // Program defines model for Program.
 type Program struct {
 	Id   int    `json:"id"`
@@ -59,6 +411,66 @@ type Program struct {
 	Versions []string `json:"versions"`
 }
 
+// QuarantinedExam defines model for QuarantinedExam.
+type QuarantinedExam struct {
+	Checksum      string    `json:"checksum"`
+	ExamDate      string    `json:"exam_date"`
+	Id            string    `json:"id"`
+	MimeType      string    `json:"mime_type"`
+	Nbytes        int       `json:"nbytes"`
+	Programid     int       `json:"programid"`
+	QuarantinedAt time.Time `json:"quarantined_at"`
+
+	// Signature What the malware scanner found.
+	Signature string `json:"signature"`
+	Userid    string `json:"userid"`
+	Version   string `json:"version"`
+}
+
+// Session defines model for Session.
+type Session struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Current Whether this is the session making the request
+	Current bool `json:"current"`
+
+	// Device Coarse label such as "Firefox on Linux"
+	Device    string    `json:"device"`
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Id opaque
+	Id        string    `json:"id"`
+	Ip        string    `json:"ip"`
+	LastSeen  time.Time `json:"last_seen"`
+	UserAgent string    `json:"user_agent"`
+	Userid    string    `json:"userid"`
+}
+
+// SignupPolicy defines model for SignupPolicy.
+type SignupPolicy struct {
+	// Domains Email domains open for registration. Empty if any domain is allowed.
+	Domains []string `json:"domains"`
+
+	// Verify Whether new users must confirm their email address.
+	Verify bool `json:"verify"`
+}
+
+// StorageUsage defines model for StorageUsage.
+type StorageUsage struct {
+	ByType []ContentTypeUsage `json:"by_type"`
+
+	// Nbytes Bytes of all stored files.
+	Nbytes  int `json:"nbytes"`
+	Objects int `json:"objects"`
+
+	// Quota Quota of the whole storage in bytes.
+	Quota int `json:"quota"`
+
+	// Reserved Bytes announced by pending uploads.
+	Reserved int                `json:"reserved"`
+	TopUsers []UserStorageUsage `json:"top_users"`
+}
+
 // User defines model for User.
 type User struct {
 	Active    UserActive          `json:"active"`
@@ -66,19 +478,26 @@ type User struct {
 	Email     openapi_types.Email `json:"email"`
 
 	// Id Version 4 UUID
-	Id            string       `json:"id"`
-	Name          string       `json:"name"`
-	Programid     int          `json:"programid"`
-	Role          UserRole     `json:"role"`
-	UpdatedAt     time.Time    `json:"updated_at"`
-	Verified      UserVerified `json:"verified"`
-	VerifiedAt    *time.Time   `json:"verified_at"`
-	VerifiedUntil *time.Time   `json:"verified_until"`
+	Id        string `json:"id"`
+	Locale    Locale `json:"locale"`
+	Name      string `json:"name"`
+	Programid int    `json:"programid"`
+
+	// Reminders Whether the user is reminded before their verification ends
+	Reminders     UserReminders `json:"reminders"`
+	Role          UserRole      `json:"role"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+	Verified      UserVerified  `json:"verified"`
+	VerifiedAt    *time.Time    `json:"verified_at"`
+	VerifiedUntil *time.Time    `json:"verified_until"`
 }
 
 // UserActive defines model for User.Active.
 type UserActive int
 
+// UserReminders Whether the user is reminded before their verification ends
+type UserReminders int
+
 // UserRole defines model for User.Role.
 type UserRole string
 
@@ -91,39 +510,320 @@ type UserLogin struct {
 	Password string              `json:"password"`
 }
 
+// UserPage defines model for UserPage.
+type UserPage struct {
+	Items      []User  `json:"items"`
+	NextCursor *string `json:"next_cursor"`
+
+	// Total Number of users matching the filters across all pages.
+	Total int `json:"total"`
+}
+
 // UserRegister defines model for UserRegister.
 type UserRegister struct {
 	Email     openapi_types.Email `json:"email"`
+	Locale    *Locale             `json:"locale,omitempty"`
 	Name      string              `json:"name"`
 	Password  string              `json:"password"`
 	Programid int                 `json:"programid"`
 }
 
+// UserStorageUsage defines model for UserStorageUsage.
+type UserStorageUsage struct {
+	Email   openapi_types.Email `json:"email"`
+	Name    string              `json:"name"`
+	Nbytes  int                 `json:"nbytes"`
+	Objects int                 `json:"objects"`
+
+	// Quota Quota of the user's role in bytes.
+	Quota  int                  `json:"quota"`
+	Role   UserStorageUsageRole `json:"role"`
+	Userid string               `json:"userid"`
+}
+
+// UserStorageUsageRole defines model for UserStorageUsage.Role.
+type UserStorageUsageRole string
+
+// UserUpdate Only the given properties are changed.
+type UserUpdate struct {
+	Active   *UserUpdateActive   `json:"active,omitempty"`
+	Role     *UserUpdateRole     `json:"role,omitempty"`
+	Verified *UserUpdateVerified `json:"verified,omitempty"`
+
+	// VerifiedUntil End of the verification window, `null` for no expiry.
+	VerifiedUntil *time.Time `json:"verified_until"`
+}
+
+// UserUpdateActive defines model for UserUpdate.Active.
+type UserUpdateActive int
+
+// UserUpdateRole defines model for UserUpdate.Role.
+type UserUpdateRole string
+
+// UserUpdateVerified defines model for UserUpdate.Verified.
+type UserUpdateVerified int
+
+// VerificationPolicy defines model for VerificationPolicy.
+type VerificationPolicy struct {
+	// Boundaries Yearly cut-off dates as MM-DD, evaluated at midnight UTC
+	Boundaries []string `json:"boundaries"`
+
+	// Domain Email domain or `*`
+	Domain       string `json:"domain"`
+	DurationDays *int   `json:"duration_days"`
+
+	// Kind `semester` verifications end at the next boundary date,
+	// `rolling` verifications end `duration_days` after verifying and
+	// `never` verifications do not expire.
+	Kind      VerificationPolicyKind `json:"kind"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// VerificationPolicyKind `semester` verifications end at the next boundary date,
+// `rolling` verifications end `duration_days` after verifying and
+// `never` verifications do not expire.
+type VerificationPolicyKind string
+
+// VerificationPolicyUpdate defines model for VerificationPolicyUpdate.
+type VerificationPolicyUpdate struct {
+	Boundaries   *[]string `json:"boundaries,omitempty"`
+	DurationDays *int      `json:"duration_days,omitempty"`
+
+	// Kind `semester` verifications end at the next boundary date,
+	// `rolling` verifications end `duration_days` after verifying and
+	// `never` verifications do not expire.
+	Kind VerificationPolicyKind `json:"kind"`
+}
+
 // CsrfHeader defines model for CsrfHeader.
 type CsrfHeader = string
 
+// UserFilterActive defines model for UserFilterActive.
+type UserFilterActive int
+
+// UserFilterProgram defines model for UserFilterProgram.
+type UserFilterProgram = int
+
+// UserFilterQuery defines model for UserFilterQuery.
+type UserFilterQuery = string
+
+// UserFilterRole defines model for UserFilterRole.
+type UserFilterRole string
+
+// UserFilterVerified defines model for UserFilterVerified.
+type UserFilterVerified int
+
+// UserFilterVerifiedUntilAfter defines model for UserFilterVerifiedUntilAfter.
+type UserFilterVerifiedUntilAfter = time.Time
+
+// UserFilterVerifiedUntilBefore defines model for UserFilterVerifiedUntilBefore.
+type UserFilterVerifiedUntilBefore = time.Time
+
 // PostAuthLogoutParams defines parameters for PostAuthLogout.
 type PostAuthLogoutParams struct {
 	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
 }
 
+// DeleteAuthMeParams defines parameters for DeleteAuthMe.
+type DeleteAuthMeParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// PatchAuthMeParams defines parameters for PatchAuthMe.
+type PatchAuthMeParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// DeleteAuthSessionsParams defines parameters for DeleteAuthSessions.
+type DeleteAuthSessionsParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// DeleteAuthSessionsIdParams defines parameters for DeleteAuthSessionsId.
+type DeleteAuthSessionsIdParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// PostAuthTokensParams defines parameters for PostAuthTokens.
+type PostAuthTokensParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// DeleteAuthTokensIdParams defines parameters for DeleteAuthTokensId.
+type DeleteAuthTokensIdParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// PostAuthUnsubscribeParams defines parameters for PostAuthUnsubscribe.
+type PostAuthUnsubscribeParams struct {
+	Token string `form:"token" json:"token"`
+}
+
 // GetAuthVerifyParams defines parameters for GetAuthVerify.
 type GetAuthVerifyParams struct {
 	Token string `form:"token" json:"token"`
 }
 
+// GetEmailOutboxParams defines parameters for GetEmailOutbox.
+type GetEmailOutboxParams struct {
+	Status *OutboxStatus `form:"status,omitempty" json:"status,omitempty"`
+	Limit  *int          `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostEmailOutboxIdRequeueParams defines parameters for PostEmailOutboxIdRequeue.
+type PostEmailOutboxIdRequeueParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// GetEmailTemplatesNamePreviewParams defines parameters for GetEmailTemplatesNamePreview.
+type GetEmailTemplatesNamePreviewParams struct {
+	Locale *Locale `form:"locale,omitempty" json:"locale,omitempty"`
+}
+
+// GetEmailTemplatesNamePreviewParamsName defines parameters for GetEmailTemplatesNamePreview.
+type GetEmailTemplatesNamePreviewParamsName string
+
+// PostExamsUploadsParams defines parameters for PostExamsUploads.
+type PostExamsUploadsParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// DeleteExamsUploadsIdParams defines parameters for DeleteExamsUploadsId.
+type DeleteExamsUploadsIdParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// PutExamsUploadsIdChunksIndexParams defines parameters for PutExamsUploadsIdChunksIndex.
+type PutExamsUploadsIdChunksIndexParams struct {
+	// XChunkChecksum Hex encoded SHA-256 of the chunk.
+	XChunkChecksum string     `json:"X-Chunk-Checksum"`
+	XCSRFToken     CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// PostExamsUploadsIdFinalizeParams defines parameters for PostExamsUploadsIdFinalize.
+type PostExamsUploadsIdFinalizeParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// DeleteQuarantineIdParams defines parameters for DeleteQuarantineId.
+type DeleteQuarantineIdParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// PostQuarantineIdReleaseParams defines parameters for PostQuarantineIdRelease.
+type PostQuarantineIdReleaseParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// GetStorageUsageParams defines parameters for GetStorageUsage.
+type GetStorageUsageParams struct {
+	// Limit Number of top consumers to list.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // GetUsersParams defines parameters for GetUsers.
 type GetUsersParams struct {
-	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	// Q Case-insensitive substring of the name or email.
+	Q         *UserFilterQuery        `form:"q,omitempty" json:"q,omitempty"`
+	Role      *GetUsersParamsRole     `form:"role,omitempty" json:"role,omitempty"`
+	Programid *UserFilterProgram      `form:"programid,omitempty" json:"programid,omitempty"`
+	Verified  *GetUsersParamsVerified `form:"verified,omitempty" json:"verified,omitempty"`
+	Active    *GetUsersParamsActive   `form:"active,omitempty" json:"active,omitempty"`
+
+	// VerifiedUntilAfter Only users whose verification ends at or after this time.
+	VerifiedUntilAfter *UserFilterVerifiedUntilAfter `form:"verified_until_after,omitempty" json:"verified_until_after,omitempty"`
+
+	// VerifiedUntilBefore Only users whose verification ends before this time.
+	VerifiedUntilBefore *UserFilterVerifiedUntilBefore `form:"verified_until_before,omitempty" json:"verified_until_before,omitempty"`
+	Limit               *int                           `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor The `next_cursor` of the previous page.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetUsersParamsRole defines parameters for GetUsers.
+type GetUsersParamsRole string
+
+// GetUsersParamsVerified defines parameters for GetUsers.
+type GetUsersParamsVerified int
+
+// GetUsersParamsActive defines parameters for GetUsers.
+type GetUsersParamsActive int
+
+// GetUsersExportParams defines parameters for GetUsersExport.
+type GetUsersExportParams struct {
+	// Q Case-insensitive substring of the name or email.
+	Q         *UserFilterQuery              `form:"q,omitempty" json:"q,omitempty"`
+	Role      *GetUsersExportParamsRole     `form:"role,omitempty" json:"role,omitempty"`
+	Programid *UserFilterProgram            `form:"programid,omitempty" json:"programid,omitempty"`
+	Verified  *GetUsersExportParamsVerified `form:"verified,omitempty" json:"verified,omitempty"`
+	Active    *GetUsersExportParamsActive   `form:"active,omitempty" json:"active,omitempty"`
+
+	// VerifiedUntilAfter Only users whose verification ends at or after this time.
+	VerifiedUntilAfter *UserFilterVerifiedUntilAfter `form:"verified_until_after,omitempty" json:"verified_until_after,omitempty"`
+
+	// VerifiedUntilBefore Only users whose verification ends before this time.
+	VerifiedUntilBefore *UserFilterVerifiedUntilBefore `form:"verified_until_before,omitempty" json:"verified_until_before,omitempty"`
+}
+
+// GetUsersExportParamsRole defines parameters for GetUsersExport.
+type GetUsersExportParamsRole string
+
+// GetUsersExportParamsVerified defines parameters for GetUsersExport.
+type GetUsersExportParamsVerified int
+
+// GetUsersExportParamsActive defines parameters for GetUsersExport.
+type GetUsersExportParamsActive int
+
+// DeleteUsersIdParams defines parameters for DeleteUsersId.
+type DeleteUsersIdParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// PatchUsersIdParams defines parameters for PatchUsersId.
+type PatchUsersIdParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// DeleteUsersIdSessionsParams defines parameters for DeleteUsersIdSessions.
+type DeleteUsersIdSessionsParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// DeleteVerificationPoliciesDomainParams defines parameters for DeleteVerificationPoliciesDomain.
+type DeleteVerificationPoliciesDomainParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
+}
+
+// PutVerificationPoliciesDomainParams defines parameters for PutVerificationPoliciesDomain.
+type PutVerificationPoliciesDomainParams struct {
+	XCSRFToken CsrfHeader `json:"X-CSRF-Token"`
 }
 
 // PostAuthLoginJSONRequestBody defines body for PostAuthLogin for application/json ContentType.
 type PostAuthLoginJSONRequestBody = UserLogin
 
+// DeleteAuthMeJSONRequestBody defines body for DeleteAuthMe for application/json ContentType.
+type DeleteAuthMeJSONRequestBody = AccountDelete
+
+// PatchAuthMeJSONRequestBody defines body for PatchAuthMe for application/json ContentType.
+type PatchAuthMeJSONRequestBody = ProfileUpdate
+
 // PostAuthRegisterJSONRequestBody defines body for PostAuthRegister for application/json ContentType.
 type PostAuthRegisterJSONRequestBody = UserRegister
 
+// PostAuthTokensJSONRequestBody defines body for PostAuthTokens for application/json ContentType.
+type PostAuthTokensJSONRequestBody = ApiTokenCreate
+
+// PostExamsUploadsJSONRequestBody defines body for PostExamsUploads for application/json ContentType.
+type PostExamsUploadsJSONRequestBody = ExamUploadRequest
+
+// PatchUsersIdJSONRequestBody defines body for PatchUsersId for application/json ContentType.
+type PatchUsersIdJSONRequestBody = UserUpdate
+
+// PutVerificationPoliciesDomainJSONRequestBody defines body for PutVerificationPoliciesDomain for application/json ContentType.
+type PutVerificationPoliciesDomainJSONRequestBody = VerificationPolicyUpdate
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Issue a CSRF token
@@ -135,27 +835,126 @@ type ServerInterface interface {
 	// Log out
 	// (POST /auth/logout)
 	PostAuthLogout(w http.ResponseWriter, r *http.Request, params PostAuthLogoutParams)
+	// Delete my account
+	// (DELETE /auth/me)
+	DeleteAuthMe(w http.ResponseWriter, r *http.Request, params DeleteAuthMeParams)
 	// Get current user
 	// (GET /auth/me)
 	GetAuthMe(w http.ResponseWriter, r *http.Request)
+	// Update my preferences
+	// (PATCH /auth/me)
+	PatchAuthMe(w http.ResponseWriter, r *http.Request, params PatchAuthMeParams)
+	// Export my data
+	// (GET /auth/me/export)
+	GetAuthMeExport(w http.ResponseWriter, r *http.Request)
 	// Register a user
 	// (POST /auth/register)
 	PostAuthRegister(w http.ResponseWriter, r *http.Request)
+	// Log out everywhere else
+	// (DELETE /auth/sessions)
+	DeleteAuthSessions(w http.ResponseWriter, r *http.Request, params DeleteAuthSessionsParams)
+	// List my active sessions
+	// (GET /auth/sessions)
+	GetAuthSessions(w http.ResponseWriter, r *http.Request)
+	// Revoke one of my sessions
+	// (DELETE /auth/sessions/{id})
+	DeleteAuthSessionsId(w http.ResponseWriter, r *http.Request, id string, params DeleteAuthSessionsIdParams)
+	// Get the email domains open for registration
+	// (GET /auth/signup-policy)
+	GetAuthSignupPolicy(w http.ResponseWriter, r *http.Request)
+	// List my personal access tokens
+	// (GET /auth/tokens)
+	GetAuthTokens(w http.ResponseWriter, r *http.Request)
+	// Create a personal access token
+	// (POST /auth/tokens)
+	PostAuthTokens(w http.ResponseWriter, r *http.Request, params PostAuthTokensParams)
+	// Revoke a personal access token
+	// (DELETE /auth/tokens/{id})
+	DeleteAuthTokensId(w http.ResponseWriter, r *http.Request, id string, params DeleteAuthTokensIdParams)
+	// Stop re-verification reminders
+	// (POST /auth/unsubscribe)
+	PostAuthUnsubscribe(w http.ResponseWriter, r *http.Request, params PostAuthUnsubscribeParams)
 	// Verify user email
 	// (GET /auth/verify)
 	GetAuthVerify(w http.ResponseWriter, r *http.Request, params GetAuthVerifyParams)
+	// List captured emails
+	// (GET /dev/mails)
+	GetDevMails(w http.ResponseWriter, r *http.Request)
+	// List queued and sent emails (restricted)
+	// (GET /email-outbox)
+	GetEmailOutbox(w http.ResponseWriter, r *http.Request, params GetEmailOutboxParams)
+	// Retry a pending or dead email now (restricted)
+	// (POST /email-outbox/{id}/requeue)
+	PostEmailOutboxIdRequeue(w http.ResponseWriter, r *http.Request, id string, params PostEmailOutboxIdRequeueParams)
+	// Render an email template with sample data (restricted)
+	// (GET /email-templates/{name}/preview)
+	GetEmailTemplatesNamePreview(w http.ResponseWriter, r *http.Request, name GetEmailTemplatesNamePreviewParamsName, params GetEmailTemplatesNamePreviewParams)
+	// Start uploading an exam
+	// (POST /exams/uploads)
+	PostExamsUploads(w http.ResponseWriter, r *http.Request, params PostExamsUploadsParams)
+	// Abort an upload
+	// (DELETE /exams/uploads/{id})
+	DeleteExamsUploadsId(w http.ResponseWriter, r *http.Request, id string, params DeleteExamsUploadsIdParams)
+	// Get the progress of an upload
+	// (GET /exams/uploads/{id})
+	GetExamsUploadsId(w http.ResponseWriter, r *http.Request, id string)
+	// Upload one chunk of a file
+	// (PUT /exams/uploads/{id}/chunks/{index})
+	PutExamsUploadsIdChunksIndex(w http.ResponseWriter, r *http.Request, id string, index int, params PutExamsUploadsIdChunksIndexParams)
+	// Finish uploading an exam
+	// (POST /exams/uploads/{id}/finalize)
+	PostExamsUploadsIdFinalize(w http.ResponseWriter, r *http.Request, id string, params PostExamsUploadsIdFinalizeParams)
+	// Get a download link for an exam
+	// (GET /exams/{id}/download)
+	GetExamsIdDownload(w http.ResponseWriter, r *http.Request, id string)
 	// List all programs and their valid POs
 	// (GET /programs)
 	GetPrograms(w http.ResponseWriter, r *http.Request)
 	// Get program by id
 	// (GET /programs/{id})
 	GetProgramsId(w http.ResponseWriter, r *http.Request, id int)
-	// List users (restricted)
+	// List quarantined uploads (restricted)
+	// (GET /quarantine)
+	GetQuarantine(w http.ResponseWriter, r *http.Request)
+	// Delete a quarantined upload (restricted)
+	// (DELETE /quarantine/{id})
+	DeleteQuarantineId(w http.ResponseWriter, r *http.Request, id string, params DeleteQuarantineIdParams)
+	// Get a download link for a quarantined upload (restricted)
+	// (GET /quarantine/{id}/download)
+	GetQuarantineIdDownload(w http.ResponseWriter, r *http.Request, id string)
+	// Publish a quarantined upload after review (restricted)
+	// (POST /quarantine/{id}/release)
+	PostQuarantineIdRelease(w http.ResponseWriter, r *http.Request, id string, params PostQuarantineIdReleaseParams)
+	// Show storage usage and its top consumers (restricted)
+	// (GET /storage-usage)
+	GetStorageUsage(w http.ResponseWriter, r *http.Request, params GetStorageUsageParams)
+	// Search users (restricted)
 	// (GET /users)
 	GetUsers(w http.ResponseWriter, r *http.Request, params GetUsersParams)
+	// Export users as CSV (restricted)
+	// (GET /users/export)
+	GetUsersExport(w http.ResponseWriter, r *http.Request, params GetUsersExportParams)
+	// Delete a user (restricted)
+	// (DELETE /users/{id})
+	DeleteUsersId(w http.ResponseWriter, r *http.Request, id string, params DeleteUsersIdParams)
 	// Get user by id
 	// (GET /users/{id})
 	GetUsersId(w http.ResponseWriter, r *http.Request, id string)
+	// Change role, activation or verification of a user (restricted)
+	// (PATCH /users/{id})
+	PatchUsersId(w http.ResponseWriter, r *http.Request, id string, params PatchUsersIdParams)
+	// Revoke all sessions of a user (restricted)
+	// (DELETE /users/{id}/sessions)
+	DeleteUsersIdSessions(w http.ResponseWriter, r *http.Request, id string, params DeleteUsersIdSessionsParams)
+	// List verification policies (restricted)
+	// (GET /verification-policies)
+	GetVerificationPolicies(w http.ResponseWriter, r *http.Request)
+	// Delete a verification policy (restricted)
+	// (DELETE /verification-policies/{domain})
+	DeleteVerificationPoliciesDomain(w http.ResponseWriter, r *http.Request, domain string, params DeleteVerificationPoliciesDomainParams)
+	// Create or replace a verification policy (restricted)
+	// (PUT /verification-policies/{domain})
+	PutVerificationPoliciesDomain(w http.ResponseWriter, r *http.Request, domain string, params PutVerificationPoliciesDomainParams)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
@@ -180,39 +979,207 @@ func (_ Unimplemented) PostAuthLogout(w http.ResponseWriter, r *http.Request, pa
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Delete my account
+// (DELETE /auth/me)
+func (_ Unimplemented) DeleteAuthMe(w http.ResponseWriter, r *http.Request, params DeleteAuthMeParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get current user
 // (GET /auth/me)
 func (_ Unimplemented) GetAuthMe(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Update my preferences
+// (PATCH /auth/me)
+func (_ Unimplemented) PatchAuthMe(w http.ResponseWriter, r *http.Request, params PatchAuthMeParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export my data
+// (GET /auth/me/export)
+func (_ Unimplemented) GetAuthMeExport(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Register a user
 // (POST /auth/register)
 func (_ Unimplemented) PostAuthRegister(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Verify user email
-// (GET /auth/verify)
-func (_ Unimplemented) GetAuthVerify(w http.ResponseWriter, r *http.Request, params GetAuthVerifyParams) {
+// Log out everywhere else
+// (DELETE /auth/sessions)
+func (_ Unimplemented) DeleteAuthSessions(w http.ResponseWriter, r *http.Request, params DeleteAuthSessionsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// List all programs and their valid POs
-// (GET /programs)
-func (_ Unimplemented) GetPrograms(w http.ResponseWriter, r *http.Request) {
+// List my active sessions
+// (GET /auth/sessions)
+func (_ Unimplemented) GetAuthSessions(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Get program by id
-// (GET /programs/{id})
-func (_ Unimplemented) GetProgramsId(w http.ResponseWriter, r *http.Request, id int) {
+// Revoke one of my sessions
+// (DELETE /auth/sessions/{id})
+func (_ Unimplemented) DeleteAuthSessionsId(w http.ResponseWriter, r *http.Request, id string, params DeleteAuthSessionsIdParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// List users (restricted)
-// (GET /users)
-func (_ Unimplemented) GetUsers(w http.ResponseWriter, r *http.Request, params GetUsersParams) {
+// Get the email domains open for registration
+// (GET /auth/signup-policy)
+func (_ Unimplemented) GetAuthSignupPolicy(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List my personal access tokens
+// (GET /auth/tokens)
+func (_ Unimplemented) GetAuthTokens(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a personal access token
+// (POST /auth/tokens)
+func (_ Unimplemented) PostAuthTokens(w http.ResponseWriter, r *http.Request, params PostAuthTokensParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke a personal access token
+// (DELETE /auth/tokens/{id})
+func (_ Unimplemented) DeleteAuthTokensId(w http.ResponseWriter, r *http.Request, id string, params DeleteAuthTokensIdParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stop re-verification reminders
+// (POST /auth/unsubscribe)
+func (_ Unimplemented) PostAuthUnsubscribe(w http.ResponseWriter, r *http.Request, params PostAuthUnsubscribeParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Verify user email
+// (GET /auth/verify)
+func (_ Unimplemented) GetAuthVerify(w http.ResponseWriter, r *http.Request, params GetAuthVerifyParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List captured emails
+// (GET /dev/mails)
+func (_ Unimplemented) GetDevMails(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List queued and sent emails (restricted)
+// (GET /email-outbox)
+func (_ Unimplemented) GetEmailOutbox(w http.ResponseWriter, r *http.Request, params GetEmailOutboxParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Retry a pending or dead email now (restricted)
+// (POST /email-outbox/{id}/requeue)
+func (_ Unimplemented) PostEmailOutboxIdRequeue(w http.ResponseWriter, r *http.Request, id string, params PostEmailOutboxIdRequeueParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Render an email template with sample data (restricted)
+// (GET /email-templates/{name}/preview)
+func (_ Unimplemented) GetEmailTemplatesNamePreview(w http.ResponseWriter, r *http.Request, name GetEmailTemplatesNamePreviewParamsName, params GetEmailTemplatesNamePreviewParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start uploading an exam
+// (POST /exams/uploads)
+func (_ Unimplemented) PostExamsUploads(w http.ResponseWriter, r *http.Request, params PostExamsUploadsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Abort an upload
+// (DELETE /exams/uploads/{id})
+func (_ Unimplemented) DeleteExamsUploadsId(w http.ResponseWriter, r *http.Request, id string, params DeleteExamsUploadsIdParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the progress of an upload
+// (GET /exams/uploads/{id})
+func (_ Unimplemented) GetExamsUploadsId(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Upload one chunk of a file
+// (PUT /exams/uploads/{id}/chunks/{index})
+func (_ Unimplemented) PutExamsUploadsIdChunksIndex(w http.ResponseWriter, r *http.Request, id string, index int, params PutExamsUploadsIdChunksIndexParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Finish uploading an exam
+// (POST /exams/uploads/{id}/finalize)
+func (_ Unimplemented) PostExamsUploadsIdFinalize(w http.ResponseWriter, r *http.Request, id string, params PostExamsUploadsIdFinalizeParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a download link for an exam
+// (GET /exams/{id}/download)
+func (_ Unimplemented) GetExamsIdDownload(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List all programs and their valid POs
+// (GET /programs)
+func (_ Unimplemented) GetPrograms(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get program by id
+// (GET /programs/{id})
+func (_ Unimplemented) GetProgramsId(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List quarantined uploads (restricted)
+// (GET /quarantine)
+func (_ Unimplemented) GetQuarantine(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a quarantined upload (restricted)
+// (DELETE /quarantine/{id})
+func (_ Unimplemented) DeleteQuarantineId(w http.ResponseWriter, r *http.Request, id string, params DeleteQuarantineIdParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a download link for a quarantined upload (restricted)
+// (GET /quarantine/{id}/download)
+func (_ Unimplemented) GetQuarantineIdDownload(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Publish a quarantined upload after review (restricted)
+// (POST /quarantine/{id}/release)
+func (_ Unimplemented) PostQuarantineIdRelease(w http.ResponseWriter, r *http.Request, id string, params PostQuarantineIdReleaseParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Show storage usage and its top consumers (restricted)
+// (GET /storage-usage)
+func (_ Unimplemented) GetStorageUsage(w http.ResponseWriter, r *http.Request, params GetStorageUsageParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Search users (restricted)
+// (GET /users)
+func (_ Unimplemented) GetUsers(w http.ResponseWriter, r *http.Request, params GetUsersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export users as CSV (restricted)
+// (GET /users/export)
+func (_ Unimplemented) GetUsersExport(w http.ResponseWriter, r *http.Request, params GetUsersExportParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a user (restricted)
+// (DELETE /users/{id})
+func (_ Unimplemented) DeleteUsersId(w http.ResponseWriter, r *http.Request, id string, params DeleteUsersIdParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -222,6 +1189,36 @@ func (_ Unimplemented) GetUsersId(w http.ResponseWriter, r *http.Request, id str
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Change role, activation or verification of a user (restricted)
+// (PATCH /users/{id})
+func (_ Unimplemented) PatchUsersId(w http.ResponseWriter, r *http.Request, id string, params PatchUsersIdParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke all sessions of a user (restricted)
+// (DELETE /users/{id}/sessions)
+func (_ Unimplemented) DeleteUsersIdSessions(w http.ResponseWriter, r *http.Request, id string, params DeleteUsersIdSessionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List verification policies (restricted)
+// (GET /verification-policies)
+func (_ Unimplemented) GetVerificationPolicies(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a verification policy (restricted)
+// (DELETE /verification-policies/{domain})
+func (_ Unimplemented) DeleteVerificationPoliciesDomain(w http.ResponseWriter, r *http.Request, domain string, params DeleteVerificationPoliciesDomainParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create or replace a verification policy (restricted)
+// (PUT /verification-policies/{domain})
+func (_ Unimplemented) PutVerificationPoliciesDomain(w http.ResponseWriter, r *http.Request, domain string, params PutVerificationPoliciesDomainParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -242,14 +1239,1576 @@ func (siw *ServerInterfaceWrapper) GetAuthCsrf(w http.ResponseWriter, r *http.Re
 		handler = middleware(handler)
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	handler.ServeHTTP(w, r)
+}
+
+// PostAuthLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthLogin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAuthLogout operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthLogout(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostAuthLogoutParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
+
+		params.XCSRFToken = XCSRFToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthLogout(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAuthMe operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAuthMe(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteAuthMeParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
+
+		params.XCSRFToken = XCSRFToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAuthMe(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAuthMe operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthMe(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAuthMe(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchAuthMe operation middleware
+func (siw *ServerInterfaceWrapper) PatchAuthMe(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchAuthMeParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
+
+		params.XCSRFToken = XCSRFToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchAuthMe(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAuthMeExport operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthMeExport(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAuthMeExport(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAuthRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthRegister(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthRegister(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAuthSessions operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAuthSessions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteAuthSessionsParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
+
+		params.XCSRFToken = XCSRFToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAuthSessions(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAuthSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthSessions(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAuthSessions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAuthSessionsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAuthSessionsId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteAuthSessionsIdParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
+
+		params.XCSRFToken = XCSRFToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAuthSessionsId(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAuthSignupPolicy operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthSignupPolicy(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAuthSignupPolicy(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAuthTokens operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthTokens(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAuthTokens(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAuthTokens operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthTokens(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostAuthTokensParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
+
+		params.XCSRFToken = XCSRFToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthTokens(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAuthTokensId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAuthTokensId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteAuthTokensIdParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
+
+		params.XCSRFToken = XCSRFToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAuthTokensId(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAuthUnsubscribe operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthUnsubscribe(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostAuthUnsubscribeParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if paramValue := r.URL.Query().Get("token"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "token"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthUnsubscribe(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAuthVerify operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthVerify(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuthVerifyParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if paramValue := r.URL.Query().Get("token"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "token"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAuthVerify(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDevMails operation middleware
+func (siw *ServerInterfaceWrapper) GetDevMails(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDevMails(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEmailOutbox operation middleware
+func (siw *ServerInterfaceWrapper) GetEmailOutbox(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEmailOutboxParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEmailOutbox(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostEmailOutboxIdRequeue operation middleware
+func (siw *ServerInterfaceWrapper) PostEmailOutboxIdRequeue(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostEmailOutboxIdRequeueParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
+
+		params.XCSRFToken = XCSRFToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEmailOutboxIdRequeue(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEmailTemplatesNamePreview operation middleware
+func (siw *ServerInterfaceWrapper) GetEmailTemplatesNamePreview(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name GetEmailTemplatesNamePreviewParamsName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEmailTemplatesNamePreviewParams
+
+	// ------------- Optional query parameter "locale" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "locale", r.URL.Query(), &params.Locale)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "locale", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEmailTemplatesNamePreview(w, r, name, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostExamsUploads operation middleware
+func (siw *ServerInterfaceWrapper) PostExamsUploads(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostExamsUploadsParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
+
+		params.XCSRFToken = XCSRFToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostExamsUploads(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteExamsUploadsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteExamsUploadsId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteExamsUploadsIdParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
+
+		params.XCSRFToken = XCSRFToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteExamsUploadsId(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetExamsUploadsId operation middleware
+func (siw *ServerInterfaceWrapper) GetExamsUploadsId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetExamsUploadsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutExamsUploadsIdChunksIndex operation middleware
+func (siw *ServerInterfaceWrapper) PutExamsUploadsIdChunksIndex(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "index" -------------
+	var index int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "index", chi.URLParam(r, "index"), &index, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "index", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutExamsUploadsIdChunksIndexParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Chunk-Checksum" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Chunk-Checksum")]; found {
+		var XChunkChecksum string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Chunk-Checksum", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Chunk-Checksum", valueList[0], &XChunkChecksum, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Chunk-Checksum", Err: err})
+			return
+		}
+
+		params.XChunkChecksum = XChunkChecksum
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Chunk-Checksum is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Chunk-Checksum", Err: err})
+		return
+	}
+
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
+
+		params.XCSRFToken = XCSRFToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutExamsUploadsIdChunksIndex(w, r, id, index, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostExamsUploadsIdFinalize operation middleware
+func (siw *ServerInterfaceWrapper) PostExamsUploadsIdFinalize(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostExamsUploadsIdFinalizeParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
+
+		params.XCSRFToken = XCSRFToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostExamsUploadsIdFinalize(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetExamsIdDownload operation middleware
+func (siw *ServerInterfaceWrapper) GetExamsIdDownload(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetExamsIdDownload(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPrograms operation middleware
+func (siw *ServerInterfaceWrapper) GetPrograms(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPrograms(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProgramsId operation middleware
+func (siw *ServerInterfaceWrapper) GetProgramsId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProgramsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetQuarantine operation middleware
+func (siw *ServerInterfaceWrapper) GetQuarantine(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetQuarantine(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteQuarantineId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteQuarantineId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteQuarantineIdParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
+
+		params.XCSRFToken = XCSRFToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteQuarantineId(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetQuarantineIdDownload operation middleware
+func (siw *ServerInterfaceWrapper) GetQuarantineIdDownload(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetQuarantineIdDownload(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostQuarantineIdRelease operation middleware
+func (siw *ServerInterfaceWrapper) PostQuarantineIdRelease(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostQuarantineIdReleaseParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
+
+		params.XCSRFToken = XCSRFToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostQuarantineIdRelease(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStorageUsage operation middleware
+func (siw *ServerInterfaceWrapper) GetStorageUsage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStorageUsageParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStorageUsage(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetUsers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "role" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "role", r.URL.Query(), &params.Role)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "programid" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "programid", r.URL.Query(), &params.Programid)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "programid", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "verified" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "verified", r.URL.Query(), &params.Verified)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "verified", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "active" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "active", r.URL.Query(), &params.Active)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "active", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "verified_until_after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "verified_until_after", r.URL.Query(), &params.VerifiedUntilAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "verified_until_after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "verified_until_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "verified_until_before", r.URL.Query(), &params.VerifiedUntilBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "verified_until_before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUsersExport operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersExport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersExportParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "role" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "role", r.URL.Query(), &params.Role)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "programid" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "programid", r.URL.Query(), &params.Programid)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "programid", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "verified" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "verified", r.URL.Query(), &params.Verified)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "verified", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "active" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "active", r.URL.Query(), &params.Active)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "active", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "verified_until_after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "verified_until_after", r.URL.Query(), &params.VerifiedUntilAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "verified_until_after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "verified_until_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "verified_until_before", r.URL.Query(), &params.VerifiedUntilBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "verified_until_before", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUsersExport(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteUsersId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUsersId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteUsersIdParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
+
+		params.XCSRFToken = XCSRFToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteUsersId(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUsersId operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
 
-// PostAuthLogin operation middleware
-func (siw *ServerInterfaceWrapper) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostAuthLogin(w, r)
+		siw.Handler.GetUsersId(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -259,19 +2818,30 @@ func (siw *ServerInterfaceWrapper) PostAuthLogin(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// PostAuthLogout operation middleware
-func (siw *ServerInterfaceWrapper) PostAuthLogout(w http.ResponseWriter, r *http.Request) {
+// PatchUsersId operation middleware
+func (siw *ServerInterfaceWrapper) PatchUsersId(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostAuthLogoutParams
+	var params PatchUsersIdParams
 
 	headers := r.Header
 
@@ -299,7 +2869,7 @@ func (siw *ServerInterfaceWrapper) PostAuthLogout(w http.ResponseWriter, r *http
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostAuthLogout(w, r, params)
+		siw.Handler.PatchUsersId(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -309,65 +2879,58 @@ func (siw *ServerInterfaceWrapper) PostAuthLogout(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// GetAuthMe operation middleware
-func (siw *ServerInterfaceWrapper) GetAuthMe(w http.ResponseWriter, r *http.Request) {
-
-	ctx := r.Context()
-
-	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+// DeleteUsersIdSessions operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUsersIdSessions(w http.ResponseWriter, r *http.Request) {
 
-	r = r.WithContext(ctx)
+	var err error
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAuthMe(w, r)
-	}))
+	// ------------- Path parameter "id" -------------
+	var id string
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
-
-// PostAuthRegister operation middleware
-func (siw *ServerInterfaceWrapper) PostAuthRegister(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostAuthRegister(w, r)
-	}))
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	handler.ServeHTTP(w, r)
-}
+	r = r.WithContext(ctx)
 
-// GetAuthVerify operation middleware
-func (siw *ServerInterfaceWrapper) GetAuthVerify(w http.ResponseWriter, r *http.Request) {
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteUsersIdSessionsParams
 
-	var err error
+	headers := r.Header
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetAuthVerifyParams
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
 
-	// ------------- Required query parameter "token" -------------
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
 
-	if paramValue := r.URL.Query().Get("token"); paramValue != "" {
+		params.XCSRFToken = XCSRFToken
 
 	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "token"})
-		return
-	}
-
-	err = runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAuthVerify(w, r, params)
+		siw.Handler.DeleteUsersIdSessions(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -377,11 +2940,19 @@ func (siw *ServerInterfaceWrapper) GetAuthVerify(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// GetPrograms operation middleware
-func (siw *ServerInterfaceWrapper) GetPrograms(w http.ResponseWriter, r *http.Request) {
+// GetVerificationPolicies operation middleware
+func (siw *ServerInterfaceWrapper) GetVerificationPolicies(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetPrograms(w, r)
+		siw.Handler.GetVerificationPolicies(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -391,63 +2962,58 @@ func (siw *ServerInterfaceWrapper) GetPrograms(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// GetProgramsId operation middleware
-func (siw *ServerInterfaceWrapper) GetProgramsId(w http.ResponseWriter, r *http.Request) {
+// DeleteVerificationPoliciesDomain operation middleware
+func (siw *ServerInterfaceWrapper) DeleteVerificationPoliciesDomain(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id int
+	// ------------- Path parameter "domain" -------------
+	var domain string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "domain", chi.URLParam(r, "domain"), &domain, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "domain", Err: err})
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetProgramsId(w, r, id)
-	}))
-
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
-
-	handler.ServeHTTP(w, r)
-}
-
-// GetUsers operation middleware
-func (siw *ServerInterfaceWrapper) GetUsers(w http.ResponseWriter, r *http.Request) {
-
-	var err error
-
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUsersParams
+	var params DeleteVerificationPoliciesDomainParams
 
-	// ------------- Optional query parameter "limit" -------------
+	headers := r.Header
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
-		return
-	}
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
 
-	// ------------- Optional query parameter "offset" -------------
+		params.XCSRFToken = XCSRFToken
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUsers(w, r, params)
+		siw.Handler.DeleteVerificationPoliciesDomain(w, r, domain, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -457,17 +3023,17 @@ func (siw *ServerInterfaceWrapper) GetUsers(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r)
 }
 
-// GetUsersId operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersId(w http.ResponseWriter, r *http.Request) {
+// PutVerificationPoliciesDomain operation middleware
+func (siw *ServerInterfaceWrapper) PutVerificationPoliciesDomain(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id string
+	// ------------- Path parameter "domain" -------------
+	var domain string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "domain", chi.URLParam(r, "domain"), &domain, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "domain", Err: err})
 		return
 	}
 
@@ -475,10 +3041,40 @@ func (siw *ServerInterfaceWrapper) GetUsersId(w http.ResponseWriter, r *http.Req
 
 	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutVerificationPoliciesDomainParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-CSRF-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-CSRF-Token")]; found {
+		var XCSRFToken CsrfHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-CSRF-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-CSRF-Token", valueList[0], &XCSRFToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-CSRF-Token", Err: err})
+			return
+		}
+
+		params.XCSRFToken = XCSRFToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-CSRF-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-CSRF-Token", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUsersId(w, r, id)
+		siw.Handler.PutVerificationPoliciesDomain(w, r, domain, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -610,27 +3206,126 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/auth/logout", wrapper.PostAuthLogout)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/auth/me", wrapper.DeleteAuthMe)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/auth/me", wrapper.GetAuthMe)
 	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/auth/me", wrapper.PatchAuthMe)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/me/export", wrapper.GetAuthMeExport)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/auth/register", wrapper.PostAuthRegister)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/auth/sessions", wrapper.DeleteAuthSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/sessions", wrapper.GetAuthSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/auth/sessions/{id}", wrapper.DeleteAuthSessionsId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/signup-policy", wrapper.GetAuthSignupPolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/tokens", wrapper.GetAuthTokens)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/tokens", wrapper.PostAuthTokens)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/auth/tokens/{id}", wrapper.DeleteAuthTokensId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/unsubscribe", wrapper.PostAuthUnsubscribe)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/auth/verify", wrapper.GetAuthVerify)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dev/mails", wrapper.GetDevMails)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/email-outbox", wrapper.GetEmailOutbox)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/email-outbox/{id}/requeue", wrapper.PostEmailOutboxIdRequeue)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/email-templates/{name}/preview", wrapper.GetEmailTemplatesNamePreview)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/exams/uploads", wrapper.PostExamsUploads)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/exams/uploads/{id}", wrapper.DeleteExamsUploadsId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/exams/uploads/{id}", wrapper.GetExamsUploadsId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/exams/uploads/{id}/chunks/{index}", wrapper.PutExamsUploadsIdChunksIndex)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/exams/uploads/{id}/finalize", wrapper.PostExamsUploadsIdFinalize)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/exams/{id}/download", wrapper.GetExamsIdDownload)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/programs", wrapper.GetPrograms)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/programs/{id}", wrapper.GetProgramsId)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/quarantine", wrapper.GetQuarantine)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/quarantine/{id}", wrapper.DeleteQuarantineId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/quarantine/{id}/download", wrapper.GetQuarantineIdDownload)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/quarantine/{id}/release", wrapper.PostQuarantineIdRelease)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/storage-usage", wrapper.GetStorageUsage)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/users", wrapper.GetUsers)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/export", wrapper.GetUsersExport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/{id}", wrapper.DeleteUsersId)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/users/{id}", wrapper.GetUsersId)
 	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/users/{id}", wrapper.PatchUsersId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/{id}/sessions", wrapper.DeleteUsersIdSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/verification-policies", wrapper.GetVerificationPolicies)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/verification-policies/{domain}", wrapper.DeleteVerificationPoliciesDomain)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/verification-policies/{domain}", wrapper.PutVerificationPoliciesDomain)
+	})
 
 	return r
 }
@@ -638,28 +3333,125 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9RYaW/cNhP+KwTf90MCyLta2zVQfWvdHEbdxojroICxMGhxpGUikQpJuREM/feCpE7r",
-	"2N36CPJpVxKHM/M8c5H3OBRpJjhwrXBwjzdAKEj79xL0qRBfGJgHFW4gJeafLjLAAVZaMh7jsiw9nBFJ",
-	"UtCV3KmS0Xu7jXliHAfVrtjDnKRG+O+D08uPbw/+El+AYw9L+JozCRQHWubgzStzH62mN1IKqySTIgOp",
-	"GdjXUL9+IO3hFJQiMYzt3LXiutqiFVh7tYC4/QyhNptdSBFLkg71M9pRwLiGGKQRcM6PmHUHUjHBrTAF",
-	"FUqWaSYMcOdMaSQidEcSRtHFB4UiIZHeMIUypx69gkW8QBcfDv3ViWd/D49eYw8zDakaVVe9IFKSYuA5",
-	"ozVNHbvG3L9SMII9CTW7s14Cz1McXPveqpXugBFKIBroDdFmcSRkav5hSjQcaGbVDwyHlLCkt9y9GVnq",
-	"OOiD+cm5g47R1dXZb2NSkwxVYE8xK0UCTl9E8sQYlisb7xUIzSNl2sYVoSnjHVhbTXlG98blDiSLGNAd",
-	"YK+Xzu3P8yQht8Yjl46T+m5yrh8Qssc+Y4FX81kFoMXVq4Oq42iXkV4s9QCcCttzEZuyNKgbu4dXRpT6",
-	"R0i6QyWptmgkpoz6CDFTeiyn9rCrDuCU8XPgsd7gYLXF/O7SE2/P0J9wtqKvUdPdZei/KeoQ5pLp4tIU",
-	"d+d1aHvPL7kxrGoj7lXbRm5u3gulDxQok9ctHiRjv0Ph2gXjkbCWM51U39rKhgPsL1YL3/gpMuDmY4CP",
-	"Fv7iyJqvN9aUJcn1ZhkqGZmnGGzqGIaIqSxnFAf4HWhjqul9tqGpTHDlHDn0fecP18CtKMmyhIVWePlZ",
-	"Cd5vsH3ya63zUWZXjSBbeg9qoOm7SJu+i5hSuU2lfs8/aJv+/yVEOMD/W7YzwrJavGyng7LsMoiD67WH",
-	"VZ6mRBY4wGdGCyKoVWx4IrEyZlt210bcQZw0iSnUCMgXQlmUXf46BEDpXwUt9kJ4zK9qrFi29aHsg2xq",
-	"WPlIarcpHmPsXMQxUGQdfjxTHj72V09msxvARow+425iCSVQ4JqRRGGr++j5db8xRQhxoVHTLuYC9FzE",
-	"DtyZoBS53ikqzbr+OHw97kS7ZNkZl8v1IL6OR2ZCFxBO1w8TEX8KjQycJhpC06AfktKv+NfrcsCS83iK",
-	"Jtf45orzH4C/Q/6e5lIC18iOgD8I2u9Ao7Br9zTssju2zOZHM+A8X+FuVOxUu1fPzr15j6rp1HHvv1zt",
-	"zUiRCFLp/fml6i5JJBBaIPjGlFazlbdmC5FtQWYLebEtvz+5VYMCbGfHrznIoh0d9d5XD+snHetmbyK2",
-	"TnEO6rq9IZWHISgV5UlSvHiYOSTneHa8WI5Rc3IZMl0dEdQczRf1mkeS0VyOzLleX/AMr0yGQ1p1T9P4",
-	"MDtxmMUkSZrViHCK9AaYbC96Ohg1TvdxWt4zWu4C1hmdyAlzwGlTwh6lt+ZDe/RbP2MzbaAfQt1h5diN",
-	"R8/fSiOR8/kp0nTM+kbutkAWywn+TB7MBvmVXbBTGUtYysxQ1PrYXEEdHXo4Jd9Ymqc4OPzpxDMnffe0",
-	"8kYO8uMKRBQpmNDgd7b0vacPkJ2S9Koaq3bNUIf+S51F3gp5yygdFsgt064x1lqKXkkwnSHUQF93YsrF",
-	"SCegtlYDK/GkpeDJWuN/Ha2+fw3YPkbbrvewIjTsbdut/DcAAP//T2dwMqAZAAA=",
+	"H4sIAAAAAAAC/+x9aXPbRrboX+nCu1UvmYIWL3Hd0a33wZHtxDN2rFiW56VCP7EJHJI9BrqR7oYkxqX/",
+	"/ur0goVokKAWSprok0UD6OX02bf+FiUiLwQHrlV08C2aA01Bmj+PQR8K8ZUB/lDJHHKKf+lFAdFBpLRk",
+	"fBZdXl7GUUElzUG77w6VnP5shsFfjEcHbtQojjjN8eP/u3N4/PHNzifxFXgURxL+KJmENDrQsoR4xWRx",
+	"dKJAvmGZBvky0ewMqjn+KEEu6imofdocDHiZRwe/78dPvsR+ZMY1zEAuDX0kxUzSvG/swj5maRRYa3jE",
+	"X80IB9+iFFQiWaGZwIEPqYIdxhVwxXC9RJUTu1kipkTPgeCUREgCOWXZbhQHF/RHNBRoH0XWCzKJzwIA",
+	"i0plDg9SpgX+QdOc8ehLvHKmzyDZlEHaN9uZf361I/LDn3DNspdTDbIL3Q88WxBcvCLnc6GA2DkTis8J",
+	"8FQRqhG4FL8nes4U0SyHPjD7JZ+WOOmp+aq1/KmQOdXRQZRSDTs4VDQMSGYXP8JUSLjSNibm0413YD/b",
+	"fAuX/gtD8C+TRJRcv4IMtFl+IUUBUjMwjwuq1LmQaRg1a9r/vX6zPnwx+TckOrqM/SyvLwohdXcWWrBT",
+	"jfzE/GIacvPHf0mYRgfR/9qr2dyeW/rey4JZDnRZTUelpAv8nYg89zxx0GB2XZAe2g9DY8IFzTcf8PUF",
+	"zcOj2eenVA89tzgqhLrCno6ECm5IgVJMbADxY/tBaCzDY9Z8jnTTQZomHGLPqqqVxU3E8PtvHK8/lSDG",
+	"efzoIFsigW4KergomAS10TcsRDRxlFGlT0sFae9IvMwyOsnAS9TOEJYfBMZWiShgcxo6xs+657p0VkZi",
+	"mqmrieImMFtQWnUkh+ab7sH4zxk/TelCWV46pWWmo4Nn+3GU0wuWo4h59uKHOMoZt7+edIVNDaKcXrwD",
+	"PtPz6ODFc/OR//kkvnn45Yy/tR8+WQPMNhzXQ8tgC82yD9Po4PfBnHEZwtpTRFtGHUMiQRMtiAKeEqrI",
+	"+GWp50KyP42YOiA/ApUgyajc33+WmFHMnzAOisjmRu2U3Q1+aWzRwq+hsxRSTFkGBxJo6viCav84l0yD",
+	"ZwD+kf1hH30JHO8hLXQpIX1PWdZFv6kUeZCqeghZ0vMuKD/NgeCZoDQlOShFZ4AAVcB1iElISICdbciN",
+	"VGmBGFqVFi307T5fS+IGDmageqb2Qu3eQ0h7KLgGrj8tCjjBrXehnLMcTu13gdXxyUKDCinksZtF9Wjr",
+	"zW3Uc9RfVWOHlv0a9fMjCWcMzrtLnus8C7NykVCrka+ix3f2rXXnBhd6vZLlZmwejFmd+z64NymF7G4K",
+	"/H93VuKwtovbP5c55QRpDaVTTBg3Rk5G+axENMeFgtKQksmCjF8mCRR65517Oibf/QQSB5gsiGPr3++u",
+	"ZR92mfWigju8oHl3g8kckq+qDJM0corT1EmhoQR/ddStbc7g47LIBE03ZALIB3sWegbSaGpr0Yl5hmr+",
+	"aFrGfogmqNoLjVtk5nYf12DvO6kTM0bovEr+9VSxPwOYd8z+BG9Tm/cU/qL2b0iJXVcDmxrAte93x/yl",
+	"zCcgcZxNR2wrg+1RX/PUL/SMZixleoG/x6XMxjjasLOdMk4z9iecThbDEaLh/aFpynBBNDtqy//lb5YI",
+	"3I5gVm8B4GmazKny+sFuFDjZPpIBPRdpU7QfnXwKyuZSZgPx1Y1pP6m33TqXNgjjJnJVGLEaQT/aja/m",
+	"K8vguyDAE5FCSo5/frnz9IcXHhdQm0G4FVRrkPjy//t9f+fvdGf65duL55f/FbY5GjyqPdNvv/322877",
+	"9zuvXoU+bPEpD3daFJnzOuwV6TR4BjULW61cr2Fng9nPeoZzHRZzrKku1TpGs4phdJ9diTB7aGOVwPDa",
+	"Vvfo3/IULkAtcUP/PlGCTKk0HqQlFbAx+gAzr4Z1l3Aay2tDJHwebddK5zgmIl0E4dO204dCtRBK9zwq",
+	"i7R/wBAU3FixXeOSsdsYbdW+vXLSPsX3oGlKNSWCZ4u4YhGEaQXZlCSoJgFJxTm3AhdNiFKV1DiR74ue",
+	"U2E/4/rF8yi+tuJzTUVmG/pLw6fWtf1YDkrTvDDU6V1bJLE2EaESSEGVgpTouRTlbG4MQy0kpN1jvSpZ",
+	"pMaRa+C91o/Uc/6a6QxuhoLsUGsJqF52COrvKjPLC7MUxwQeFGIfSj0RF+9rI2bJ1aw15IXu4YxX8Q2u",
+	"8vNVVtbas+BwoU/d4jaaXkLCCuZYa+epAr5yuLXrUpUUXWXjWqA7ibvS0g1hSb2FpmHrZo7rI2sBtQuy",
+	"JfzyWw+hVGu9HUIep0DTsXffKDKlLEOqFYKIqQZOKHrJJBjuTSRoySAl53PgVl8uId0d8WND9DwlOFo9",
+	"GH7nsN2Frp7tE/R37o4Mu/IuMOApAszso/7L0gpNg6h/JEGxGYf05OO7fvfqRkbmEK3c6uFrnL9H1qV3",
+	"UoQ1WhMmQzE4Y2fASb1yA69kTvksxCU39cBIyBn3VtK6eGVoDz6y3F5Gn3zrddQ78RTAvXdMaRQfxnok",
+	"Rx8UmQoX3XTyjXwHu7NdcvTh6f6TF7H59+mz7wMa3yZOP+ePrtYVOsJfSyop14zXWs2Ddbn8Ue9lM9cr",
+	"m3Gqy1Ck919zqg0K5zQ7R6xVCeUcJJmKsmU1360PZ7XW09xhB0ohpPBRwRsJsyWllE6SLcMW9NxH+Zn1",
+	"ULgwIcnpV0y5wP9y3op66IkQGVBuFaMzlgSO7VBQqdCLOYGMqDIxWtkoesMkTMUFEZy8Y7y8GEU3Gxds",
+	"L0IU9I8y/HbRr10oAD58XsSRUzrr0xR6cXElvrUkbr2oJWdMY2qzo+o06iMP4hab8bI4EhlLFl0ES0VO",
+	"WYiFGm8+cY+JKIAbHiphxpSWxgWyS17nhV4QNiWUL9y7iFg0y8S5lTRD2WlsEzMW/VjL4dylgOSl0mgQ",
+	"TJnMEWOZyw0iNE0lKLUbQN0l+PttV9MGIaeFpLO+GMxkUfHUQXHOTlQnAIOaF7dh8CP+t/GtZpkzdoyp",
+	"q8K+1RVBHmRHQtPuFL/if3uHyPlcZGAmwpAEw3CD7ptMggIZ9LLYVVPORckTG9BwCplzjPaMqEVxak56",
+	"MGwxLaJ1WuvEdcWz68hWtQ8Porg64uaSQohy4jI3loykKjtvpY50NXvJIHzrdfs/A1nlZyvVyHNycvI2",
+	"6P/cVCns1dLW6BAtZbJPXoGhfGQt7vW0zvdC8u+kg0XxOqBLlwZYJUdUOX4bpvwtG/TDzu+skRu4ZqX+",
+	"1WtZoO20t6uOE5JiHu+c8utSKKvk00aSY1OtqmKw9flv5hpEmnsnZiygNG1AG8Mz8/wQKzP0cFFHQWlR",
+	"sbHB/CwoH9BYT0qpBvpDtNA0WxW1cwKV6mTu1b+pSctUhCZSKCPLSYE2d4hXL+OD2Zuftr3cPnB9NApF",
+	"iH9ucIxXZVZrMpma2NF89UW8IZvrwSVHMtU0zVH64LVaKdkAZr0M+4rpI8M0C0S4/60Icol1eoVouys3",
+	"4McDlfBK/3bn4EHlWFgn6cXvsO9obtgtM1h/uDqkriKFKgHSH7NvSuNzxlNxHpMxMquxsSG4IMawWfTG",
+	"8teLoc4BfG5M2mfsTNCHQCULqdi/AZXZgiSl3hHTKcHVKDRiTYA4JnBGs5Iad6MmOUs5m801Ofl02LRx",
+	"lgLTX749vdzxfwSj08v83Zolq40xIiQZ/20cGi4trWFWZX42I9A9MG0c8VfG03U8tAvmf+JXV9KCgjZZ",
+	"5NYRN09reWtrtYOeZXad1ApyUBrkuIW1ipgUTuuJQilG3GIWBjHiER9LkWWMz0LfjVuLHTsHtXlvgVKW",
+	"8nTExxzOutOmgnChLXlA25ftV2oZVGad2WaQIGl3IVCzp1VUcYPIvAobbxb7ljDJDNVFCxPJSUrJ9OIY",
+	"B3TbN1m5mKrbRY8jkAoTgAhNElCKmDxc4tTUKgA5Pvpw/Ins0VLP98wbymQpmUUbJ4SZoQbZXOvCVlhg",
+	"bZmfGene/VddrXJ6+rNQesd56eohaMH+CQtbh8L41KSruogjPmv4LQ+i/d0nu/tGeBfA8eFB9Gx3f/eZ",
+	"zaSZGyDYxSdKTvHXDAwNI5IYUL9No4PoJ9C4VKxts9ZyIbiyIHy6v4//uBhtdPCtlSjzb2U9m3WFzZKP",
+	"0826mjuYtwJn2sm/wro6d1BMqRLSRnqTrenbqYv6QsjmXt6rq/8uL5u4Ex38/iWOVJnnVC6ig+gtzkIo",
+	"qSfGc6Izhcs2p/sFP7cgziqTxUW/20DGmDh+Yi0bCwFQ+kcXxx4M4XXGhR3/sg1klAuX1zzaAcUrnRN7",
+	"J2YzSInZ8PVPKo6e7z+5sTXbxN/Aot9yG1xKJKTANaOZidk+3392+3NbdQAFhdfKYkJtcRjGSVFvNAzK",
+	"F016zYELkgk+A+k9tNEqvH4nZoStwWVR6kHIjO+1q2R76i/qV/YaVbSXXzpo+TwQ9bN4ZOd6MIj0i9AE",
+	"wYlIlFDdPZRvLUHx+5fLzinZHfcdU+5MEl+b2J7e1izaYJDHIauwOA+79wt4K3WX4LmqmPgKshHH+Lwp",
+	"GzEGzVcoNJmUmlCtJZuURlQKQskoqkL2dqJRRIqMJjAXWQrSqjttFLKrwx29h+sj0M3z0nbh5yB+GkDc",
+	"lxXtGvDEVVTOHjxJMqAS0hvD6v3tsUcHdGJyl+6QpLbEmf8lBZ9VpIIMmDlANLQDs5i/b2ExoszwBHJx",
+	"Bq7IRGlivQEb8RiL3iRfeMLtcpvYK40dzIZCK1KEFGlFzpmem5WNm8VqY2Iq+najOKyCvofoDrSUQxtj",
+	"NR6s6P4Kh7ht0Swf5U+gSdLcSeAkC3QHX8ONtaQF4Gj3l4O3U6ruiUZsV5M2cO2RZd/2tpsMehPmaM8K",
+	"mWMhYQoSeAKqS1YNdWwPqg4OQab5+gzkQpuAjIv104korTOqSbsx+icp+cfxh1+qLPcVTNP1jbhFdG43",
+	"qAjA+hXV1OV0t5UZlxyx84qpQiim2fK0nf4bD0Q9t7BA7MBKhRVoIZtxsGBm/BvKMicyx8asO7Vm3SkX",
+	"+tQZdGNS8swI2Dk4228CaPOZwjPBYcSdk95n9mSsKjb96bX3YimTM7RTGBfbOKSYe9uuCt/dnq+immIQ",
+	"c35y+8xZgfQ+wK0z54IukMy3pkNaVwPNUC9bELhgSquVXgN/WoT26BcVxjd7p/RZpx/hTHwFG4UWUyQj",
+	"/xWBC1QtW0xR8IDSWJuQx37CW/dEfDB5K9VSpdlF+ihMh3ozCIY1FudzkEAgU7DC3ggKu8ZJX0vYXa+l",
+	"Txc8tmFbhRgxyYXSpu6Qa9vhCvP6pNJ3iSobnRdT2tqFrY0NoPq9byy9bJP+Orp9m3Yp10RNMIxRx0xc",
+	"Nczghno3T/5uwRXdP2xv5B2yDpz6+XY2beoLNkN+K5xQ6izJplXo39Ss1sXaWqnbt6i3t+YJwMc+J4V/",
+	"oV/6/wRWIsP67PEVQKq72K2Czif71jZYfH+fvC6wgjFjFWP+Oij9MNl72H0X9B2Fi3pNpYnpj8WULfhD",
+	"CU8k6FJyE/uzVSn+KHd7DY7q1O9fLKDdmW3L1spyp7MAMnxqZi/creXyqASvoDp7hISGyW4t39xAs7LE",
+	"9GD0KovAf21r6kGoRJujbsmx73Mi2QT6PWCfqJyB9mmmY5ROOyf1h2NiNWd8wZcVWE1ExeR8zpL5iOMv",
+	"kmQMoUASdCsYH6rgsJNkLPlKGgsh3318c0j+e/+H//5+l3x0A6oRd41FnOgSnNAZZdx55o5efjr8mXhX",
+	"70rfWWPpPQS41LtYb9wtfBBZNdaxfbEQ5I8tnDrWoiASdlppxc2ykT6cqisKV6mRn+1bWzuA66TKNboK",
+	"rk2J7vPl+ZQhLNNF0pyWWba4h8duz8UWflVFDN2TTuFsD5+q3nCKiVvSM8pM+rMj01evP5++//Dq9f/B",
+	"AxybDhOGp2AscGxSrHPIhVyMCQ4+4lpSrgohteMkJuaZA+XaGDYpnEEmihy43iXvQCuyECWZCvTKj3gL",
+	"czOGvaZwFejoomZ8YkoPgxkwP4F+BWfvzQ63Yeq02rsOMHf8+xWj7do5WxJXjDePgeQihZgI6boJsIxU",
+	"Z0hSAcrkzyV29W7xq9PhmKre97ttoOQrOHMYaR7tCNMcpRcpfxSpj5xzs/YkK1OT+sN4YrI1FiSnC9P3",
+	"CIVLF4WCkT5D4rYvyzCOVvWHGXYA7RY1l3F40IzlTLfGrKosf2i2oH76w4vVLagvv2wD5dvNjgbgvHs1",
+	"iOxb5KG2Um9r2ugbIScsTTd26SPd2H4+hs0qJE5LPuQ7CSjAEg3p9w1aMlgcoCZj2ey57kD9auJHUMiB",
+	"TUajbWxETFwaZNit0CCat7ZlZQn30SK6OdRawvheDHfndhcotn07Z0sR1Zculop0sKlxpeWC0KqJgtE7",
+	"qJNFhIvz4fSEVJFRDWrvG+Ly5V5R9+0OJ/JV+pMWxFYWKkPOJp1whTD65Gf6hebgu4MPIS5fT95LXr4w",
+	"qikazQet/wjd0dMjtaq24IMO2FcT3yqhtnqqB5DpI6AR5PWRrUkgtBgL15kxq6qq/0NZxAn/ysU5J55m",
+	"NiVa44ag3JGpH8XaIYrmRQYmN2gQ8WKS/Z5rntIvAAdn3TbuevBJtyNuG/8q4moB0LehbFTHN4T5o6+i",
+	"25lRI/5HfzcZvBnBjUal3h3xjyYIgF4YNRdS72SmB+/Jx3fIao5OPtWNXbXw1pcJIVC7zRHHF+o2M9hn",
+	"16zEoQVB+t8lL1FZ4hTj1K1usdabM+JGL2G80ch8XPftHdtKdT+9KuUZOwNFGNcgZVloSEc8EZxDgmeg",
+	"dsmnuv23a/s9AeL7/FbtS8aNzr/jeMSFnoM8Zwrq9THl6wL+x6ZF2BXW3YoxvVuNeGuoPrcTtpxT7oDv",
+	"Ywyl2zp8y2GUegHhvFxzoAZz78BX1szMRelfOs7kexr6ms+/gmdcldMpSxgSrWFcK2otnmxDLFn+JEiG",
+	"PurK7+A4wLmpxMCMNbB+pg4H9R84LmnW/XQL2ugnIUhO+YKUfMo4U/Pq2gZ13VKDY6QSN5gtezdFYk3R",
+	"Zi8e64q2QAjr5iRcT4ZgkzU+mIiYY0h0ImqG9Ej42wyUuRPgg+Nla6jmJR4l0oolhQC1XLfUKqj0oZdG",
+	"rbgMIcZ/KW8qPJ65JZQb3UczXkI9T86UMlYqB9Xj2L59krtV22z5kox+7DACGpT6SxLonZGiqX83nVRu",
+	"pGzOFEG7gzS9OFfQaFii7Vna2vuGocsLI+CK8sZJ+RAnIWMzx5gk4szfRmRMGGt/2afkb6Rh4sTWqjL2",
+	"m6kUNc9MFGIC1jYDuUuOnffJXfPkgt8STCW3IkwHbY9yidjNIpW5h+WWRG1oEDdd/zhVMGI/1Oxug7uK",
+	"DGyqO4gDt3/j853DumP0raoRQww1kWjQO0pLsF3JAxciTxinxmMWuA35CmXvFlFtidsWbSlEfAy9YpYI",
+	"1qwimzg3ddsWoZEYHjWpu9ekWuwbzaGnWzCHPOnWIWLTHJMwrUjV3/2awsRtVHA/l7k0D509wyWJd/bc",
+	"lhPwH4JxtXRVYPtaP2V8aNxBxVBNXIHI9jhDNlEZue7WILPP2LT1R2Fhsid8r3902hXlJEMzVFlBhIvb",
+	"JWhdKzLNqGnwMrFl6P5mAPyy0WPfJZqjxxzfROXUxCgI40oDTf1oek6177dmz7hxqYhRd00Q3rsHq/Vb",
+	"eWe6IDZNfIupaojj7W36xp/ePbQyb9aXFsxDuqB5dc6PRus9ZLX7f98OqzUObnSP40omALxmEwvQW+X6",
+	"ZilLTL8bV2hwOCFNYKGRYrR0YYnjVszs44f9Z9vZx/IymCIlr7Lfriu63hgf4YZOPSOwfIOC/gjzZvJq",
+	"XaMYs4y36Ss/7QNzLrRuoQqVZDVCZR60Ji/s0cVwq+XwKLp4K2fkyf4WGZRTppAEkLK9j60RU3Xhg+u7",
+	"O2gbr4xatZraXRhqZeHekX9nG4l9brIhKX3+xrBqD2tTQU1jfPe2D3zjVRT+yrEGkKpNt+FURTnWAetG",
+	"faM3l1w5CPSBAsn6VO62lKWD8j6OOlkQlvafX21sbEuY1ZfGbYVulu+oG0A/jU9q+/C+lLzeuwTZNezX",
+	"pcx2INqbInQRQs67CKLWaPDQQqjO8P9roemWOHCXOdxcvNTiHaEBerkCuaw3UypV7JzWHimqvN0Vo09L",
+	"zU2+hwmnTMC0P7A17pQoOkWvFj9jUnBTLjTi1xAW4bhqkwofDaBHCr7fFNxrbNwQSUvIgKoVnvI3QpIp",
+	"zRQQ23vwzN612PAx23ROb3VVrksi+IhPYE6zaSuX63wuakcWuwKJLznjgx7lJpF/dFt8dCc/CvCHRv5H",
+	"9uTC5G470btozgDad/6PndKXSQdluLk8jTCX4g5pw3/C/rTE37xkNV6+tnTEqQQiwVVAKEBq0JAtNqF0",
+	"5BRDhHnrwrcOgfdd7KdFQRLBVZmb7A9h+nxW+QjD6zWfNOs1n+zv33K95souVU1AhDQC+5zYs992braF",
+	"4YMzNY/n4pyoJuSML4tptYRCA4ivurM3SHQ2w3Dpwkl0otl24u7aybbT4Dbo6UTZrhGb1T7gV2/MEn81",
+	"tHMZb/DJR5HBZl/UPrINPvrsb/Pb6Cvbk/JqM51gFPzl1FYhX/X7H00pTHSFYvJnTzcqJo9Dtty4cUnp",
+	"2GtxphpSlMrcfNrHNe030V0ZSNVdsyEXK9Kxv971jurSMS7qQPTwuCJQmcwds+rhfJaPNDjf2rbu5rHt",
+	"ZRy4d3eJDcLubNcmxwDKdDVnxYhblUPtktvhilWH+EfeeM9443pGouFC7yXqbE3b/O5teZ+dOwtRhvoO",
+	"WlKcP7azGMgsLNU4kqaKIEgH84wb89FbQl9yHCzd1EXqi7qa7orq0i7K0/alXba01t/aZV4edGGX2efD",
+	"iQDgvlr+/8dWF3d+AdVQt79ByjXkdt2Kqa4Q7RWhD6+MadU1F/ew3eQAZ7JBieUYfo0LPddbXY/TvvLX",
+	"btrSGLME2b68wuaH+B7hxqXMZDXdiLfnsx4mbOWHnLfONlY2p6++5NPkI6OhjGSlSEI5F5qkkAsNrkWM",
+	"excHyBVkZ6DiquOdJUl8A3yitBtiAm6UtD1MGvRII1DvF9u/ndtoHi8KC3d0fhSZ911kHpob+kyjltjz",
+	"DyY4Eneru6CpRhkiVtta7KD7fK7DZFfrmv2X+9znK0KW7gb6Tyei6yGwby6dZfXVShshaxPN7RUcrq9u",
+	"UD08ci+QFBKWAkFPOV6l5iw9Ma2bOrn7tZWmi6q/7u6Io3/RTLMwnpz62jUy/tuYGFCDCc4YDcHcGWWf",
+	"q//xDWJHnOm4RZ9m5tbLvorJVnDchs/8c2N+D5atZCJ2Jl4MSUZsfkX8ORMhbau0ycIB7oEmBp4Fd3dV",
+	"9N/7ZoFxuWW2HUKpV/ZYhnDw1L96p1zcouRfy3dwQ3Z7F4sX6834ouzt7z2dQqIVmZamn3NzdLU5R1yb",
+	"f1PqB4G/N28CdRny3RhEIcEQTgmAtLq4atv2UWPahyRl3P0zpozauHuvRq5XWMXl/x8ATSZU242/AAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,250 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/database"
+	"github.com/fachschaftinformatik/web/internal/i18n"
+)
+
+// deletedUserID is the placeholder user created by migration 008 that owns
+// the posts, comments and exams of deleted accounts.
+const deletedUserID = "00000000-0000-0000-0000-000000000000"
+
+func (s *Server) GetAuthMeExport(w http.ResponseWriter, r *http.Request) {
+	session, dbUser, err := s.authenticate(w, r, "")
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	export, err := s.exportUser(r.Context(), dbUser, session)
+	if err != nil {
+		s.Log.Printf("Failed to export user %s: %v", dbUser.ID, err)
+		s.jsonError(w, r, "server_error", "Could not export data", http.StatusInternalServerError)
+		return
+	}
+
+	filename := fmt.Sprintf("export-%s.json", export.ExportedAt.Format("2006-01-02"))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	s.respondJSON(w, http.StatusOK, export)
+}
+
+func (s *Server) exportUser(ctx context.Context, dbUser database.User, session database.Session) (api.AccountExport, error) {
+	export := api.AccountExport{
+		ExportedAt: time.Now().UTC(),
+		Sessions:   []api.Session{},
+		ApiTokens:  []api.ApiToken{},
+		Posts:      []api.ExportedPost{},
+		Comments:   []api.ExportedComment{},
+		Exams:      []api.ExportedExam{},
+	}
+
+	export.User = dbUserToAPI(dbUser)
+
+	sessions, err := s.DB.ListUserSessions(ctx, dbUser.ID)
+	if err != nil {
+		return api.AccountExport{}, err
+	}
+	for _, dbSession := range sessions {
+		export.Sessions = append(export.Sessions, dbSessionToAPI(dbSession, dbSession.ID == session.ID))
+	}
+
+	tokens, err := s.DB.ListUserAPITokens(ctx, dbUser.ID)
+	if err != nil {
+		return api.AccountExport{}, err
+	}
+	for _, dbToken := range tokens {
+		export.ApiTokens = append(export.ApiTokens, dbAPITokenToAPI(dbToken))
+	}
+
+	posts, err := s.DB.ListUserPosts(ctx, dbUser.ID)
+	if err != nil {
+		return api.AccountExport{}, err
+	}
+	for _, post := range posts {
+		apiPost := api.ExportedPost{
+			Id:        post.ID,
+			Title:     post.Title,
+			Body:      post.Body,
+			CreatedAt: post.CreatedAt.String(),
+			UpdatedAt: post.UpdatedAt.String(),
+		}
+		if post.Deleted.Valid {
+			deleted := post.Deleted.String()
+			apiPost.Deleted = &deleted
+		}
+		export.Posts = append(export.Posts, apiPost)
+	}
+
+	comments, err := s.DB.ListUserComments(ctx, dbUser.ID)
+	if err != nil {
+		return api.AccountExport{}, err
+	}
+	for _, comment := range comments {
+		export.Comments = append(export.Comments, api.ExportedComment{
+			Id:        comment.ID,
+			Postid:    comment.Postid,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt.String(),
+			UpdatedAt: comment.UpdatedAt.String(),
+		})
+	}
+
+	exams, err := s.DB.ListUserExams(ctx, dbUser.ID)
+	if err != nil {
+		return api.AccountExport{}, err
+	}
+	for _, exam := range exams {
+		export.Exams = append(export.Exams, api.ExportedExam{
+			Id:         exam.ID,
+			Programid:  int(exam.Programid),
+			Version:    exam.Version,
+			ExamDate:   exam.ExamDate,
+			UploadedAt: exam.UploadedAt.String(),
+			MimeType:   exam.MimeType,
+			Nbytes:     exam.Nbytes,
+			Checksum:   exam.Checksum,
+		})
+	}
+
+	return export, nil
+}
+
+func (s *Server) PatchAuthMe(w http.ResponseWriter, r *http.Request, params api.PatchAuthMeParams) {
+	_, dbUser, err := s.authenticate(w, r, "")
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	var payload api.ProfileUpdate
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		s.jsonError(w, r, "invalid_request_body", "Could not decode JSON body", http.StatusBadRequest)
+		return
+	}
+
+	if payload.Reminders != nil && *payload.Reminders != 0 && *payload.Reminders != 1 {
+		s.jsonError(w, r, "invalid_reminders", "Reminders must be 0 or 1", http.StatusBadRequest)
+		return
+	}
+
+	if payload.Locale != nil {
+		locale, ok := i18n.Parse(string(*payload.Locale))
+		if !ok {
+			s.jsonError(w, r, "invalid_locale", "Unsupported locale", http.StatusBadRequest)
+			return
+		}
+		dbUser, err = s.DB.UpdateUserLocale(r.Context(), database.UpdateUserLocaleParams{
+			ID:     dbUser.ID,
+			Locale: string(locale),
+		})
+		if err != nil {
+			s.Log.Printf("Failed to update locale of user %s: %v", dbUser.ID, err)
+			s.jsonError(w, r, "database_error", "Could not update user", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if payload.Reminders != nil {
+		dbUser, err = s.DB.UpdateUserReminders(r.Context(), database.UpdateUserRemindersParams{
+			ID:        dbUser.ID,
+			Reminders: int64(*payload.Reminders),
+		})
+		if err != nil {
+			s.Log.Printf("Failed to update reminders of user %s: %v", dbUser.ID, err)
+			s.jsonError(w, r, "database_error", "Could not update user", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	s.respondJSON(w, http.StatusOK, dbUserToAPI(dbUser))
+}
+
+func (s *Server) DeleteAuthMe(w http.ResponseWriter, r *http.Request, params api.DeleteAuthMeParams) {
+	_, dbUser, err := s.authenticate(w, r, "")
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	var payload api.AccountDelete
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		s.jsonError(w, r, "invalid_request_body", "Could not decode JSON body", http.StatusBadRequest)
+		return
+	}
+
+	ok, _, err := s.Passwords.Verify(payload.Password, dbUser.Password)
+	if err != nil {
+		s.Log.Printf("Failed to verify password of user %s: %v", dbUser.ID, err)
+	}
+	if !ok {
+		s.jsonError(w, r, "invalid_password", "The password is incorrect", http.StatusForbidden)
+		return
+	}
+
+	ctx := r.Context()
+	err = s.DB.WithTx(ctx, func(q database.Querier) error {
+		if isActiveAdmin(dbUser) {
+			if err := ensureOtherAdmin(ctx, q); err != nil {
+				return err
+			}
+		}
+		return anonymiseUser(ctx, q, dbUser.ID)
+	})
+	if errors.Is(err, errLastAdmin) {
+		s.adminError(w, r, err)
+		return
+	}
+	if err != nil {
+		s.Log.Printf("Failed to delete user %s: %v", dbUser.ID, err)
+		s.jsonError(w, r, "database_error", "Could not delete account", http.StatusInternalServerError)
+		return
+	}
+
+	s.setCookie(w, sessionCookieName, "", -time.Hour, true)
+	s.setCookie(w, csrfCookieName, "", -time.Hour, false)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// anonymiseUser hands the content of a user over to the deleted user
+// placeholder and deletes the account. Sessions, tokens and reminders are
+// removed by ON DELETE CASCADE.
+func anonymiseUser(ctx context.Context, q database.Querier, userID string) error {
+	if err := q.ReassignUserPosts(ctx, database.ReassignUserPostsParams{
+		NewUserid: deletedUserID,
+		Userid:    userID,
+	}); err != nil {
+		return err
+	}
+	if err := q.ReassignUserComments(ctx, database.ReassignUserCommentsParams{
+		NewUserid: deletedUserID,
+		Userid:    userID,
+	}); err != nil {
+		return err
+	}
+	if err := q.ReassignUserExams(ctx, database.ReassignUserExamsParams{
+		NewUserid: deletedUserID,
+		Userid:    userID,
+	}); err != nil {
+		return err
+	}
+	return q.DeleteUser(ctx, userID)
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -11,9 +12,13 @@ import (
 	"time"
 
 	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/buckets"
 	"github.com/fachschaftinformatik/web/internal/config"
 	"github.com/fachschaftinformatik/web/internal/database"
 	"github.com/fachschaftinformatik/web/internal/email"
+	"github.com/fachschaftinformatik/web/internal/i18n"
+	"github.com/fachschaftinformatik/web/internal/password"
+	"github.com/fachschaftinformatik/web/internal/scan"
 	"github.com/google/uuid"
 	"github.com/oapi-codegen/runtime/types"
 	"golang.org/x/crypto/bcrypt"
@@ -27,19 +32,36 @@ const (
 )
 
 type Server struct {
-	DB            database.Querier
+	DB            database.Store
 	Log           *log.Logger
 	Config        *config.Config
 	Email         *email.Sender
+	Outbox        *email.Outbox
+	Storage       buckets.Storage
+	Scanner       scan.Scanner
+	Passwords     *password.Hasher
 	SecureCookies bool
 }
 
-func NewServer(db database.Querier, logger *log.Logger, cfg *config.Config, emailSender *email.Sender) *Server {
+func NewServer(db database.Store, logger *log.Logger, cfg *config.Config, emailSender *email.Sender, outbox *email.Outbox, storage buckets.Storage, scanner scan.Scanner) *Server {
 	return &Server{
 		DB:            db,
 		Log:           logger,
 		Config:        cfg,
 		Email:         emailSender,
+		Outbox:        outbox,
+		Storage:       storage,
+		Scanner:       scanner,
+		Passwords: password.New(
+			password.Argon2id{
+				Memory:     cfg.Argon2Memory,
+				Time:       cfg.Argon2Time,
+				Threads:    cfg.Argon2Threads,
+				SaltLength: password.DefaultArgon2id.SaltLength,
+				KeyLength:  password.DefaultArgon2id.KeyLength,
+			},
+			password.Bcrypt{Cost: bcrypt.DefaultCost},
+		),
 		SecureCookies: cfg.SecureCookies,
 	}
 }
@@ -47,207 +69,245 @@ func NewServer(db database.Querier, logger *log.Logger, cfg *config.Config, emai
 func (s *Server) PostAuthRegister(w http.ResponseWriter, r *http.Request) {
 	var payload api.UserRegister
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-		s.jsonError(w, "invalid_request_body", "Could not decode JSON body", http.StatusBadRequest)
+		s.jsonError(w, r, "invalid_request_body", "Could not decode JSON body", http.StatusBadRequest)
 		return
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(payload.Password), bcrypt.DefaultCost)
+	if !s.domainAllowed(string(payload.Email)) {
+		s.jsonError(w, r, "email_domain_not_allowed", i18n.Sprintf(requestLocale(r), "Registration is only open for addresses at %s", strings.Join(s.Config.SignupsDomains, ", ")), http.StatusBadRequest)
+		return
+	}
+
+	locale, ok := registrationLocale(r, payload.Locale)
+	if !ok {
+		s.jsonError(w, r, "invalid_locale", "Unsupported locale", http.StatusBadRequest)
+		return
+	}
+
+	hashedPassword, err := s.Passwords.Hash(payload.Password)
 	if err != nil {
 		s.Log.Printf("Failed to hash password: %v", err)
-		s.jsonError(w, "server_error", "Could not process registration", http.StatusInternalServerError)
+		s.jsonError(w, r, "server_error", "Could not process registration", http.StatusInternalServerError)
 		return
 	}
 
-	verificationToken := uuid.NewString()
+	verificationToken, verificationHash := newToken()
 
 	params := database.CreateUserParams{
 		ID:                uuid.NewString(),
 		Email:             string(payload.Email),
 		Name:              payload.Name,
-		Password:          string(hashedPassword),
+		Password:          hashedPassword,
 		Role:              "user",
 		Active:            1,
 		Programid:         int64(payload.Programid),
-		VerificationToken: sql.NullString{String: verificationToken, Valid: true},
+		VerificationToken: sql.NullString{String: verificationHash, Valid: true},
+		Locale:            string(locale),
 	}
 
-	dbUser, err := s.DB.CreateUser(r.Context(), params)
+	var verifiedUntil database.NullTime
+	var msg email.Message
+	if s.Config.SignupsVerify {
+		msg, err = s.Email.VerificationEmail(locale, params.Email, params.Name, verificationToken)
+	} else {
+		verifiedUntil, err = s.verificationWindow(r.Context(), s.DB, params.Email, time.Now())
+	}
 	if err != nil {
-		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
-			s.jsonError(w, "email_exists", "A user with this email already exists", http.StatusConflict)
-		} else {
-			s.Log.Printf("Failed to create user: %v", err)
-			s.jsonError(w, "database_error", "Could not create user", http.StatusInternalServerError)
-		}
+		s.Log.Printf("Failed to prepare registration: %v", err)
+		s.jsonError(w, r, "server_error", "Could not process registration", http.StatusInternalServerError)
 		return
 	}
 
-	// Send verification email
-	go func() {
-		if err := s.Email.SendVerificationEmail(dbUser.Email, dbUser.Name, verificationToken); err != nil {
-			s.Log.Printf("Failed to send verification email to %s: %v", dbUser.Email, err)
+	// The user is created together with the verification email or the
+	// verification, so that a failure in between cannot leave an account
+	// that is neither verified nor able to receive a link.
+	var dbUser database.User
+	err = s.DB.WithTx(r.Context(), func(q database.Querier) error {
+		user, err := q.CreateUser(r.Context(), params)
+		if err != nil {
+			return err
 		}
-	}()
-
-	apiUser, err := dbUserToAPI(dbUser)
+		if s.Config.SignupsVerify {
+			if err := s.Outbox.Stage(r.Context(), q, msg); err != nil {
+				return err
+			}
+		} else if user, err = q.VerifyUser(r.Context(), database.VerifyUserParams{
+			ID:            user.ID,
+			VerifiedUntil: verifiedUntil,
+		}); err != nil {
+			return err
+		}
+		dbUser = user
+		return nil
+	})
 	if err != nil {
-		s.jsonError(w, "server_error", "Could not process user data", http.StatusInternalServerError)
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			s.jsonError(w, r, "email_exists", "A user with this email already exists", http.StatusConflict)
+		} else {
+			s.Log.Printf("Failed to create user: %v", err)
+			s.jsonError(w, r, "database_error", "Could not create user", http.StatusInternalServerError)
+		}
 		return
 	}
+	if s.Config.SignupsVerify {
+		s.Outbox.Notify()
+	}
 
-	s.respondJSON(w, http.StatusCreated, apiUser)
+	s.respondJSON(w, http.StatusCreated, dbUserToAPI(dbUser))
 }
 
 func (s *Server) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
 	var payload api.UserLogin
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-		s.jsonError(w, "invalid_request_body", "Could not decode JSON body", http.StatusBadRequest)
+		s.jsonError(w, r, "invalid_request_body", "Could not decode JSON body", http.StatusBadRequest)
 		return
 	}
 
 	dbUser, err := s.DB.GetUserByEmail(r.Context(), string(payload.Email))
 	if err != nil {
-		s.jsonError(w, "invalid_credentials", "Invalid email or password", http.StatusUnauthorized)
+		s.jsonError(w, r, "invalid_credentials", "Invalid email or password", http.StatusUnauthorized)
 		return
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(dbUser.Password), []byte(payload.Password))
+	ok, rehash, err := s.Passwords.Verify(payload.Password, dbUser.Password)
 	if err != nil {
-		s.jsonError(w, "invalid_credentials", "Invalid email or password", http.StatusUnauthorized)
+		s.Log.Printf("Failed to verify password of user %s: %v", dbUser.ID, err)
+	}
+	if !ok {
+		s.jsonError(w, r, "invalid_credentials", "Invalid email or password", http.StatusUnauthorized)
 		return
 	}
 
+	if err := s.checkAccount(dbUser); err != nil {
+		s.accountError(w, r, err)
+		return
+	}
+
+	if rehash {
+		if hashedPassword, err := s.Passwords.Hash(payload.Password); err != nil {
+			s.Log.Printf("Failed to rehash password of user %s: %v", dbUser.ID, err)
+		} else if err := s.DB.UpdateUserPassword(r.Context(), database.UpdateUserPasswordParams{
+			ID:       dbUser.ID,
+			Password: hashedPassword,
+		}); err != nil {
+			s.Log.Printf("Failed to store rehashed password of user %s: %v", dbUser.ID, err)
+		}
+	}
+
 	if dbUser.Verified == 0 {
 		// Logic to resend token if needed could go here
 		// For now, just regenerate a token and resend if one exists or if expired.
 		// Simplest approach: Always generate a new one and send it if they try to login.
-		newToken := uuid.NewString()
-		if err := s.DB.UpdateUserToken(r.Context(), database.UpdateUserTokenParams{
-			ID:                dbUser.ID,
-			VerificationToken: sql.NullString{String: newToken, Valid: true},
-		}); err != nil {
-			s.Log.Printf("Failed to update token for user %s: %v", dbUser.ID, err)
+		token, tokenHash := newToken()
+		err := s.DB.WithTx(r.Context(), func(q database.Querier) error {
+			if err := q.UpdateUserToken(r.Context(), database.UpdateUserTokenParams{
+				ID:                dbUser.ID,
+				VerificationToken: sql.NullString{String: tokenHash, Valid: true},
+			}); err != nil {
+				return err
+			}
+			return s.queueVerificationEmail(r.Context(), q, dbUser, token)
+		})
+		if err != nil {
+			s.Log.Printf("Failed to queue verification email to %s: %v", dbUser.Email, err)
 		} else {
-			go func() {
-				if err := s.Email.SendVerificationEmail(dbUser.Email, dbUser.Name, newToken); err != nil {
-					s.Log.Printf("Failed to resend verification email to %s: %v", dbUser.Email, err)
-				}
-			}()
+			s.Outbox.Notify()
 		}
 
-		s.jsonError(w, "email_not_verified", "Du musst erst deine E-Mail bestätigen. Wir haben dir eine neue E-Mail gesendet.", http.StatusForbidden)
+		s.jsonError(w, r, "email_not_verified", "You need to confirm your email address first. We have sent you a new email.", http.StatusForbidden)
 		return
 	}
 
-	sessionID := uuid.NewString()
+	sessionToken, sessionHash := newToken()
 	expiresAt := time.Now().Add(sessionDuration)
 
+	userAgent := r.UserAgent()
 	_, err = s.DB.CreateSession(r.Context(), database.CreateSessionParams{
-		ID:        sessionID,
+		ID:        sessionHash,
+		Publicid:  uuid.NewString(),
 		Userid:    dbUser.ID,
-		ExpiresAt: expiresAt.Format(time.RFC3339),
+		UserAgent: userAgent,
+		Ip:        clientIP(r),
+		Device:    deviceLabel(userAgent),
+		ExpiresAt: database.NewTime(expiresAt),
 	})
 	if err != nil {
 		s.Log.Printf("Failed to create session: %v", err)
-		s.jsonError(w, "server_error", "Could not create session", http.StatusInternalServerError)
+		s.jsonError(w, r, "server_error", "Could not create session", http.StatusInternalServerError)
 		return
 	}
 
-	s.setCookie(w, sessionCookieName, sessionID, sessionDuration, true)
-
-	apiUser, err := dbUserToAPI(dbUser)
-	if err != nil {
-		s.jsonError(w, "server_error", "Could not process user data", http.StatusInternalServerError)
-		return
-	}
+	s.setCookie(w, sessionCookieName, sessionToken, sessionDuration, true)
 
-	s.respondJSON(w, http.StatusOK, apiUser)
+	s.respondJSON(w, http.StatusOK, dbUserToAPI(dbUser))
 }
 
 func (s *Server) GetAuthVerify(w http.ResponseWriter, r *http.Request, params api.GetAuthVerifyParams) {
-	dbUser, err := s.DB.GetUserByVerificationToken(r.Context(), sql.NullString{String: params.Token, Valid: true})
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			s.jsonError(w, "invalid_token", "Invalid verification token", http.StatusBadRequest)
-		} else {
-			s.Log.Printf("Failed to lookup token: %v", err)
-			s.jsonError(w, "server_error", "Database error", http.StatusInternalServerError)
+	// The token is looked up and used up in one transaction, so that the
+	// verification sweeper cannot unverify the user in between and a token
+	// clicked twice extends the period only once.
+	err := s.DB.WithTx(r.Context(), func(q database.Querier) error {
+		dbUser, err := q.GetUserByVerificationToken(r.Context(), sql.NullString{String: hashToken(params.Token), Valid: true})
+		if err != nil {
+			return err
 		}
-		return
-	}
-
-	// Calculate Verified Until
-	var verifiedUntil sql.NullString
-	now := time.Now()
 
-	if strings.HasSuffix(dbUser.Email, "@studmail.w-hs.de") {
-		// Determine next March 1st or October 1st
-		year := now.Year()
-		march1 := time.Date(year, time.March, 1, 0, 0, 0, 0, time.UTC)
-		oct1 := time.Date(year, time.October, 1, 0, 0, 0, 0, time.UTC)
+		// Re-verifying ahead of time extends the current period instead of
+		// ending at the same boundary again.
+		from := time.Now()
+		if dbUser.Verified == 1 && dbUser.VerifiedUntil.Valid {
+			if until := dbUser.VerifiedUntil.Time; until.After(from) {
+				from = until
+			}
+		}
 
-		var nextDate time.Time
-		if now.Before(march1) {
-			nextDate = march1
-		} else if now.Before(oct1) {
-			nextDate = oct1
-		} else {
-			// After Oct 1st, next date is March 1st next year
-			nextDate = time.Date(year+1, time.March, 1, 0, 0, 0, 0, time.UTC)
+		verifiedUntil, err := s.verificationWindow(r.Context(), q, dbUser.Email, from)
+		if err != nil {
+			return fmt.Errorf("could not compute verification window: %w", err)
 		}
-		verifiedUntil = sql.NullString{String: nextDate.Format(time.RFC3339), Valid: true}
-	} else if strings.HasSuffix(dbUser.Email, "@fachschaftinformatik.de") {
-		// Forever verified (NULL)
-		verifiedUntil = sql.NullString{Valid: false}
-	} else {
-		// Default fallback for other domains (if any allowed in future)
-		// For now, treat as students or maybe block? Assuming studmail behavior or just verified until forever for now to be safe?
-		// Prompt said "users with @studmail...". Let's assume others are external and verify once.
-		verifiedUntil = sql.NullString{Valid: false}
-	}
 
-	_, err = s.DB.VerifyUser(r.Context(), database.VerifyUserParams{
-		ID:            dbUser.ID,
-		VerifiedUntil: verifiedUntil,
+		_, err = q.VerifyUser(r.Context(), database.VerifyUserParams{
+			ID:            dbUser.ID,
+			VerifiedUntil: verifiedUntil,
+		})
+		return err
 	})
+	if errors.Is(err, sql.ErrNoRows) {
+		s.jsonError(w, r, "invalid_token", "Invalid verification token", http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		s.Log.Printf("Failed to verify user: %v", err)
-		s.jsonError(w, "server_error", "Verification failed", http.StatusInternalServerError)
+		s.jsonError(w, r, "server_error", "Verification failed", http.StatusInternalServerError)
 		return
 	}
 
 	// Instead of JSON, we should probably redirect to the frontend dashboard or a success page
 	// But the prompt asked to hook it up. The link in email points to API.
 	// We can redirect to the frontend login page with a success parameter.
-	http.Redirect(w, r, fmt.Sprintf("%s/login?verified=true", s.Config.PublicURL), http.StatusFound)
+	http.Redirect(w, r, fmt.Sprintf("%s/login?verified=true", s.Config.Domain), http.StatusFound)
 }
 
 func (s *Server) GetAuthMe(w http.ResponseWriter, r *http.Request) {
-	_, dbUser, err := s.authenticate(w, r)
-	if err != nil {
-		s.jsonError(w, "unauthorized", err.Error(), http.StatusUnauthorized)
-		return
-	}
-
-	apiUser, err := dbUserToAPI(dbUser)
+	_, dbUser, err := s.authenticate(w, r, scopeProfileRead)
 	if err != nil {
-		s.jsonError(w, "server_error", "Could not process user data", http.StatusInternalServerError)
+		s.authError(w, r, err)
 		return
 	}
 
-	s.respondJSON(w, http.StatusOK, apiUser)
+	s.respondJSON(w, http.StatusOK, dbUserToAPI(dbUser))
 }
 
 func (s *Server) PostAuthLogout(w http.ResponseWriter, r *http.Request, params api.PostAuthLogoutParams) {
-	session, _, err := s.authenticate(w, r)
+	session, _, err := s.authenticate(w, r, "")
 	if err != nil {
 		s.setCookie(w, sessionCookieName, "", -time.Hour, true)
-		s.jsonError(w, "unauthorized", err.Error(), http.StatusUnauthorized)
+		s.authError(w, r, err)
 		return
 	}
 
 	if err := s.checkCSRF(r); err != nil {
-		s.jsonError(w, "invalid_csrf", err.Error(), http.StatusForbidden)
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
 		return
 	}
 
@@ -268,86 +328,122 @@ func (s *Server) GetAuthCsrf(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) GetUsers(w http.ResponseWriter, r *http.Request, params api.GetUsersParams) {
-	_, dbUser, err := s.authenticate(w, r)
+	_, dbUser, err := s.authenticate(w, r, scopeUsersRead)
 	if err != nil {
-		s.jsonError(w, "unauthorized", err.Error(), http.StatusUnauthorized)
+		s.authError(w, r, err)
 		return
 	}
 
 	if dbUser.Role != "admin" {
-		s.jsonError(w, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		return
+	}
+
+	filter := userFilter{
+		query:     params.Q,
+		programid: params.Programid,
+		after:     params.VerifiedUntilAfter,
+		before:    params.VerifiedUntilBefore,
+	}
+	if params.Role != nil {
+		filter.role = (*string)(params.Role)
+	}
+	if params.Verified != nil {
+		filter.verified = (*int)(params.Verified)
+	}
+	if params.Active != nil {
+		filter.active = (*int)(params.Active)
+	}
+	if err := filter.validate(); err != nil {
+		s.jsonError(w, r, "invalid_filter", err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	limit := int64(32)
-	offset := int64(0)
 	if params.Limit != nil {
 		limit = int64(*params.Limit)
 	}
-	if params.Offset != nil {
-		offset = int64(*params.Offset)
+	if limit < 1 || limit > 256 {
+		s.jsonError(w, r, "invalid_limit", "Limit must be between 1 and 256", http.StatusBadRequest)
+		return
 	}
 
-	dbUsers, err := s.DB.ListUsers(r.Context(), database.ListUsersParams{
-		Limit:  limit,
-		Offset: offset,
-	})
+	search := filter.searchParams()
+	if params.Cursor != nil {
+		createdAt, id, err := decodeUserCursor(*params.Cursor)
+		if err != nil {
+			s.jsonError(w, r, "invalid_cursor", "Invalid cursor", http.StatusBadRequest)
+			return
+		}
+		search.AfterCreatedAt = sql.NullString{String: createdAt, Valid: true}
+		search.AfterID = sql.NullString{String: id, Valid: true}
+	}
+	// Fetch one extra row to find out whether there is another page.
+	search.Limit = limit + 1
+
+	dbUsers, err := s.DB.SearchUsers(r.Context(), search)
 	if err != nil {
 		s.Log.Printf("Failed to list users: %v", err)
-		s.jsonError(w, "database_error", "Could not list users", http.StatusInternalServerError)
+		s.jsonError(w, r, "database_error", "Could not list users", http.StatusInternalServerError)
 		return
 	}
 
-	apiUsers := make([]api.User, 0, len(dbUsers))
+	total, err := s.DB.CountUsers(r.Context(), filter.countParams())
+	if err != nil {
+		s.Log.Printf("Failed to count users: %v", err)
+		s.jsonError(w, r, "database_error", "Could not list users", http.StatusInternalServerError)
+		return
+	}
+
+	page := api.UserPage{
+		Items: make([]api.User, 0, len(dbUsers)),
+		Total: int(total),
+	}
+	if int64(len(dbUsers)) > limit {
+		dbUsers = dbUsers[:limit]
+		last := dbUsers[len(dbUsers)-1]
+		cursor := encodeUserCursor(last.CreatedAt.String(), last.ID)
+		page.NextCursor = &cursor
+	}
+
 	for _, user := range dbUsers {
-		apiUser, err := dbUserToAPI(user)
-		if err != nil {
-			s.jsonError(w, "server_error", "Could not process user data", http.StatusInternalServerError)
-			return
-		}
-		apiUsers = append(apiUsers, apiUser)
+		page.Items = append(page.Items, dbUserToAPI(user))
 	}
 
-	s.respondJSON(w, http.StatusOK, apiUsers)
+	s.respondJSON(w, http.StatusOK, page)
 }
 
 func (s *Server) GetUsersId(w http.ResponseWriter, r *http.Request, id string) {
-	_, authUser, err := s.authenticate(w, r)
+	_, authUser, err := s.authenticate(w, r, scopeUsersRead)
 	if err != nil {
-		s.jsonError(w, "unauthorized", err.Error(), http.StatusUnauthorized)
+		s.authError(w, r, err)
 		return
 	}
 
 	if authUser.ID != id && authUser.Role != "admin" {
-		s.jsonError(w, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
 		return
 	}
 
 	dbUser, err := s.DB.GetUser(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			s.jsonError(w, "not_found", "User not found", http.StatusNotFound)
+			s.jsonError(w, r, "not_found", "User not found", http.StatusNotFound)
 		} else {
 			s.Log.Printf("Failed to get user: %v", err)
-			s.jsonError(w, "database_error", "Database error", http.StatusInternalServerError)
+			s.jsonError(w, r, "database_error", "Database error", http.StatusInternalServerError)
 		}
 		return
 	}
 
-	apiUser, err := dbUserToAPI(dbUser)
-	if err != nil {
-		s.jsonError(w, "server_error", "Could not process user data", http.StatusInternalServerError)
-		return
-	}
-
-	s.respondJSON(w, http.StatusOK, apiUser)
+	s.respondJSON(w, http.StatusOK, dbUserToAPI(dbUser))
 }
 
 func (s *Server) GetPrograms(w http.ResponseWriter, r *http.Request) {
 	rows, err := s.DB.ListProgramsWithVersions(r.Context())
 	if err != nil {
 		s.Log.Printf("Failed to list programs: %v", err)
-		s.jsonError(w, "database_error", "Could not fetch programs", http.StatusInternalServerError)
+		s.jsonError(w, r, "database_error", "Could not fetch programs", http.StatusInternalServerError)
 		return
 	}
 
@@ -380,12 +476,12 @@ func (s *Server) GetProgramsId(w http.ResponseWriter, r *http.Request, id int) {
 	rows, err := s.DB.GetProgramWithVersions(r.Context(), int64(id))
 	if err != nil {
 		s.Log.Printf("Failed to get program: %v", err)
-		s.jsonError(w, "database_error", "Could not fetch program", http.StatusInternalServerError)
+		s.jsonError(w, r, "database_error", "Could not fetch program", http.StatusInternalServerError)
 		return
 	}
 
 	if len(rows) == 0 {
-		s.jsonError(w, "not_found", "Program not found", http.StatusNotFound)
+		s.jsonError(w, r, "not_found", "Program not found", http.StatusNotFound)
 		return
 	}
 
@@ -402,13 +498,32 @@ func (s *Server) GetProgramsId(w http.ResponseWriter, r *http.Request, id int) {
 	s.respondJSON(w, http.StatusOK, prog)
 }
 
-func (s *Server) jsonError(w http.ResponseWriter, err, msg string, status int) {
+// jsonError responds with an error whose message is translated into the
+// language preferred by the client.
+func (s *Server) jsonError(w http.ResponseWriter, r *http.Request, err, msg string, status int) {
 	s.respondJSON(w, status, api.Error{
 		Error:   err,
-		Message: msg,
+		Message: i18n.T(requestLocale(r), msg),
 	})
 }
 
+// registrationLocale returns the locale chosen in the registration form,
+// falling back to the language of the request, so that the response and the
+// verification email are in the same language.
+func registrationLocale(r *http.Request, chosen *api.Locale) (i18n.Locale, bool) {
+	if chosen != nil {
+		return i18n.Parse(string(*chosen))
+	}
+	return requestLocale(r), true
+}
+
+// requestLocale returns the locale negotiated from the Accept-Language
+// header. Clients that send none or ask for neither German nor English get
+// the default locale.
+func requestLocale(r *http.Request) i18n.Locale {
+	return i18n.Negotiate(r.Header.Get("Accept-Language"), i18n.Default)
+}
+
 func (s *Server) respondJSON(w http.ResponseWriter, status int, payload interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -432,16 +547,27 @@ func (s *Server) setCookie(w http.ResponseWriter, name string, value string, max
 	http.SetCookie(w, cookie)
 }
 
-func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) (database.Session, database.User, error) {
+// authenticate resolves the user behind a request. Session cookies are always
+// accepted. Personal access tokens are only accepted if scope is not empty and
+// the token was granted that scope; they yield an empty session.
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request, scope string) (database.Session, database.User, error) {
+	if token, ok := bearerToken(r); ok {
+		if scope == "" {
+			return database.Session{}, database.User{}, errors.New("personal access tokens are not accepted here")
+		}
+		user, err := s.authenticateToken(r.Context(), token, scope)
+		return database.Session{}, user, err
+	}
+
 	cookie, err := r.Cookie(sessionCookieName)
 	if err != nil {
 		return database.Session{}, database.User{}, errors.New("session cookie not found")
 	}
 
-	sessionID := cookie.Value
+	sessionToken := cookie.Value
 	ctx := r.Context()
 
-	session, err := s.DB.GetSession(ctx, sessionID)
+	session, err := s.DB.GetSession(ctx, hashToken(sessionToken))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return database.Session{}, database.User{}, errors.New("invalid session")
@@ -450,8 +576,7 @@ func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) (database.
 		return database.Session{}, database.User{}, errors.New("database error")
 	}
 
-	expiresAt, err := time.Parse(time.RFC3339, session.ExpiresAt)
-	if err != nil || expiresAt.Before(time.Now()) {
+	if session.ExpiresAt.Before(time.Now()) {
 		return database.Session{}, database.User{}, errors.New("session expired")
 	}
 
@@ -461,20 +586,41 @@ func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) (database.
 		return database.Session{}, database.User{}, errors.New("user not found for session")
 	}
 
+	if err := s.checkAccount(user); err != nil {
+		return database.Session{}, database.User{}, err
+	}
+
 	newExpiresAt := time.Now().Add(sessionDuration)
 	if _, err = s.DB.SlideSession(ctx, database.SlideSessionParams{
-		ID:        sessionID,
-		ExpiresAt: newExpiresAt.Format(time.RFC3339),
+		ID:        session.ID,
+		ExpiresAt: database.NewTime(newExpiresAt),
 	}); err != nil {
 		s.Log.Printf("Auth: Failed to slide session: %v", err)
 	}
 
-	s.setCookie(w, sessionCookieName, sessionID, sessionDuration, true)
+	s.setCookie(w, sessionCookieName, sessionToken, sessionDuration, true)
 
 	return session, user, nil
 }
 
+func (s *Server) authError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, errInsufficientScope) {
+		s.jsonError(w, r, "insufficient_scope", err.Error(), http.StatusForbidden)
+		return
+	}
+	if errors.Is(err, errAccountDisabled) || errors.Is(err, errDomainNotAllowed) {
+		s.accountError(w, r, err)
+		return
+	}
+	s.jsonError(w, r, "unauthorized", err.Error(), http.StatusUnauthorized)
+}
+
 func (s *Server) checkCSRF(r *http.Request) error {
+	// Bearer tokens are never sent by the browser on its own.
+	if _, ok := bearerToken(r); ok {
+		return nil
+	}
+
 	headerToken := r.Header.Get("X-CSRF-Token")
 	if headerToken == "" {
 		return errors.New("X-CSRF-Token header missing")
@@ -492,7 +638,7 @@ func (s *Server) checkCSRF(r *http.Request) error {
 	return nil
 }
 
-func dbUserToAPI(user database.User) (api.User, error) {
+func dbUserToAPI(user database.User) api.User {
 	apiUser := api.User{
 		Active:       api.UserActive(user.Active),
 		Programid:    int(user.Programid),
@@ -501,41 +647,31 @@ func dbUserToAPI(user database.User) (api.User, error) {
 		Name:         user.Name,
 		Role:         api.UserRole(user.Role),
 		Verified:     api.UserVerified(user.Verified),
+		Locale:       api.Locale(user.Locale),
+		Reminders:    api.UserReminders(user.Reminders),
+		CreatedAt:    user.CreatedAt.Time,
+		UpdatedAt:    user.UpdatedAt.Time,
 	}
 
-	var err error
-	apiUser.CreatedAt, err = time.Parse(time.RFC3339, user.CreatedAt)
-	if err != nil {
-		return api.User{}, fmt.Errorf("could not parse CreatedAt: %w", err)
-	}
-
-	apiUser.UpdatedAt, err = time.Parse(time.RFC3339, user.UpdatedAt)
-	if err != nil {
-		return api.User{}, fmt.Errorf("could not parse UpdatedAt: %w", err)
-	}
+	apiUser.VerifiedAt = convertNullTime(user.VerifiedAt)
+	apiUser.VerifiedUntil = convertNullTime(user.VerifiedUntil)
 
-	if t, err := convertNullTime(user.VerifiedAt); err == nil && t != nil {
-		apiUser.VerifiedAt = t
-	} else if err != nil {
-		return api.User{}, err
-	}
+	return apiUser
+}
 
-	if t, err := convertNullTime(user.VerifiedUntil); err == nil && t != nil {
-		apiUser.VerifiedUntil = t
-	} else if err != nil {
-		return api.User{}, err
+// queueVerificationEmail stages the email confirming the address of user in
+// the outbox. The caller notifies the outbox once q is committed.
+func (s *Server) queueVerificationEmail(ctx context.Context, q database.Querier, user database.User, token string) error {
+	msg, err := s.Email.VerificationEmail(i18n.Locale(user.Locale), user.Email, user.Name, token)
+	if err != nil {
+		return err
 	}
-
-	return apiUser, nil
+	return s.Outbox.Stage(ctx, q, msg)
 }
 
-func convertNullTime(ns sql.NullString) (*time.Time, error) {
-	if !ns.Valid {
-		return nil, nil
-	}
-	t, err := time.Parse(time.RFC3339, ns.String)
-	if err != nil {
-		return nil, fmt.Errorf("could not parse timestamp: %w", err)
+func convertNullTime(t database.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
 	}
-	return &t, nil
+	return &t.Time
 }
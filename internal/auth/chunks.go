@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/buckets"
+	"github.com/fachschaftinformatik/web/internal/database"
+	"github.com/fachschaftinformatik/web/internal/i18n"
+)
+
+const (
+	// uploadChunkSize must not be smaller than buckets.MinComposePart, so
+	// that the chunks can be joined by the bucket.
+	uploadChunkSize      = 8 << 20
+	chunkTransferTimeout = 5 * time.Minute
+)
+
+func chunkCount(nbytes int64) int {
+	return int((nbytes + uploadChunkSize - 1) / uploadChunkSize)
+}
+
+// chunkLength returns the size of chunk index, which is uploadChunkSize for
+// all chunks but the last.
+func chunkLength(nbytes int64, index int) int64 {
+	return min(uploadChunkSize, nbytes-int64(index)*uploadChunkSize)
+}
+
+func chunkPrefix(uploadID string) string {
+	return "uploads/" + uploadID + "/"
+}
+
+func chunkKey(uploadID string, index int) string {
+	return fmt.Sprintf("%s%06d", chunkPrefix(uploadID), index)
+}
+
+// stagingKey is where the presigned URL of an upload puts the file. The URL
+// outlives finalizing, so it must not point at the published object.
+func stagingKey(uploadID string) string {
+	return chunkPrefix(uploadID) + "direct"
+}
+
+func (s *Server) GetExamsUploadsId(w http.ResponseWriter, r *http.Request, id string) {
+	_, dbUser, err := s.authenticate(w, r, scopeExamsWrite)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	upload, ok := s.pendingUpload(w, r, id, dbUser.ID)
+	if !ok {
+		return
+	}
+
+	chunks, err := s.DB.ListExamUploadChunks(r.Context(), upload.ID)
+	if err != nil {
+		s.Log.Printf("Failed to list chunks of upload %s: %v", upload.ID, err)
+		s.jsonError(w, r, "database_error", "Database error", http.StatusInternalServerError)
+		return
+	}
+	received := make([]int, len(chunks))
+	for i, chunk := range chunks {
+		received[i] = int(chunk.Idx)
+	}
+
+	s.respondJSON(w, http.StatusOK, api.ExamUploadStatus{
+		Id:         upload.ID,
+		Nbytes:     int(upload.Nbytes),
+		ChunkSize:  uploadChunkSize,
+		Chunks:     chunkCount(upload.Nbytes),
+		Received:   received,
+		FinalizeBy: upload.ExpiresAt.Time,
+	})
+}
+
+func (s *Server) DeleteExamsUploadsId(w http.ResponseWriter, r *http.Request, id string, params api.DeleteExamsUploadsIdParams) {
+	_, dbUser, err := s.authenticate(w, r, scopeExamsWrite)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	upload, ok := s.pendingUpload(w, r, id, dbUser.ID)
+	if !ok {
+		return
+	}
+
+	if err := discardExamUpload(r.Context(), s.DB, s.Storage, upload); err != nil {
+		s.Log.Printf("Failed to discard upload %s: %v", upload.ID, err)
+		s.jsonError(w, r, "storage_error", "Could not abort upload", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) PutExamsUploadsIdChunksIndex(w http.ResponseWriter, r *http.Request, id string, index int, params api.PutExamsUploadsIdChunksIndexParams) {
+	_, dbUser, err := s.authenticate(w, r, scopeExamsWrite)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	ctx := r.Context()
+	upload, ok := s.pendingUpload(w, r, id, dbUser.ID)
+	if !ok {
+		return
+	}
+
+	if index < 0 || index >= chunkCount(upload.Nbytes) {
+		s.jsonError(w, r, "invalid_chunk", "Chunk index out of range", http.StatusBadRequest)
+		return
+	}
+	size := chunkLength(upload.Nbytes, index)
+	if r.ContentLength >= 0 && r.ContentLength != size {
+		s.jsonError(w, r, "invalid_chunk_size", i18n.Sprintf(requestLocale(r), "The chunk must have %d bytes", size), http.StatusBadRequest)
+		return
+	}
+	checksum := strings.ToLower(params.XChunkChecksum)
+	if sum, err := hex.DecodeString(checksum); err != nil || len(sum) != sha256.Size {
+		s.jsonError(w, r, "invalid_checksum", "The checksum must be a hex encoded SHA-256", http.StatusBadRequest)
+		return
+	}
+
+	// Chunks take longer to arrive than the server timeouts meant for API
+	// requests allow.
+	rc := http.NewResponseController(w)
+	rc.SetReadDeadline(time.Now().Add(chunkTransferTimeout))
+	rc.SetWriteDeadline(time.Now().Add(chunkTransferTimeout))
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, size+1))
+	if err != nil {
+		s.jsonError(w, r, "invalid_request_body", "Could not read the chunk", http.StatusBadRequest)
+		return
+	}
+	if int64(len(data)) != size {
+		s.jsonError(w, r, "invalid_chunk_size", i18n.Sprintf(requestLocale(r), "The chunk must have %d bytes", size), http.StatusBadRequest)
+		return
+	}
+	if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != checksum {
+		s.jsonError(w, r, "chunk_mismatch", "The chunk does not match its checksum", http.StatusUnprocessableEntity)
+		return
+	}
+
+	if err := s.Storage.Put(ctx, chunkKey(upload.ID, index), bytes.NewReader(data), size, "application/octet-stream"); err != nil {
+		s.Log.Printf("Failed to store chunk %d of upload %s: %v", index, upload.ID, err)
+		s.jsonError(w, r, "storage_error", "Could not store chunk", http.StatusInternalServerError)
+		return
+	}
+	err = s.DB.WithTx(ctx, func(q database.Querier) error {
+		if err := q.UpsertExamUploadChunk(ctx, database.UpsertExamUploadChunkParams{
+			Uploadid: upload.ID,
+			Idx:      int64(index),
+			Nbytes:   size,
+			Checksum: checksum,
+		}); err != nil {
+			return err
+		}
+		// Uploads that are still making progress are not swept.
+		return q.ExtendExamUpload(ctx, database.ExtendExamUploadParams{
+			ExpiresAt: database.NewTime(time.Now().Add(uploadFinalizeWindow)),
+			ID:        upload.ID,
+		})
+	})
+	if err != nil {
+		s.Log.Printf("Failed to record chunk %d of upload %s: %v", index, upload.ID, err)
+		s.jsonError(w, r, "database_error", "Could not store chunk", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// assembleUpload copies what the client sent into the object of the upload,
+// either the chunks of a chunked upload or the file sent with the presigned
+// URL. Only that copy is verified and published, so later writes to the URL
+// cannot replace it. The sources are deleted once copied, if there are none
+// an earlier attempt has already copied them.
+func (s *Server) assembleUpload(ctx context.Context, upload database.ExamUpload) error {
+	chunks, err := s.DB.ListExamUploadChunks(ctx, upload.ID)
+	if err != nil {
+		return err
+	}
+	if len(chunks) == 0 {
+		key := stagingKey(upload.ID)
+		if _, err := s.Storage.Stat(ctx, key); errors.Is(err, buckets.ErrNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := s.Storage.Compose(ctx, upload.Accesskey, []string{key}, upload.MimeType); err != nil {
+			return err
+		}
+		if err := s.Storage.Delete(ctx, key); err != nil {
+			s.Log.Printf("Failed to delete object %s: %v", key, err)
+		}
+		return nil
+	}
+
+	n := chunkCount(upload.Nbytes)
+	if len(chunks) != n {
+		return errUploadMissing
+	}
+	keys := make([]string, n)
+	for i, chunk := range chunks {
+		if chunk.Idx != int64(i) {
+			return errUploadMissing
+		}
+		keys[i] = chunkKey(upload.ID, i)
+	}
+
+	if err := s.Storage.Compose(ctx, upload.Accesskey, keys, upload.MimeType); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := s.Storage.Delete(ctx, key); err != nil {
+			s.Log.Printf("Failed to delete chunk %s: %v", key, err)
+		}
+	}
+	return s.DB.DeleteExamUploadChunks(ctx, upload.ID)
+}
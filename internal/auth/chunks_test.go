@@ -0,0 +1,380 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/buckets"
+	"github.com/fachschaftinformatik/web/internal/config"
+	"github.com/fachschaftinformatik/web/internal/database"
+	"github.com/fachschaftinformatik/web/internal/scan"
+)
+
+// testServer serves the API backed by a fresh database and in-memory
+// storage, as a signed in admin.
+type testServer struct {
+	*Server
+	t       *testing.T
+	handler http.Handler
+	user    database.User
+	session string
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	ctx := context.Background()
+	logger := log.New(io.Discard, "", 0)
+
+	dsn := "file:" + filepath.Join(t.TempDir(), "test.db")
+	if err := database.Migrate(dsn, logger); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	db, err := database.NewConnection(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	cfg := &config.Config{
+		Domain:         "https://fsv.example",
+		Storage:        "memory",
+		ExamMaxBytes:   50 << 20,
+		UploadsPerUser: 3,
+		QuotaUser:      100 << 20,
+		QuotaEditor:    100 << 20,
+		QuotaAdmin:     100 << 20,
+		QuotaTotal:     1 << 30,
+		Argon2Memory:   8,
+		Argon2Time:     1,
+		Argon2Threads:  1,
+	}
+	storage, err := buckets.New(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := database.NewStore(db)
+	s := NewServer(store, logger, cfg, nil, nil, storage, scan.Noop{})
+
+	user, err := store.CreateUser(ctx, database.CreateUserParams{
+		ID:        "11111111-1111-1111-1111-111111111111",
+		Email:     "admin@fsv.example",
+		Name:      "Admin",
+		Password:  "unused",
+		Role:      "admin",
+		Active:    1,
+		Programid: 1,
+		Locale:    "en",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	session := "session-token"
+	if _, err := store.CreateSession(ctx, database.CreateSessionParams{
+		ID:        hashToken(session),
+		Publicid:  "session",
+		Userid:    user.ID,
+		ExpiresAt: database.NewTime(time.Now().Add(time.Hour)),
+	}); err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+
+	return &testServer{Server: s, t: t, handler: api.Handler(s), user: user, session: session}
+}
+
+// do sends a request signed in as the admin and returns the response.
+func (ts *testServer) do(method, path string, body []byte, header http.Header) *httptest.ResponseRecorder {
+	ts.t.Helper()
+	r := httptest.NewRequest(method, path, bytes.NewReader(body))
+	for name, values := range header {
+		r.Header[name] = values
+	}
+	r.AddCookie(&http.Cookie{Name: sessionCookieName, Value: ts.session})
+	r.AddCookie(&http.Cookie{Name: csrfCookieName, Value: "csrf"})
+	r.Header.Set("X-CSRF-Token", "csrf")
+	w := httptest.NewRecorder()
+	ts.handler.ServeHTTP(w, r)
+	return w
+}
+
+func (ts *testServer) doJSON(method, path string, payload any, want int, result any) {
+	ts.t.Helper()
+	body, err := json.Marshal(payload)
+	if err != nil {
+		ts.t.Fatal(err)
+	}
+	w := ts.do(method, path, body, http.Header{"Content-Type": {"application/json"}})
+	if w.Code != want {
+		ts.t.Fatalf("%s %s = %d %s, want %d", method, path, w.Code, w.Body, want)
+	}
+	if result != nil {
+		if err := json.Unmarshal(w.Body.Bytes(), result); err != nil {
+			ts.t.Fatalf("%s %s: %v", method, path, err)
+		}
+	}
+}
+
+func (ts *testServer) putChunk(id string, index int, data []byte, checksum string) int {
+	ts.t.Helper()
+	w := ts.do(http.MethodPut, fmt.Sprintf("/exams/uploads/%s/chunks/%d", id, index), data, http.Header{
+		"Content-Type":     {"application/octet-stream"},
+		"X-Chunk-Checksum": {checksum},
+	})
+	return w.Code
+}
+
+// testPDF returns a PDF-looking file of n bytes.
+func testPDF(t *testing.T, n int) []byte {
+	t.Helper()
+	data := make([]byte, n)
+	rand.Read(data)
+	copy(data, "%PDF-1.4\n")
+	return data
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (ts *testServer) startUpload(data []byte) api.ExamUpload {
+	ts.t.Helper()
+	var upload api.ExamUpload
+	ts.doJSON(http.MethodPost, "/exams/uploads", api.ExamUploadRequest{
+		Programid: 1,
+		Version:   "PO2023",
+		ExamDate:  "2025-07-01",
+		MimeType:  api.Applicationpdf,
+		Nbytes:    len(data),
+		Checksum:  sha256Hex(data),
+	}, http.StatusCreated, &upload)
+	return upload
+}
+
+func chunkOf(data []byte, index int) []byte {
+	start := index * uploadChunkSize
+	return data[start:min(start+uploadChunkSize, len(data))]
+}
+
+func TestChunkedUpload(t *testing.T) {
+	ts := newTestServer(t)
+	ctx := context.Background()
+	data := testPDF(t, 2*uploadChunkSize+1000)
+
+	upload := ts.startUpload(data)
+	if upload.Chunks != 3 || upload.ChunkSize != uploadChunkSize {
+		t.Fatalf("got %d chunks of %d bytes, want 3 of %d", upload.Chunks, upload.ChunkSize, uploadChunkSize)
+	}
+
+	// Chunks may arrive in any order.
+	for _, index := range []int{2, 0} {
+		chunk := chunkOf(data, index)
+		if code := ts.putChunk(upload.Id, index, chunk, sha256Hex(chunk)); code != http.StatusNoContent {
+			t.Fatalf("PUT chunk %d = %d, want 204", index, code)
+		}
+	}
+
+	w := ts.do(http.MethodPost, "/exams/uploads/"+upload.Id+"/finalize", nil, nil)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("finalize with a missing chunk = %d %s, want 409", w.Code, w.Body)
+	}
+
+	var status api.ExamUploadStatus
+	ts.doJSON(http.MethodGet, "/exams/uploads/"+upload.Id, nil, http.StatusOK, &status)
+	if !slices.Equal(status.Received, []int{0, 2}) {
+		t.Errorf("received chunks %v, want [0 2]", status.Received)
+	}
+
+	chunk := chunkOf(data, 1)
+	if code := ts.putChunk(upload.Id, 1, chunk[1:], sha256Hex(chunk)); code != http.StatusBadRequest {
+		t.Errorf("PUT short chunk = %d, want 400", code)
+	}
+	if code := ts.putChunk(upload.Id, 3, chunk, sha256Hex(chunk)); code != http.StatusBadRequest {
+		t.Errorf("PUT chunk out of range = %d, want 400", code)
+	}
+	if code := ts.putChunk(upload.Id, 1, chunk, sha256Hex(chunkOf(data, 0))); code != http.StatusUnprocessableEntity {
+		t.Errorf("PUT chunk with wrong checksum = %d, want 422", code)
+	}
+	if code := ts.putChunk(upload.Id, 1, chunk, sha256Hex(chunk)); code != http.StatusNoContent {
+		t.Fatalf("PUT chunk 1 = %d, want 204", code)
+	}
+
+	var exam api.Exam
+	ts.doJSON(http.MethodPost, "/exams/uploads/"+upload.Id+"/finalize", nil, http.StatusCreated, &exam)
+	if exam.Checksum != sha256Hex(data) || exam.Nbytes != len(data) {
+		t.Errorf("exam has %d bytes with checksum %s, want %d with %s", exam.Nbytes, exam.Checksum, len(data), sha256Hex(data))
+	}
+
+	body, _, err := ts.Storage.Get(ctx, fmt.Sprintf("exams/%s.pdf", upload.Id))
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	composed, err := io.ReadAll(body)
+	body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(composed, data) {
+		t.Error("composed object differs from the uploaded file")
+	}
+
+	leftover, err := ts.Storage.List(ctx, chunkPrefix(upload.Id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leftover) != 0 {
+		t.Errorf("%d chunks left after finalizing", len(leftover))
+	}
+	if chunks, err := ts.DB.ListExamUploadChunks(ctx, upload.Id); err != nil || len(chunks) != 0 {
+		t.Errorf("ListExamUploadChunks = %d rows, %v, want none", len(chunks), err)
+	}
+}
+
+// Re-sending a chunk, e.g. after a lost response, replaces the stored chunk.
+func TestChunkResend(t *testing.T) {
+	ts := newTestServer(t)
+	data := testPDF(t, uploadChunkSize+10)
+	upload := ts.startUpload(data)
+
+	last := chunkOf(data, 1)
+	corrupted := bytes.Clone(last)
+	corrupted[0] ^= 0xff
+	for _, chunk := range [][]byte{chunkOf(data, 0), corrupted, last, last} {
+		index := 0
+		if len(chunk) != uploadChunkSize {
+			index = 1
+		}
+		if code := ts.putChunk(upload.Id, index, chunk, sha256Hex(chunk)); code != http.StatusNoContent {
+			t.Fatalf("PUT chunk %d = %d, want 204", index, code)
+		}
+	}
+
+	chunks, err := ts.DB.ListExamUploadChunks(context.Background(), upload.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 2 || chunks[1].Checksum != sha256Hex(last) {
+		t.Fatalf("chunks %+v, want two with the last one re-sent", chunks)
+	}
+
+	var exam api.Exam
+	ts.doJSON(http.MethodPost, "/exams/uploads/"+upload.Id+"/finalize", nil, http.StatusCreated, &exam)
+	if exam.Checksum != sha256Hex(data) {
+		t.Errorf("exam checksum %s, want %s", exam.Checksum, sha256Hex(data))
+	}
+}
+
+// The presigned URL outlives finalizing, so what is sent to it must not
+// replace the published object.
+func TestDirectUpload(t *testing.T) {
+	ts := newTestServer(t)
+	ctx := context.Background()
+	data := testPDF(t, 1000)
+	upload := ts.startUpload(data)
+
+	u, err := url.Parse(upload.Url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	staging := stagingKey(upload.Id)
+	if !strings.HasSuffix(u.Path, "/"+staging) {
+		t.Fatalf("upload URL %s, want one for %s", upload.Url, staging)
+	}
+	send := func(data []byte) {
+		t.Helper()
+		if err := ts.Storage.Put(ctx, staging, bytes.NewReader(data), int64(len(data)), "application/pdf"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	readExam := func() []byte {
+		t.Helper()
+		body, _, err := ts.Storage.Get(ctx, fmt.Sprintf("exams/%s.pdf", upload.Id))
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		defer body.Close()
+		stored, err := io.ReadAll(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return stored
+	}
+
+	corrupted := bytes.Clone(data)
+	corrupted[len(corrupted)-1] ^= 0xff
+	send(corrupted)
+	if w := ts.do(http.MethodPost, "/exams/uploads/"+upload.Id+"/finalize", nil, nil); w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("finalize with a corrupted file = %d %s, want 422", w.Code, w.Body)
+	}
+
+	// The URL is still valid, so the file can be sent again.
+	send(data)
+	var exam api.Exam
+	ts.doJSON(http.MethodPost, "/exams/uploads/"+upload.Id+"/finalize", nil, http.StatusCreated, &exam)
+	if exam.Checksum != sha256Hex(data) {
+		t.Errorf("exam checksum %s, want %s", exam.Checksum, sha256Hex(data))
+	}
+	if _, err := ts.Storage.Stat(ctx, staging); !errors.Is(err, buckets.ErrNotFound) {
+		t.Errorf("Stat of the staging object after finalizing = %v, want %v", err, buckets.ErrNotFound)
+	}
+
+	send(corrupted)
+	if !bytes.Equal(readExam(), data) {
+		t.Error("a write to the upload URL replaced the published exam")
+	}
+}
+
+// Concurrent requests must not exceed the limit of unfinished uploads
+// together.
+func TestUploadLimit(t *testing.T) {
+	ts := newTestServer(t)
+	data := testPDF(t, 100)
+	body, _ := json.Marshal(api.ExamUploadRequest{
+		Programid: 1,
+		Version:   "PO2023",
+		ExamDate:  "2025-07-01",
+		MimeType:  api.Applicationpdf,
+		Nbytes:    len(data),
+		Checksum:  sha256Hex(data),
+	})
+
+	codes := make([]int, ts.Config.UploadsPerUser+5)
+	var wg sync.WaitGroup
+	for i := range codes {
+		wg.Go(func() {
+			codes[i] = ts.do(http.MethodPost, "/exams/uploads", body, http.Header{"Content-Type": {"application/json"}}).Code
+		})
+	}
+	wg.Wait()
+
+	created := 0
+	for _, code := range codes {
+		switch code {
+		case http.StatusCreated:
+			created++
+		case http.StatusTooManyRequests:
+		default:
+			t.Errorf("POST /exams/uploads = %d", code)
+		}
+	}
+	if created != ts.Config.UploadsPerUser {
+		t.Errorf("%d uploads started, want %d", created, ts.Config.UploadsPerUser)
+	}
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/email"
+)
+
+// GetDevMails is deliberately unauthenticated, so it only exists with
+// DEV_MODE=true. The captured mails contain verification links, which must
+// never be readable by anyone on a public server.
+func (s *Server) GetDevMails(w http.ResponseWriter, r *http.Request) {
+	if !s.Config.DevMode {
+		s.jsonError(w, r, "not_found", "Only available in development mode", http.StatusNotFound)
+		return
+	}
+
+	captured, err := s.Email.Captured()
+	if errors.Is(err, email.ErrNotRecorded) {
+		s.jsonError(w, r, "not_found", "The mail transport does not capture emails", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		s.Log.Printf("Failed to list captured mails: %v", err)
+		s.jsonError(w, r, "server_error", "Could not list captured mails", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]api.CapturedMail, 0, len(captured))
+	for _, mail := range captured {
+		to := mail.To
+		if to == nil {
+			to = []string{}
+		}
+		response = append(response, api.CapturedMail{
+			Id:         mail.ID,
+			From:       mail.From,
+			To:         to,
+			Subject:    mail.Subject,
+			ReceivedAt: mail.ReceivedAt,
+			Raw:        mail.Raw,
+		})
+	}
+
+	s.respondJSON(w, http.StatusOK, response)
+}
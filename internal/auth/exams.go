@@ -0,0 +1,446 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/buckets"
+	"github.com/fachschaftinformatik/web/internal/database"
+	"github.com/fachschaftinformatik/web/internal/i18n"
+	"github.com/fachschaftinformatik/web/internal/scan"
+	"github.com/google/uuid"
+)
+
+const (
+	// uploadURLExpiry limits how long an upload URL can be used, the upload
+	// itself may then be finalized until uploadFinalizeWindow has passed.
+	// The URL stays valid after finalizing, which is why it points at the
+	// staging key of the upload.
+	uploadURLExpiry      = 15 * time.Minute
+	uploadFinalizeWindow = time.Hour
+	downloadURLExpiry    = 5 * time.Minute
+	uploadSweepBatchSize = 100
+	// uploadVerifyTimeout bounds how long finalizing may take to read the
+	// uploaded object back.
+	uploadVerifyTimeout = 5 * time.Minute
+)
+
+var (
+	errUploadMissing  = errors.New("the file has not been uploaded")
+	errUploadMismatch = errors.New("the file does not match the upload")
+	errTooManyUploads = errors.New("too many unfinished uploads")
+	errQuotaExceeded  = errors.New("storage quota exceeded")
+	errScanFailed     = errors.New("the file could not be scanned")
+)
+
+// examKey is where the object of an exam is stored. No upload URL points
+// below exams/, only the server writes there.
+func examKey(id string) string {
+	return fmt.Sprintf("exams/%s.pdf", id)
+}
+
+func (s *Server) PostExamsUploads(w http.ResponseWriter, r *http.Request, params api.PostExamsUploadsParams) {
+	_, dbUser, err := s.authenticate(w, r, scopeExamsWrite)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	var payload api.ExamUploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		s.jsonError(w, r, "invalid_request_body", "Could not decode JSON body", http.StatusBadRequest)
+		return
+	}
+
+	if payload.MimeType != api.Applicationpdf {
+		s.jsonError(w, r, "invalid_mime_type", "Only PDF files are accepted", http.StatusBadRequest)
+		return
+	}
+	if payload.Nbytes < 1 {
+		s.jsonError(w, r, "invalid_size", "The file must not be empty", http.StatusBadRequest)
+		return
+	}
+	if int64(payload.Nbytes) > s.Config.ExamMaxBytes {
+		s.jsonError(w, r, "file_too_large", i18n.Sprintf(requestLocale(r), "Files must not be larger than %d MiB", s.Config.ExamMaxBytes>>20), http.StatusRequestEntityTooLarge)
+		return
+	}
+	checksum := strings.ToLower(payload.Checksum)
+	if sum, err := hex.DecodeString(checksum); err != nil || len(sum) != sha256.Size {
+		s.jsonError(w, r, "invalid_checksum", "The checksum must be a hex encoded SHA-256", http.StatusBadRequest)
+		return
+	}
+	if _, err := time.Parse(time.DateOnly, payload.ExamDate); err != nil {
+		s.jsonError(w, r, "invalid_exam_date", "The exam date must have the form YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	versions, err := s.DB.GetProgramWithVersions(r.Context(), int64(payload.Programid))
+	if err != nil {
+		s.Log.Printf("Failed to fetch program: %v", err)
+		s.jsonError(w, r, "database_error", "Could not start upload", http.StatusInternalServerError)
+		return
+	}
+	if !slices.ContainsFunc(versions, func(v database.GetProgramWithVersionsRow) bool { return v.Version == payload.Version }) {
+		s.jsonError(w, r, "invalid_program", "Unknown program or version", http.StatusBadRequest)
+		return
+	}
+
+	// The uploads and the storage they reserve are counted in the
+	// transaction that adds one, so that concurrent requests cannot exceed
+	// the limits together.
+	now := time.Now()
+	id := uuid.NewString()
+	var upload database.ExamUpload
+	var quota quotaResult
+	err = s.DB.WithTx(r.Context(), func(q database.Querier) error {
+		pending, err := q.CountUserExamUploads(r.Context(), database.CountUserExamUploadsParams{
+			Userid: dbUser.ID,
+			Now:    database.NewTime(now),
+		})
+		if err != nil {
+			return err
+		}
+		if pending >= int64(s.Config.UploadsPerUser) {
+			return errTooManyUploads
+		}
+		if quota, err = s.withinQuota(r.Context(), q, dbUser, int64(payload.Nbytes)); err != nil {
+			return err
+		}
+		if quota != quotaOK {
+			return errQuotaExceeded
+		}
+
+		upload, err = q.CreateExamUpload(r.Context(), database.CreateExamUploadParams{
+			ID:        id,
+			Userid:    dbUser.ID,
+			Programid: int64(payload.Programid),
+			Version:   payload.Version,
+			ExamDate:  payload.ExamDate,
+			Accesskey: examKey(id),
+			MimeType:  string(payload.MimeType),
+			Nbytes:    int64(payload.Nbytes),
+			Checksum:  checksum,
+			ExpiresAt: database.NewTime(now.Add(uploadFinalizeWindow)),
+		})
+		return err
+	})
+	if errors.Is(err, errTooManyUploads) {
+		s.jsonError(w, r, "too_many_uploads", i18n.Sprintf(requestLocale(r), "You cannot have more than %d unfinished uploads", s.Config.UploadsPerUser), http.StatusTooManyRequests)
+		return
+	}
+	if errors.Is(err, errQuotaExceeded) {
+		s.quotaError(w, r, dbUser, quota)
+		return
+	}
+	if err != nil {
+		s.Log.Printf("Failed to create exam upload: %v", err)
+		s.jsonError(w, r, "database_error", "Could not start upload", http.StatusInternalServerError)
+		return
+	}
+
+	uploadURL, err := s.Storage.PresignPut(r.Context(), stagingKey(upload.ID), upload.Nbytes, upload.MimeType, uploadURLExpiry)
+	if err != nil {
+		s.Log.Printf("Failed to presign upload %s: %v", upload.ID, err)
+		if err := s.DB.DeleteExamUpload(r.Context(), upload.ID); err != nil {
+			s.Log.Printf("Failed to delete exam upload %s: %v", upload.ID, err)
+		}
+		s.jsonError(w, r, "storage_error", "Could not start upload", http.StatusInternalServerError)
+		return
+	}
+
+	s.respondJSON(w, http.StatusCreated, api.ExamUpload{
+		Id:         upload.ID,
+		Method:     api.PUT,
+		Url:        uploadURL.String(),
+		Headers:    map[string]string{"Content-Type": upload.MimeType},
+		ExpiresAt:  now.Add(uploadURLExpiry).UTC(),
+		FinalizeBy: now.Add(uploadFinalizeWindow).UTC(),
+		ChunkSize:  uploadChunkSize,
+		Chunks:     chunkCount(upload.Nbytes),
+	})
+}
+
+func (s *Server) PostExamsUploadsIdFinalize(w http.ResponseWriter, r *http.Request, id string, params api.PostExamsUploadsIdFinalizeParams) {
+	_, dbUser, err := s.authenticate(w, r, scopeExamsWrite)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	ctx := r.Context()
+	upload, ok := s.pendingUpload(w, r, id, dbUser.ID)
+	if !ok {
+		return
+	}
+
+	// Reading large objects back takes longer than the server timeouts meant
+	// for API requests allow.
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Now().Add(uploadVerifyTimeout))
+
+	if err := s.assembleUpload(ctx, upload); err != nil {
+		if errors.Is(err, errUploadMissing) {
+			s.jsonError(w, r, "upload_incomplete", "Not all chunks have been uploaded yet", http.StatusConflict)
+			return
+		}
+		s.Log.Printf("Failed to assemble upload %s: %v", upload.ID, err)
+		s.jsonError(w, r, "storage_error", "Could not verify upload", http.StatusInternalServerError)
+		return
+	}
+
+	result, err := s.verifyUpload(ctx, upload)
+	if err != nil {
+		switch {
+		case errors.Is(err, errUploadMissing):
+			s.jsonError(w, r, "upload_incomplete", "The file has not been uploaded yet", http.StatusConflict)
+		case errors.Is(err, errUploadMismatch):
+			// The upload URL may still be valid, so the file can be sent
+			// again and is copied anew.
+			if err := s.Storage.Delete(ctx, upload.Accesskey); err != nil {
+				s.Log.Printf("Failed to delete object %s: %v", upload.Accesskey, err)
+			}
+			s.jsonError(w, r, "upload_mismatch", "The file does not match the announced size, checksum or type", http.StatusUnprocessableEntity)
+		case errors.Is(err, errScanFailed):
+			s.Log.Printf("Failed to scan upload %s: %v", upload.ID, err)
+			s.jsonError(w, r, "scan_failed", "The file could not be checked for malware, please try again later", http.StatusServiceUnavailable)
+		default:
+			s.Log.Printf("Failed to verify upload %s: %v", upload.ID, err)
+			s.jsonError(w, r, "storage_error", "Could not verify upload", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if result.Infected {
+		if err := s.quarantineUpload(ctx, upload, result); err != nil {
+			s.Log.Printf("Failed to quarantine upload %s: %v", upload.ID, err)
+			s.jsonError(w, r, "scan_failed", "The file could not be checked for malware, please try again later", http.StatusServiceUnavailable)
+			return
+		}
+		s.jsonError(w, r, "upload_quarantined", "The file was flagged by the malware scanner and will be reviewed by an admin", http.StatusUnprocessableEntity)
+		return
+	}
+
+	var exam database.Exam
+	err = s.DB.WithTx(ctx, func(q database.Querier) error {
+		var err error
+		if exam, err = q.CreateExam(ctx, database.CreateExamParams{
+			ID:        upload.ID,
+			Userid:    upload.Userid,
+			Programid: upload.Programid,
+			Version:   upload.Version,
+			ExamDate:  upload.ExamDate,
+			Accesskey: upload.Accesskey,
+			MimeType:  upload.MimeType,
+			Nbytes:    upload.Nbytes,
+			Checksum:  upload.Checksum,
+		}); err != nil {
+			return err
+		}
+		return q.DeleteExamUpload(ctx, upload.ID)
+	})
+	if err != nil {
+		s.Log.Printf("Failed to create exam: %v", err)
+		s.jsonError(w, r, "database_error", "Could not publish exam", http.StatusInternalServerError)
+		return
+	}
+
+	s.respondJSON(w, http.StatusCreated, dbExamToAPI(exam))
+}
+
+// pendingUpload returns the upload of the user that has not expired yet. If
+// there is none, it writes the error response and returns false.
+func (s *Server) pendingUpload(w http.ResponseWriter, r *http.Request, id, userid string) (database.ExamUpload, bool) {
+	upload, err := s.DB.GetExamUpload(r.Context(), database.GetExamUploadParams{ID: id, Userid: userid})
+	if errors.Is(err, sql.ErrNoRows) {
+		s.jsonError(w, r, "not_found", "Upload not found", http.StatusNotFound)
+		return database.ExamUpload{}, false
+	}
+	if err != nil {
+		s.Log.Printf("Failed to get exam upload: %v", err)
+		s.jsonError(w, r, "database_error", "Database error", http.StatusInternalServerError)
+		return database.ExamUpload{}, false
+	}
+	if upload.ExpiresAt.Before(time.Now()) {
+		s.jsonError(w, r, "not_found", "Upload not found", http.StatusNotFound)
+		return database.ExamUpload{}, false
+	}
+	return upload, true
+}
+
+// verifyUpload reads the uploaded object to check its size, checksum and
+// type against what was announced when the upload was started. The object
+// is scanned for malware in the same pass.
+func (s *Server) verifyUpload(ctx context.Context, upload database.ExamUpload) (scan.Result, error) {
+	body, info, err := s.Storage.Get(ctx, upload.Accesskey)
+	if errors.Is(err, buckets.ErrNotFound) {
+		return scan.Result{}, errUploadMissing
+	}
+	if err != nil {
+		return scan.Result{}, err
+	}
+	defer body.Close()
+
+	if info.Size != upload.Nbytes || info.ContentType != upload.MimeType {
+		return scan.Result{}, errUploadMismatch
+	}
+
+	br := bufio.NewReaderSize(body, 512)
+	head, _ := br.Peek(512)
+	if http.DetectContentType(head) != upload.MimeType {
+		return scan.Result{}, errUploadMismatch
+	}
+
+	// The scanner may stop reading early, e.g. because it found something
+	// or does not need the rest, so the remainder is hashed afterwards.
+	h := sha256.New()
+	result, scanErr := s.Scanner.Scan(ctx, io.TeeReader(br, h))
+	if _, err := io.Copy(h, br); err != nil {
+		return scan.Result{}, err
+	}
+	if hex.EncodeToString(h.Sum(nil)) != upload.Checksum {
+		return scan.Result{}, errUploadMismatch
+	}
+	if scanErr != nil {
+		return scan.Result{}, fmt.Errorf("%w: %w", errScanFailed, scanErr)
+	}
+	return result, nil
+}
+
+func (s *Server) GetExamsIdDownload(w http.ResponseWriter, r *http.Request, id string) {
+	_, _, err := s.authenticate(w, r, scopeExamsRead)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	exam, err := s.DB.GetExam(r.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.jsonError(w, r, "not_found", "Exam not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		s.Log.Printf("Failed to get exam: %v", err)
+		s.jsonError(w, r, "database_error", "Database error", http.StatusInternalServerError)
+		return
+	}
+	// The reconciliation flags exams whose object it could not find.
+	if exam.MissingSince.Valid {
+		s.jsonError(w, r, "file_missing", "The file of this exam is missing", http.StatusGone)
+		return
+	}
+
+	expiresAt := time.Now().Add(downloadURLExpiry)
+	downloadURL, err := s.Storage.Presign(r.Context(), http.MethodGet, exam.Accesskey, downloadURLExpiry)
+	if err != nil {
+		s.Log.Printf("Failed to presign download of exam %s: %v", exam.ID, err)
+		s.jsonError(w, r, "storage_error", "Could not create download link", http.StatusInternalServerError)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, api.PresignedURL{
+		Url:       downloadURL.String(),
+		ExpiresAt: expiresAt.UTC(),
+	})
+}
+
+func dbExamToAPI(exam database.Exam) api.Exam {
+	return api.Exam{
+		Id:         exam.ID,
+		Userid:     exam.Userid,
+		Programid:  int(exam.Programid),
+		Version:    exam.Version,
+		ExamDate:   exam.ExamDate,
+		UploadedAt: exam.UploadedAt.Time,
+		MimeType:   exam.MimeType,
+		Nbytes:     int(exam.Nbytes),
+		Checksum:   exam.Checksum,
+	}
+}
+
+// StartUploadSweeper deletes uploads that were never finalized, together
+// with their chunks and objects.
+func StartUploadSweeper(ctx context.Context, querier database.Store, storage buckets.Storage, logger *log.Logger) {
+	logger.Println("Upload sweeper started.")
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		sweepExamUploads(ctx, querier, storage, logger)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			logger.Println("Upload sweeper stopped.")
+			return
+		}
+	}
+}
+
+func sweepExamUploads(ctx context.Context, querier database.Store, storage buckets.Storage, logger *log.Logger) {
+	now := database.NewTime(time.Now())
+	for ctx.Err() == nil {
+		uploads, err := querier.ListExpiredExamUploads(ctx, database.ListExpiredExamUploadsParams{
+			Before: now,
+			Limit:  uploadSweepBatchSize,
+		})
+		if err != nil {
+			logger.Printf("Error listing expired uploads: %v", err)
+			return
+		}
+		for _, upload := range uploads {
+			if err := discardExamUpload(ctx, querier, storage, upload); err != nil {
+				logger.Printf("Error discarding upload %s: %v", upload.ID, err)
+				return
+			}
+		}
+		if len(uploads) < uploadSweepBatchSize {
+			return
+		}
+	}
+}
+
+// discardExamUpload deletes an upload with its chunks and objects. The rows
+// are kept if an object cannot be deleted, so that the next sweep tries
+// again.
+func discardExamUpload(ctx context.Context, querier database.Store, storage buckets.Storage, upload database.ExamUpload) error {
+	chunks, err := storage.List(ctx, chunkPrefix(upload.ID))
+	if err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		if err := storage.Delete(ctx, chunk.Key); err != nil {
+			return err
+		}
+	}
+	if err := storage.Delete(ctx, upload.Accesskey); err != nil {
+		return err
+	}
+	return querier.WithTx(ctx, func(q database.Querier) error {
+		if err := q.DeleteExamUploadChunks(ctx, upload.ID); err != nil {
+			return err
+		}
+		return q.DeleteExamUpload(ctx, upload.ID)
+	})
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/database"
+)
+
+func (s *Server) GetEmailOutbox(w http.ResponseWriter, r *http.Request, params api.GetEmailOutboxParams) {
+	_, authUser, err := s.authenticate(w, r, "")
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if authUser.Role != "admin" {
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		return
+	}
+
+	query := database.ListEmailOutboxParams{Limit: 50}
+	if params.Status != nil {
+		switch *params.Status {
+		case api.Pending, api.Sending, api.Sent, api.Dead:
+		default:
+			s.jsonError(w, r, "invalid_filter", "Status must be one of pending, sending, sent or dead", http.StatusBadRequest)
+			return
+		}
+		query.Status = sql.NullString{String: string(*params.Status), Valid: true}
+	}
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > 256 {
+			s.jsonError(w, r, "invalid_limit", "Limit must be between 1 and 256", http.StatusBadRequest)
+			return
+		}
+		query.Limit = int64(*params.Limit)
+	}
+
+	rows, err := s.DB.ListEmailOutbox(r.Context(), query)
+	if err != nil {
+		s.Log.Printf("Failed to list email outbox: %v", err)
+		s.jsonError(w, r, "database_error", "Could not list emails", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]api.OutboxMessage, 0, len(rows))
+	for _, row := range rows {
+		response = append(response, dbOutboxToAPI(row))
+	}
+
+	s.respondJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) PostEmailOutboxIdRequeue(w http.ResponseWriter, r *http.Request, id string, params api.PostEmailOutboxIdRequeueParams) {
+	_, authUser, err := s.authenticate(w, r, "")
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if authUser.Role != "admin" {
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	row, err := s.Outbox.Requeue(r.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Tell apart unknown messages from those that cannot be requeued.
+		if _, err := s.DB.GetEmail(r.Context(), id); err == nil {
+			s.jsonError(w, r, "email_not_requeueable", "Only pending or dead emails can be requeued", http.StatusConflict)
+			return
+		}
+		s.jsonError(w, r, "not_found", "Email not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		s.Log.Printf("Failed to requeue email %s: %v", id, err)
+		s.jsonError(w, r, "database_error", "Could not requeue email", http.StatusInternalServerError)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, dbOutboxToAPI(row))
+}
+
+func dbOutboxToAPI(row database.EmailOutbox) api.OutboxMessage {
+	msg := api.OutboxMessage{
+		Id:            row.ID,
+		Recipient:     row.Recipient,
+		Subject:       row.Subject,
+		Status:        api.OutboxStatus(row.Status),
+		Attempts:      int(row.Attempts),
+		CreatedAt:     row.CreatedAt.Time,
+		NextAttemptAt: row.NextAttemptAt.Time,
+		SentAt:        convertNullTime(row.SentAt),
+	}
+	if row.LastError.Valid {
+		msg.LastError = &row.LastError.String
+	}
+	return msg
+}
@@ -0,0 +1,193 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/database"
+	"github.com/fachschaftinformatik/web/internal/policy"
+)
+
+func (s *Server) GetVerificationPolicies(w http.ResponseWriter, r *http.Request) {
+	_, authUser, err := s.authenticate(w, r, scopeUsersRead)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if authUser.Role != "admin" {
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		return
+	}
+
+	rows, err := s.DB.ListVerificationPolicies(r.Context())
+	if err != nil {
+		s.Log.Printf("Failed to list verification policies: %v", err)
+		s.jsonError(w, r, "database_error", "Could not list verification policies", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]api.VerificationPolicy, 0, len(rows))
+	for _, row := range rows {
+		response = append(response, dbPolicyToAPI(row))
+	}
+
+	s.respondJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) PutVerificationPoliciesDomain(w http.ResponseWriter, r *http.Request, domain string, params api.PutVerificationPoliciesDomainParams) {
+	_, authUser, err := s.authenticate(w, r, scopeUsersWrite)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if authUser.Role != "admin" {
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	var payload api.VerificationPolicyUpdate
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		s.jsonError(w, r, "invalid_request_body", "Could not decode JSON body", http.StatusBadRequest)
+		return
+	}
+
+	var boundaries []string
+	if payload.Boundaries != nil {
+		boundaries = *payload.Boundaries
+	}
+	var days int
+	if payload.DurationDays != nil {
+		days = *payload.DurationDays
+	}
+
+	p, err := policy.New(domain, policy.Kind(payload.Kind), boundaries, days)
+	if err != nil {
+		s.jsonError(w, r, "invalid_policy", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	row, err := s.DB.UpsertVerificationPolicy(r.Context(), policyToDB(p))
+	if err != nil {
+		s.Log.Printf("Failed to store verification policy: %v", err)
+		s.jsonError(w, r, "database_error", "Could not store verification policy", http.StatusInternalServerError)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, dbPolicyToAPI(row))
+}
+
+func (s *Server) DeleteVerificationPoliciesDomain(w http.ResponseWriter, r *http.Request, domain string, params api.DeleteVerificationPoliciesDomainParams) {
+	_, authUser, err := s.authenticate(w, r, scopeUsersWrite)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if authUser.Role != "admin" {
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	n, err := s.DB.DeleteVerificationPolicy(r.Context(), strings.ToLower(domain))
+	if err != nil {
+		s.Log.Printf("Failed to delete verification policy: %v", err)
+		s.jsonError(w, r, "database_error", "Could not delete verification policy", http.StatusInternalServerError)
+		return
+	}
+	if n == 0 {
+		s.jsonError(w, r, "not_found", "Verification policy not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// verificationWindow returns the end of the verification period of a user
+// with the given email verified at now, or NULL if it does not expire.
+func (s *Server) verificationWindow(ctx context.Context, q database.Querier, email string, now time.Time) (database.NullTime, error) {
+	rows, err := q.ListVerificationPolicies(ctx)
+	if err != nil {
+		return database.NullTime{}, fmt.Errorf("could not load verification policies: %w", err)
+	}
+
+	policies := make([]policy.Policy, 0, len(rows))
+	for _, row := range rows {
+		p, err := dbPolicyToPolicy(row)
+		if err != nil {
+			// A broken row must not block verifications of other domains.
+			s.Log.Printf("Skipping invalid verification policy for %q: %v", row.Domain, err)
+			continue
+		}
+		policies = append(policies, p)
+	}
+
+	p, ok := policy.Match(policies, email)
+	if !ok {
+		return database.NullTime{}, nil
+	}
+	until, ok := p.Until(now)
+	if !ok {
+		return database.NullTime{}, nil
+	}
+	return database.NewNullTime(until), nil
+}
+
+func dbPolicyToPolicy(row database.VerificationPolicy) (policy.Policy, error) {
+	var boundaries []string
+	if row.Boundaries != "" {
+		boundaries = strings.Split(row.Boundaries, ",")
+	}
+	return policy.New(row.Domain, policy.Kind(row.Kind), boundaries, int(row.DurationDays.Int64))
+}
+
+func policyToDB(p policy.Policy) database.UpsertVerificationPolicyParams {
+	params := database.UpsertVerificationPolicyParams{
+		Domain: p.Domain,
+		Kind:   string(p.Kind),
+	}
+	boundaries := make([]string, 0, len(p.Boundaries))
+	for _, b := range p.Boundaries {
+		boundaries = append(boundaries, b.String())
+	}
+	params.Boundaries = strings.Join(boundaries, ",")
+	if p.Kind == policy.Rolling {
+		params.DurationDays = sql.NullInt64{Int64: int64(p.Days()), Valid: true}
+	}
+	return params
+}
+
+func dbPolicyToAPI(row database.VerificationPolicy) api.VerificationPolicy {
+	apiPolicy := api.VerificationPolicy{
+		Domain:     row.Domain,
+		Kind:       api.VerificationPolicyKind(row.Kind),
+		Boundaries: []string{},
+		UpdatedAt:  row.UpdatedAt.Time,
+	}
+	if row.Boundaries != "" {
+		apiPolicy.Boundaries = strings.Split(row.Boundaries, ",")
+	}
+	if row.DurationDays.Valid {
+		days := int(row.DurationDays.Int64)
+		apiPolicy.DurationDays = &days
+	}
+
+	return apiPolicy
+}
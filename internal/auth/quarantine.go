@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/database"
+	"github.com/fachschaftinformatik/web/internal/scan"
+)
+
+func quarantineKey(id string) string {
+	return fmt.Sprintf("quarantine/%s.pdf", id)
+}
+
+// quarantineUpload moves the object of an upload flagged by the scanner
+// below quarantine/ and records it for review, and removes the upload.
+func (s *Server) quarantineUpload(ctx context.Context, upload database.ExamUpload, result scan.Result) error {
+	s.Log.Printf("Upload %s was flagged as %s, moving it to quarantine.", upload.ID, result.Signature)
+	key := quarantineKey(upload.ID)
+	if err := s.Storage.Compose(ctx, key, []string{upload.Accesskey}, upload.MimeType); err != nil {
+		return err
+	}
+	err := s.DB.WithTx(ctx, func(q database.Querier) error {
+		if _, err := q.QuarantineExamUpload(ctx, database.QuarantineExamUploadParams{
+			ID:        upload.ID,
+			Userid:    upload.Userid,
+			Programid: upload.Programid,
+			Version:   upload.Version,
+			ExamDate:  upload.ExamDate,
+			Accesskey: key,
+			MimeType:  upload.MimeType,
+			Nbytes:    upload.Nbytes,
+			Checksum:  upload.Checksum,
+			Signature: result.Signature,
+		}); err != nil {
+			return err
+		}
+		return q.DeleteExamUpload(ctx, upload.ID)
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.Storage.Delete(ctx, upload.Accesskey); err != nil {
+		s.Log.Printf("Failed to delete object %s: %v", upload.Accesskey, err)
+	}
+	return nil
+}
+
+func (s *Server) GetQuarantine(w http.ResponseWriter, r *http.Request) {
+	_, authUser, err := s.authenticate(w, r, scopeExamsRead)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if authUser.Role != "admin" {
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		return
+	}
+
+	rows, err := s.DB.ListQuarantinedExams(r.Context())
+	if err != nil {
+		s.Log.Printf("Failed to list quarantined exams: %v", err)
+		s.jsonError(w, r, "database_error", "Could not list quarantined uploads", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]api.QuarantinedExam, 0, len(rows))
+	for _, row := range rows {
+		response = append(response, dbQuarantinedExamToAPI(row))
+	}
+
+	s.respondJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) GetQuarantineIdDownload(w http.ResponseWriter, r *http.Request, id string) {
+	_, authUser, err := s.authenticate(w, r, scopeExamsRead)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if authUser.Role != "admin" {
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		return
+	}
+
+	exam, ok := s.quarantinedExam(w, r, id)
+	if !ok {
+		return
+	}
+
+	expiresAt := time.Now().Add(downloadURLExpiry)
+	downloadURL, err := s.Storage.Presign(r.Context(), http.MethodGet, exam.Accesskey, downloadURLExpiry)
+	if err != nil {
+		s.Log.Printf("Failed to presign download of quarantined exam %s: %v", exam.ID, err)
+		s.jsonError(w, r, "storage_error", "Could not create download link", http.StatusInternalServerError)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, api.PresignedURL{
+		Url:       downloadURL.String(),
+		ExpiresAt: expiresAt.UTC(),
+	})
+}
+
+func (s *Server) PostQuarantineIdRelease(w http.ResponseWriter, r *http.Request, id string, params api.PostQuarantineIdReleaseParams) {
+	_, authUser, err := s.authenticate(w, r, scopeExamsWrite)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if authUser.Role != "admin" {
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	ctx := r.Context()
+	quarantined, ok := s.quarantinedExam(w, r, id)
+	if !ok {
+		return
+	}
+
+	key := examKey(quarantined.ID)
+	if err := s.Storage.Compose(ctx, key, []string{quarantined.Accesskey}, quarantined.MimeType); err != nil {
+		s.Log.Printf("Failed to release object %s: %v", quarantined.Accesskey, err)
+		s.jsonError(w, r, "storage_error", "Could not release upload", http.StatusInternalServerError)
+		return
+	}
+	var exam database.Exam
+	err = s.DB.WithTx(ctx, func(q database.Querier) error {
+		var err error
+		if exam, err = q.CreateExam(ctx, database.CreateExamParams{
+			ID:        quarantined.ID,
+			Userid:    quarantined.Userid,
+			Programid: quarantined.Programid,
+			Version:   quarantined.Version,
+			ExamDate:  quarantined.ExamDate,
+			Accesskey: key,
+			MimeType:  quarantined.MimeType,
+			Nbytes:    quarantined.Nbytes,
+			Checksum:  quarantined.Checksum,
+		}); err != nil {
+			return err
+		}
+		return q.DeleteQuarantinedExam(ctx, quarantined.ID)
+	})
+	if err != nil {
+		s.Log.Printf("Failed to create exam: %v", err)
+		s.jsonError(w, r, "database_error", "Could not publish exam", http.StatusInternalServerError)
+		return
+	}
+	s.Log.Printf("Quarantined exam %s was released by %s.", quarantined.ID, authUser.ID)
+	if err := s.Storage.Delete(ctx, quarantined.Accesskey); err != nil {
+		s.Log.Printf("Failed to delete object %s: %v", quarantined.Accesskey, err)
+	}
+
+	s.respondJSON(w, http.StatusCreated, dbExamToAPI(exam))
+}
+
+func (s *Server) DeleteQuarantineId(w http.ResponseWriter, r *http.Request, id string, params api.DeleteQuarantineIdParams) {
+	_, authUser, err := s.authenticate(w, r, scopeExamsWrite)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if authUser.Role != "admin" {
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	ctx := r.Context()
+	quarantined, ok := s.quarantinedExam(w, r, id)
+	if !ok {
+		return
+	}
+
+	// The row is kept if the object cannot be deleted, so that it is not
+	// left behind unnoticed.
+	if err := s.Storage.Delete(ctx, quarantined.Accesskey); err != nil {
+		s.Log.Printf("Failed to delete object %s: %v", quarantined.Accesskey, err)
+		s.jsonError(w, r, "storage_error", "Could not delete upload", http.StatusInternalServerError)
+		return
+	}
+	if err := s.DB.DeleteQuarantinedExam(ctx, quarantined.ID); err != nil {
+		s.Log.Printf("Failed to delete quarantined exam %s: %v", quarantined.ID, err)
+		s.jsonError(w, r, "database_error", "Could not delete upload", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// quarantinedExam returns the quarantined upload with id. If there is none,
+// it writes the error response and returns false.
+func (s *Server) quarantinedExam(w http.ResponseWriter, r *http.Request, id string) (database.ExamQuarantine, bool) {
+	exam, err := s.DB.GetQuarantinedExam(r.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.jsonError(w, r, "not_found", "Quarantined upload not found", http.StatusNotFound)
+		return database.ExamQuarantine{}, false
+	}
+	if err != nil {
+		s.Log.Printf("Failed to get quarantined exam: %v", err)
+		s.jsonError(w, r, "database_error", "Database error", http.StatusInternalServerError)
+		return database.ExamQuarantine{}, false
+	}
+	return exam, true
+}
+
+func dbQuarantinedExamToAPI(exam database.ExamQuarantine) api.QuarantinedExam {
+	return api.QuarantinedExam{
+		Id:            exam.ID,
+		Userid:        exam.Userid,
+		Programid:     int(exam.Programid),
+		Version:       exam.Version,
+		ExamDate:      exam.ExamDate,
+		MimeType:      exam.MimeType,
+		Nbytes:        int(exam.Nbytes),
+		Checksum:      exam.Checksum,
+		Signature:     exam.Signature,
+		QuarantinedAt: exam.QuarantinedAt.Time,
+	}
+}
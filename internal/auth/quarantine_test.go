@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/fachschaftinformatik/web/internal/buckets"
+	"github.com/fachschaftinformatik/web/internal/scan"
+)
+
+// stubScanner reads the first n bytes of a file and returns result and err.
+type stubScanner struct {
+	n      int64
+	result scan.Result
+	err    error
+}
+
+func (s stubScanner) Scan(ctx context.Context, r io.Reader) (scan.Result, error) {
+	if _, err := io.CopyN(io.Discard, r, s.n); err != nil && !errors.Is(err, io.EOF) {
+		return scan.Result{}, err
+	}
+	return s.result, s.err
+}
+
+// uploadFile sends data as the only chunk of a new upload.
+func (ts *testServer) uploadFile(data []byte) string {
+	ts.t.Helper()
+	upload := ts.startUpload(data)
+	chunk := chunkOf(data, 0)
+	if code := ts.putChunk(upload.Id, 0, chunk, sha256Hex(chunk)); code != http.StatusNoContent {
+		ts.t.Fatalf("PUT chunk = %d, want 204", code)
+	}
+	return upload.Id
+}
+
+func TestFinalizeScans(t *testing.T) {
+	tests := []struct {
+		name    string
+		scanner scan.Scanner
+		corrupt bool
+		want    int
+	}{
+		{"clean", stubScanner{n: 1 << 20}, false, http.StatusCreated},
+		{"scanner stops early", stubScanner{n: 10}, false, http.StatusCreated},
+		{"infected", stubScanner{n: 10, result: scan.Result{Infected: true, Signature: "Eicar"}}, false, http.StatusUnprocessableEntity},
+		{"scanner unavailable", stubScanner{err: errors.New("connection refused")}, false, http.StatusServiceUnavailable},
+		// A mismatch is reported even if the scanner fails.
+		{"checksum mismatch", stubScanner{err: errors.New("connection refused")}, true, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t)
+			ts.Scanner = tt.scanner
+			ctx := context.Background()
+			data := testPDF(t, 1000)
+			id := ts.uploadFile(data)
+			if tt.corrupt {
+				data[999] ^= 1
+				if err := ts.Storage.Put(ctx, chunkKey(id, 0), bytes.NewReader(data), int64(len(data)), "application/octet-stream"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			w := ts.do(http.MethodPost, "/exams/uploads/"+id+"/finalize", nil, nil)
+			if w.Code != tt.want {
+				t.Fatalf("finalize = %d %s, want %d", w.Code, w.Body, tt.want)
+			}
+
+			quarantined, err := ts.DB.ListQuarantinedExams(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			infected := tt.want == http.StatusUnprocessableEntity && !tt.corrupt
+			if infected != (len(quarantined) == 1) {
+				t.Fatalf("%d quarantined exams", len(quarantined))
+			}
+			if infected {
+				if quarantined[0].Signature != "Eicar" {
+					t.Errorf("quarantined with signature %q", quarantined[0].Signature)
+				}
+				if _, err := ts.Storage.Stat(ctx, "exams/"+id+".pdf"); !errors.Is(err, buckets.ErrNotFound) {
+					t.Errorf("Stat of the infected object: %v, want ErrNotFound", err)
+				}
+			}
+		})
+	}
+}
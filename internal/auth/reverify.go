@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/config"
+	"github.com/fachschaftinformatik/web/internal/database"
+	"github.com/fachschaftinformatik/web/internal/email"
+	"github.com/fachschaftinformatik/web/internal/i18n"
+)
+
+// StartVerificationSweeper reminds users whose verification is about to end
+// and unverifies them once it has ended.
+func StartVerificationSweeper(ctx context.Context, querier database.Store, sender *email.Sender, outbox *email.Outbox, cfg *config.Config, logger *log.Logger) {
+	logger.Println("Verification sweeper started.")
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		sendReverificationReminders(ctx, querier, sender, outbox, cfg, logger)
+		expireVerifications(ctx, querier, logger)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			logger.Println("Verification sweeper stopped.")
+			return
+		}
+	}
+}
+
+func sendReverificationReminders(ctx context.Context, querier database.Store, sender *email.Sender, outbox *email.Outbox, cfg *config.Config, logger *log.Logger) {
+	remindBefore := database.NewNullTime(time.Now().AddDate(0, 0, cfg.ReverifyRemind))
+	users, err := querier.ListUsersDueForReminder(ctx, remindBefore)
+	if err != nil {
+		logger.Printf("Error listing users due for re-verification: %v", err)
+		return
+	}
+
+	queued := 0
+	for _, user := range users {
+		// The claim, the new token and the email are stored together, so a
+		// restart neither queues a second reminder for the same verification
+		// period nor loses the first one.
+		err := querier.WithTx(ctx, func(q database.Querier) error {
+			n, err := q.ClaimVerificationReminder(ctx, database.ClaimVerificationReminderParams{
+				Userid:        user.ID,
+				VerifiedUntil: database.NewTime(user.VerifiedUntil.Time),
+			})
+			if err != nil || n == 0 {
+				return err
+			}
+			if err := queueReverificationReminder(ctx, q, sender, outbox, user); err != nil {
+				return err
+			}
+			queued++
+			return nil
+		})
+		if err != nil {
+			logger.Printf("Failed to queue re-verification reminder to %s: %v", user.Email, err)
+		}
+	}
+	if queued > 0 {
+		outbox.Notify()
+	}
+}
+
+func queueReverificationReminder(ctx context.Context, q database.Querier, sender *email.Sender, outbox *email.Outbox, user database.User) error {
+	token, tokenHash := newToken()
+	if err := q.UpdateUserToken(ctx, database.UpdateUserTokenParams{
+		ID:                user.ID,
+		VerificationToken: sql.NullString{String: tokenHash, Valid: true},
+	}); err != nil {
+		return err
+	}
+	// Only the link of the latest reminder unsubscribes.
+	unsubscribeToken, unsubscribeHash := newToken()
+	if err := q.UpdateUserUnsubscribeToken(ctx, database.UpdateUserUnsubscribeTokenParams{
+		ID:               user.ID,
+		UnsubscribeToken: sql.NullString{String: unsubscribeHash, Valid: true},
+	}); err != nil {
+		return err
+	}
+
+	msg, err := sender.ReverificationEmail(i18n.Locale(user.Locale), user.Email, user.Name, token, unsubscribeToken, user.VerifiedUntil.Time)
+	if err != nil {
+		return err
+	}
+	return outbox.Stage(ctx, q, msg)
+}
+
+func expireVerifications(ctx context.Context, querier database.Store, logger *log.Logger) {
+	// Unverified users have to verify again when logging in.
+	var ids []string
+	err := querier.WithTx(ctx, func(q database.Querier) error {
+		var err error
+		if ids, err = q.SweepExpiredVerifications(ctx); err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := q.DeleteUserSessions(ctx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Printf("Error sweeping verifications: %v", err)
+		return
+	}
+	if len(ids) > 0 {
+		logger.Printf("Unverified %d users whose verification expired.", len(ids))
+	}
+
+	if err := querier.DeletePastVerificationReminders(ctx); err != nil {
+		logger.Printf("Error sweeping verification reminders: %v", err)
+	}
+}
+
+// PostAuthUnsubscribe turns off reminders for the user whose latest reminder
+// linked to the token. It needs no session, since mail clients call it on
+// their own.
+func (s *Server) PostAuthUnsubscribe(w http.ResponseWriter, r *http.Request, params api.PostAuthUnsubscribeParams) {
+	n, err := s.DB.UnsubscribeUser(r.Context(), sql.NullString{String: hashToken(params.Token), Valid: true})
+	if err != nil {
+		s.Log.Printf("Failed to unsubscribe: %v", err)
+		s.jsonError(w, r, "server_error", "Database error", http.StatusInternalServerError)
+		return
+	}
+	if n == 0 {
+		s.jsonError(w, r, "invalid_token", "Invalid unsubscribe token", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
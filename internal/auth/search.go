@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/database"
+)
+
+// exportBatchSize is the number of users fetched per query while streaming a
+// CSV export.
+const exportBatchSize = 256
+
+// userFilter holds the user search filters shared by GetUsers and
+// GetUsersExport. Nil fields are not filtered on.
+type userFilter struct {
+	query     *string
+	role      *string
+	programid *int
+	verified  *int
+	active    *int
+	after     *time.Time
+	before    *time.Time
+}
+
+func (f userFilter) validate() error {
+	if f.role != nil {
+		switch *f.role {
+		case "user", "editor", "admin":
+		default:
+			return errors.New("role must be one of user, editor or admin")
+		}
+	}
+	if f.verified != nil && *f.verified != 0 && *f.verified != 1 {
+		return errors.New("verified must be 0 or 1")
+	}
+	if f.active != nil && *f.active != 0 && *f.active != 1 {
+		return errors.New("active must be 0 or 1")
+	}
+	return nil
+}
+
+func (f userFilter) searchParams() database.SearchUsersParams {
+	c := f.countParams()
+	return database.SearchUsersParams{
+		Query:               c.Query,
+		Role:                c.Role,
+		Programid:           c.Programid,
+		Verified:            c.Verified,
+		Active:              c.Active,
+		VerifiedUntilAfter:  c.VerifiedUntilAfter,
+		VerifiedUntilBefore: c.VerifiedUntilBefore,
+	}
+}
+
+func (f userFilter) countParams() database.CountUsersParams {
+	var p database.CountUsersParams
+	if f.query != nil && strings.TrimSpace(*f.query) != "" {
+		p.Query = sql.NullString{String: strings.TrimSpace(*f.query), Valid: true}
+	}
+	if f.role != nil {
+		p.Role = sql.NullString{String: *f.role, Valid: true}
+	}
+	if f.programid != nil {
+		p.Programid = sql.NullInt64{Int64: int64(*f.programid), Valid: true}
+	}
+	if f.verified != nil {
+		p.Verified = sql.NullInt64{Int64: int64(*f.verified), Valid: true}
+	}
+	if f.active != nil {
+		p.Active = sql.NullInt64{Int64: int64(*f.active), Valid: true}
+	}
+	if f.after != nil {
+		p.VerifiedUntilAfter = database.NewNullTime(*f.after)
+	}
+	if f.before != nil {
+		p.VerifiedUntilBefore = database.NewNullTime(*f.before)
+	}
+	return p
+}
+
+// encodeUserCursor returns an opaque cursor pointing just past the given row
+// in the (created_at, id) ordering used by SearchUsers.
+func encodeUserCursor(createdAt, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(createdAt + "\n" + id))
+}
+
+func decodeUserCursor(cursor string) (createdAt, id string, err error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", "", err
+	}
+	createdAt, id, ok := strings.Cut(string(b), "\n")
+	if !ok || createdAt == "" || id == "" {
+		return "", "", errors.New("malformed cursor")
+	}
+	return createdAt, id, nil
+}
+
+func (s *Server) GetUsersExport(w http.ResponseWriter, r *http.Request, params api.GetUsersExportParams) {
+	_, dbUser, err := s.authenticate(w, r, scopeUsersRead)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if dbUser.Role != "admin" {
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		return
+	}
+
+	filter := userFilter{
+		query:     params.Q,
+		programid: params.Programid,
+		after:     params.VerifiedUntilAfter,
+		before:    params.VerifiedUntilBefore,
+	}
+	if params.Role != nil {
+		filter.role = (*string)(params.Role)
+	}
+	if params.Verified != nil {
+		filter.verified = (*int)(params.Verified)
+	}
+	if params.Active != nil {
+		filter.active = (*int)(params.Active)
+	}
+	if err := filter.validate(); err != nil {
+		s.jsonError(w, r, "invalid_filter", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	programs, err := s.DB.ListProgramsWithVersions(ctx)
+	if err != nil {
+		s.Log.Printf("Failed to list programs: %v", err)
+		s.jsonError(w, r, "database_error", "Could not export users", http.StatusInternalServerError)
+		return
+	}
+	programNames := make(map[int64]string, len(programs))
+	for _, program := range programs {
+		programNames[program.ID] = program.Name
+	}
+
+	search := filter.searchParams()
+	search.Limit = exportBatchSize
+	batch, err := s.DB.SearchUsers(ctx, search)
+	if err != nil {
+		s.Log.Printf("Failed to list users: %v", err)
+		s.jsonError(w, r, "database_error", "Could not export users", http.StatusInternalServerError)
+		return
+	}
+
+	filename := fmt.Sprintf("users-%s.csv", time.Now().UTC().Format("2006-01-02"))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	out := csv.NewWriter(w)
+	out.Write([]string{
+		"id", "email", "name", "role", "active", "verified",
+		"verified_at", "verified_until", "programid", "program", "created_at",
+	})
+
+	// The status line has been sent, so errors past this point can only be
+	// logged and end the export early.
+	for len(batch) > 0 {
+		for _, user := range batch {
+			out.Write([]string{
+				user.ID,
+				csvSafe(user.Email),
+				csvSafe(user.Name),
+				user.Role,
+				strconv.FormatInt(user.Active, 10),
+				strconv.FormatInt(user.Verified, 10),
+				user.VerifiedAt.String(),
+				user.VerifiedUntil.String(),
+				strconv.FormatInt(user.Programid, 10),
+				programNames[user.Programid],
+				user.CreatedAt.String(),
+			})
+		}
+		out.Flush()
+		if err := out.Error(); err != nil {
+			s.Log.Printf("Failed to write user export: %v", err)
+			return
+		}
+
+		if len(batch) < exportBatchSize {
+			break
+		}
+		last := batch[len(batch)-1]
+		search.AfterCreatedAt = sql.NullString{String: last.CreatedAt.String(), Valid: true}
+		search.AfterID = sql.NullString{String: last.ID, Valid: true}
+		if batch, err = s.DB.SearchUsers(ctx, search); err != nil {
+			s.Log.Printf("Failed to list users during export: %v", err)
+			return
+		}
+	}
+}
+
+// csvSafe keeps spreadsheet applications from interpreting user supplied
+// values as formulas when the export is opened.
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
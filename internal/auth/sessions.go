@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"database/sql"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/database"
+)
+
+func (s *Server) GetAuthSessions(w http.ResponseWriter, r *http.Request) {
+	session, dbUser, err := s.authenticate(w, r, "")
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	dbSessions, err := s.DB.ListUserSessions(r.Context(), dbUser.ID)
+	if err != nil {
+		s.Log.Printf("Failed to list sessions: %v", err)
+		s.jsonError(w, r, "database_error", "Could not list sessions", http.StatusInternalServerError)
+		return
+	}
+
+	apiSessions := make([]api.Session, 0, len(dbSessions))
+	for _, dbSession := range dbSessions {
+		apiSessions = append(apiSessions, dbSessionToAPI(dbSession, dbSession.ID == session.ID))
+	}
+
+	s.respondJSON(w, http.StatusOK, apiSessions)
+}
+
+func (s *Server) DeleteAuthSessions(w http.ResponseWriter, r *http.Request, params api.DeleteAuthSessionsParams) {
+	session, dbUser, err := s.authenticate(w, r, "")
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	if err := s.DB.DeleteOtherUserSessions(r.Context(), database.DeleteOtherUserSessionsParams{
+		Userid: dbUser.ID,
+		ID:     session.ID,
+	}); err != nil {
+		s.Log.Printf("Failed to delete sessions: %v", err)
+		s.jsonError(w, r, "database_error", "Could not revoke sessions", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) DeleteAuthSessionsId(w http.ResponseWriter, r *http.Request, id string, params api.DeleteAuthSessionsIdParams) {
+	session, dbUser, err := s.authenticate(w, r, "")
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	// Sessions are scoped to the caller, so guessing another user's
+	// handle yields the same 404 as a handle that does not exist.
+	n, err := s.DB.DeleteUserSession(r.Context(), database.DeleteUserSessionParams{
+		Publicid: id,
+		Userid:   dbUser.ID,
+	})
+	if err != nil {
+		s.Log.Printf("Failed to delete session: %v", err)
+		s.jsonError(w, r, "database_error", "Could not revoke session", http.StatusInternalServerError)
+		return
+	}
+	if n == 0 {
+		s.jsonError(w, r, "not_found", "Session not found", http.StatusNotFound)
+		return
+	}
+
+	if id == session.Publicid {
+		s.setCookie(w, sessionCookieName, "", -time.Hour, true)
+		s.setCookie(w, csrfCookieName, "", -time.Hour, false)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) DeleteUsersIdSessions(w http.ResponseWriter, r *http.Request, id string, params api.DeleteUsersIdSessionsParams) {
+	_, authUser, err := s.authenticate(w, r, scopeUsersWrite)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if authUser.Role != "admin" {
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	if _, err := s.DB.GetUser(r.Context(), id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.jsonError(w, r, "not_found", "User not found", http.StatusNotFound)
+		} else {
+			s.Log.Printf("Failed to get user: %v", err)
+			s.jsonError(w, r, "database_error", "Database error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := s.DB.DeleteUserSessions(r.Context(), id); err != nil {
+		s.Log.Printf("Failed to delete sessions of user %s: %v", id, err)
+		s.jsonError(w, r, "database_error", "Could not revoke sessions", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func dbSessionToAPI(session database.Session, current bool) api.Session {
+	return api.Session{
+		Id:        session.Publicid,
+		Userid:    session.Userid,
+		UserAgent: session.UserAgent,
+		Ip:        session.Ip,
+		Device:    session.Device,
+		Current:   current,
+		CreatedAt: session.CreatedAt.Time,
+		LastSeen:  session.LastSeen.Time,
+		ExpiresAt: session.ExpiresAt.Time,
+	}
+}
+
+// clientIP returns the address of the peer that opened the connection.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// deviceLabel turns a User-Agent header into a coarse, human readable label
+// such as "Firefox on Linux". It is only meant to help users recognise their
+// own sessions, not to fingerprint them.
+func deviceLabel(userAgent string) string {
+	var browser string
+	switch {
+	case strings.Contains(userAgent, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(userAgent, "OPR/"):
+		browser = "Opera"
+	case strings.Contains(userAgent, "Firefox/"):
+		browser = "Firefox"
+	case strings.Contains(userAgent, "Chrome/"), strings.Contains(userAgent, "CriOS/"):
+		browser = "Chrome"
+	case strings.Contains(userAgent, "Safari/"):
+		browser = "Safari"
+	}
+
+	var os string
+	switch {
+	case strings.Contains(userAgent, "Windows"):
+		os = "Windows"
+	case strings.Contains(userAgent, "Android"):
+		os = "Android"
+	case strings.Contains(userAgent, "iPhone"), strings.Contains(userAgent, "iPad"):
+		os = "iOS"
+	case strings.Contains(userAgent, "Mac OS X"):
+		os = "macOS"
+	case strings.Contains(userAgent, "CrOS"):
+		os = "ChromeOS"
+	case strings.Contains(userAgent, "Linux"):
+		os = "Linux"
+	}
+
+	switch {
+	case browser != "" && os != "":
+		return browser + " on " + os
+	case browser != "":
+		return browser
+	case os != "":
+		return os
+	default:
+		return "Unknown device"
+	}
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/database"
+)
+
+var (
+	errAccountDisabled  = errors.New("account deactivated")
+	errDomainNotAllowed = errors.New("email domain no longer allowed")
+)
+
+func (s *Server) GetAuthSignupPolicy(w http.ResponseWriter, r *http.Request) {
+	domains := s.Config.SignupsDomains
+	if domains == nil {
+		domains = []string{}
+	}
+
+	s.respondJSON(w, http.StatusOK, api.SignupPolicy{
+		Domains: domains,
+		Verify:  s.Config.SignupsVerify,
+	})
+}
+
+// domainAllowed reports whether email belongs to one of the domains that are
+// open for registration. An empty allowlist allows every domain.
+func (s *Server) domainAllowed(email string) bool {
+	if len(s.Config.SignupsDomains) == 0 {
+		return true
+	}
+	return slices.Contains(s.Config.SignupsDomains, emailDomain(email))
+}
+
+// checkAccount reports whether user may still log in. Users whose domain was
+// removed from the allowlist are locked out, except for admins so that they
+// cannot lock themselves out by editing the configuration.
+func (s *Server) checkAccount(user database.User) error {
+	if user.Active == 0 {
+		return errAccountDisabled
+	}
+	if user.Role != "admin" && !s.domainAllowed(user.Email) {
+		return errDomainNotAllowed
+	}
+	return nil
+}
+
+func (s *Server) accountError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, errDomainNotAllowed) {
+		s.jsonError(w, r, "email_domain_not_allowed", "Accounts with this email domain are no longer allowed", http.StatusForbidden)
+		return
+	}
+	s.jsonError(w, r, "account_disabled", "This account has been deactivated", http.StatusForbidden)
+}
+
+func emailDomain(email string) string {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(email[at+1:])
+}
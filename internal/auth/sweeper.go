@@ -17,6 +17,9 @@ func StartSessionSweeper(ctx context.Context, querier database.Querier, logger *
 		if err := querier.DeleteExpiredSessions(ctx); err != nil { //
 			logger.Printf("Error sweeping sessions: %v", err)
 		}
+		if err := querier.DeleteExpiredAPITokens(ctx); err != nil {
+			logger.Printf("Error sweeping API tokens: %v", err)
+		}
 		select {
 		case <-ticker.C:
 		case <-ctx.Done():
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/email"
+	"github.com/fachschaftinformatik/web/internal/i18n"
+)
+
+func (s *Server) GetEmailTemplatesNamePreview(w http.ResponseWriter, r *http.Request, name api.GetEmailTemplatesNamePreviewParamsName, params api.GetEmailTemplatesNamePreviewParams) {
+	_, dbUser, err := s.authenticate(w, r, "")
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if dbUser.Role != "editor" && dbUser.Role != "admin" {
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		return
+	}
+
+	locale := i18n.Locale(dbUser.Locale)
+	if params.Locale != nil {
+		var ok bool
+		if locale, ok = i18n.Parse(string(*params.Locale)); !ok {
+			s.jsonError(w, r, "invalid_locale", "Unsupported locale", http.StatusBadRequest)
+			return
+		}
+	}
+
+	msg, err := s.Email.Preview(locale, string(name))
+	if errors.Is(err, email.ErrUnknownTemplate) {
+		s.jsonError(w, r, "not_found", "Unknown email template", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		s.Log.Printf("Failed to render email template %s: %v", name, err)
+		s.jsonError(w, r, "server_error", "Could not render email template", http.StatusInternalServerError)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, api.EmailPreview{
+		Locale:  api.Locale(locale),
+		Subject: msg.Subject,
+		Html:    msg.HTML,
+		Text:    msg.Text,
+	})
+}
@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// tokenBytes is the amount of entropy in session and verification tokens.
+const tokenBytes = 32
+
+// newToken returns a random token to hand out to the client together with
+// the hash that is stored in the database in its place.
+func newToken() (token, hash string) {
+	b := make([]byte, tokenBytes)
+	// crypto/rand.Read never returns an error and always fills b.
+	rand.Read(b)
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, hashToken(token)
+}
+
+// hashToken returns the database representation of a client token. The
+// tokens carry enough entropy that a fast, unsalted hash is sufficient.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
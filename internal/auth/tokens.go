@@ -0,0 +1,227 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/database"
+	"github.com/fachschaftinformatik/web/internal/i18n"
+	"github.com/google/uuid"
+)
+
+const (
+	scopeProfileRead = string(api.ProfileRead)
+	scopeUsersRead   = string(api.UsersRead)
+	scopeUsersWrite  = string(api.UsersWrite)
+	scopeExamsRead   = string(api.ExamsRead)
+	scopeExamsWrite  = string(api.ExamsWrite)
+
+	// apiTokenPrefix makes leaked tokens easy to recognise, e.g. for secret
+	// scanners.
+	apiTokenPrefix        = "fsv_"
+	apiTokenDefaultExpiry = 30
+	apiTokenMaxExpiry     = 365
+)
+
+var (
+	validScopes          = []string{scopeProfileRead, scopeUsersRead, scopeUsersWrite, scopeExamsRead, scopeExamsWrite}
+	errInsufficientScope = errors.New("token lacks the required scope")
+)
+
+func (s *Server) GetAuthTokens(w http.ResponseWriter, r *http.Request) {
+	_, dbUser, err := s.authenticate(w, r, "")
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	dbTokens, err := s.DB.ListUserAPITokens(r.Context(), dbUser.ID)
+	if err != nil {
+		s.Log.Printf("Failed to list API tokens: %v", err)
+		s.jsonError(w, r, "database_error", "Could not list tokens", http.StatusInternalServerError)
+		return
+	}
+
+	apiTokens := make([]api.ApiToken, 0, len(dbTokens))
+	for _, dbToken := range dbTokens {
+		apiTokens = append(apiTokens, dbAPITokenToAPI(dbToken))
+	}
+
+	s.respondJSON(w, http.StatusOK, apiTokens)
+}
+
+func (s *Server) PostAuthTokens(w http.ResponseWriter, r *http.Request, params api.PostAuthTokensParams) {
+	_, dbUser, err := s.authenticate(w, r, "")
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	var payload api.ApiTokenCreate
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		s.jsonError(w, r, "invalid_request_body", "Could not decode JSON body", http.StatusBadRequest)
+		return
+	}
+
+	name := strings.TrimSpace(payload.Name)
+	if name == "" || len(name) > 64 {
+		s.jsonError(w, r, "invalid_name", "Token name must be between 1 and 64 characters", http.StatusBadRequest)
+		return
+	}
+
+	if len(payload.Scopes) == 0 {
+		s.jsonError(w, r, "invalid_scopes", "At least one scope is required", http.StatusBadRequest)
+		return
+	}
+	scopes := make([]string, 0, len(payload.Scopes))
+	for _, scope := range payload.Scopes {
+		if !slices.Contains(validScopes, string(scope)) {
+			s.jsonError(w, r, "invalid_scopes", i18n.Sprintf(requestLocale(r), "Unknown scope %q", scope), http.StatusBadRequest)
+			return
+		}
+		if !slices.Contains(scopes, string(scope)) {
+			scopes = append(scopes, string(scope))
+		}
+	}
+
+	days := apiTokenDefaultExpiry
+	if payload.ExpiresInDays != nil {
+		days = *payload.ExpiresInDays
+	}
+	if days < 1 || days > apiTokenMaxExpiry {
+		s.jsonError(w, r, "invalid_expiry", i18n.Sprintf(requestLocale(r), "Tokens must expire within 1 to %d days", apiTokenMaxExpiry), http.StatusBadRequest)
+		return
+	}
+
+	token, _ := newToken()
+	secret := apiTokenPrefix + token
+
+	dbToken, err := s.DB.CreateAPIToken(r.Context(), database.CreateAPITokenParams{
+		ID:        uuid.NewString(),
+		Userid:    dbUser.ID,
+		Name:      name,
+		Tokenhash: hashToken(secret),
+		Scopes:    strings.Join(scopes, " "),
+		ExpiresAt: database.NewTime(time.Now().AddDate(0, 0, days)),
+	})
+	if err != nil {
+		s.Log.Printf("Failed to create API token: %v", err)
+		s.jsonError(w, r, "database_error", "Could not create token", http.StatusInternalServerError)
+		return
+	}
+
+	apiToken := dbAPITokenToAPI(dbToken)
+	s.respondJSON(w, http.StatusCreated, api.ApiTokenCreated{
+		Id:        apiToken.Id,
+		Name:      apiToken.Name,
+		Scopes:    apiToken.Scopes,
+		CreatedAt: apiToken.CreatedAt,
+		ExpiresAt: apiToken.ExpiresAt,
+		LastUsed:  apiToken.LastUsed,
+		Token:     secret,
+	})
+}
+
+func (s *Server) DeleteAuthTokensId(w http.ResponseWriter, r *http.Request, id string, params api.DeleteAuthTokensIdParams) {
+	_, dbUser, err := s.authenticate(w, r, "")
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	n, err := s.DB.DeleteUserAPIToken(r.Context(), database.DeleteUserAPITokenParams{
+		ID:     id,
+		Userid: dbUser.ID,
+	})
+	if err != nil {
+		s.Log.Printf("Failed to delete API token: %v", err)
+		s.jsonError(w, r, "database_error", "Could not revoke token", http.StatusInternalServerError)
+		return
+	}
+	if n == 0 {
+		s.jsonError(w, r, "not_found", "Token not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authenticateToken resolves the owner of a personal access token, provided
+// the token is still valid and was granted scope.
+func (s *Server) authenticateToken(ctx context.Context, token, scope string) (database.User, error) {
+	dbToken, err := s.DB.GetAPITokenByHash(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return database.User{}, errors.New("invalid token")
+		}
+		s.Log.Printf("Auth: DB.GetAPITokenByHash error: %v", err)
+		return database.User{}, errors.New("database error")
+	}
+
+	if dbToken.ExpiresAt.Before(time.Now()) {
+		return database.User{}, errors.New("token expired")
+	}
+
+	if !slices.Contains(strings.Fields(dbToken.Scopes), scope) {
+		return database.User{}, fmt.Errorf("%w %s", errInsufficientScope, scope)
+	}
+
+	user, err := s.DB.GetUser(ctx, dbToken.Userid)
+	if err != nil {
+		s.Log.Printf("Auth: DB.GetUser error: %v", err)
+		return database.User{}, errors.New("user not found for token")
+	}
+
+	if err := s.checkAccount(user); err != nil {
+		return database.User{}, err
+	}
+
+	if err := s.DB.TouchAPIToken(ctx, dbToken.ID); err != nil {
+		s.Log.Printf("Auth: Failed to touch API token: %v", err)
+	}
+
+	return user, nil
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer" header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func dbAPITokenToAPI(token database.ApiToken) api.ApiToken {
+	apiToken := api.ApiToken{
+		Id:        token.ID,
+		Name:      token.Name,
+		Scopes:    []api.ApiTokenScope{},
+		CreatedAt: token.CreatedAt.Time,
+		ExpiresAt: token.ExpiresAt.Time,
+		LastUsed:  convertNullTime(token.LastUsed),
+	}
+	for _, scope := range strings.Fields(token.Scopes) {
+		apiToken.Scopes = append(apiToken.Scopes, api.ApiTokenScope(scope))
+	}
+	return apiToken
+}
@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/database"
+	"github.com/fachschaftinformatik/web/internal/i18n"
+	"github.com/oapi-codegen/runtime/types"
+)
+
+// storageQuota returns how many bytes users of role may store.
+func (s *Server) storageQuota(role string) int64 {
+	switch role {
+	case "admin":
+		return s.Config.QuotaAdmin
+	case "editor":
+		return s.Config.QuotaEditor
+	default:
+		return s.Config.QuotaUser
+	}
+}
+
+// quotaError writes the error response for an upload that would exceed a
+// quota.
+func (s *Server) quotaError(w http.ResponseWriter, r *http.Request, user database.User, result quotaResult) {
+	switch result {
+	case quotaUser:
+		s.jsonError(w, r, "quota_exceeded", i18n.Sprintf(requestLocale(r), "This upload would exceed your storage quota of %d MiB", s.storageQuota(user.Role)>>20), http.StatusRequestEntityTooLarge)
+	case quotaTotal:
+		s.jsonError(w, r, "storage_full", "There is not enough storage left for this upload", http.StatusRequestEntityTooLarge)
+	}
+}
+
+type quotaResult int
+
+const (
+	quotaOK quotaResult = iota
+	quotaUser
+	quotaTotal
+)
+
+// withinQuota reports whether user can upload nbytes more without exceeding
+// the quota of their role or of the whole storage. Pending uploads count as
+// used, so it has to run in the transaction that adds the upload for
+// concurrent uploads not to exceed a quota together.
+func (s *Server) withinQuota(ctx context.Context, q database.Querier, user database.User, nbytes int64) (quotaResult, error) {
+	now := database.NewTime(time.Now())
+	usage, err := q.GetUserStorageUsage(ctx, database.GetUserStorageUsageParams{Userid: user.ID, Now: now})
+	if err != nil {
+		return quotaOK, err
+	}
+	if usage.StoredBytes+usage.ReservedBytes+nbytes > s.storageQuota(user.Role) {
+		return quotaUser, nil
+	}
+	total, err := q.GetTotalStorageUsage(ctx, now)
+	if err != nil {
+		return quotaOK, err
+	}
+	if total.StoredBytes+total.ReservedBytes+nbytes > s.Config.QuotaTotal {
+		return quotaTotal, nil
+	}
+	return quotaOK, nil
+}
+
+func (s *Server) GetStorageUsage(w http.ResponseWriter, r *http.Request, params api.GetStorageUsageParams) {
+	_, authUser, err := s.authenticate(w, r, scopeUsersRead)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if authUser.Role != "admin" {
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		return
+	}
+
+	limit := int64(10)
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > 100 {
+			s.jsonError(w, r, "invalid_limit", "Limit must be between 1 and 100", http.StatusBadRequest)
+			return
+		}
+		limit = int64(*params.Limit)
+	}
+
+	ctx := r.Context()
+	total, err := s.DB.GetTotalStorageUsage(ctx, database.NewTime(time.Now()))
+	if err != nil {
+		s.Log.Printf("Failed to get storage usage: %v", err)
+		s.jsonError(w, r, "database_error", "Could not compute storage usage", http.StatusInternalServerError)
+		return
+	}
+	byType, err := s.DB.ListStorageUsageByType(ctx)
+	if err != nil {
+		s.Log.Printf("Failed to list storage usage by type: %v", err)
+		s.jsonError(w, r, "database_error", "Could not compute storage usage", http.StatusInternalServerError)
+		return
+	}
+	users, err := s.DB.ListTopStorageUsers(ctx, limit)
+	if err != nil {
+		s.Log.Printf("Failed to list top storage users: %v", err)
+		s.jsonError(w, r, "database_error", "Could not compute storage usage", http.StatusInternalServerError)
+		return
+	}
+
+	response := api.StorageUsage{
+		Nbytes:   int(total.StoredBytes),
+		Objects:  int(total.Objects),
+		Reserved: int(total.ReservedBytes),
+		Quota:    int(s.Config.QuotaTotal),
+		ByType:   make([]api.ContentTypeUsage, 0, len(byType)),
+		TopUsers: make([]api.UserStorageUsage, 0, len(users)),
+	}
+	for _, row := range byType {
+		response.ByType = append(response.ByType, api.ContentTypeUsage{
+			MimeType: row.MimeType,
+			Objects:  int(row.Objects),
+			Nbytes:   int(row.Nbytes),
+		})
+	}
+	for _, row := range users {
+		response.TopUsers = append(response.TopUsers, api.UserStorageUsage{
+			Userid:  row.ID,
+			Name:    row.Name,
+			Email:   types.Email(row.Email),
+			Role:    api.UserStorageUsageRole(row.Role),
+			Objects: int(row.Objects),
+			Nbytes:  int(row.Nbytes),
+			Quota:   int(s.storageQuota(row.Role)),
+		})
+	}
+
+	s.respondJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+)
+
+// Pending uploads reserve their size, so concurrent uploads must not exceed
+// the quota together.
+func TestUploadQuota(t *testing.T) {
+	ts := newTestServer(t)
+	ts.Config.UploadsPerUser = 10
+	ts.Config.QuotaAdmin = 250
+
+	data := testPDF(t, 100)
+	body, _ := json.Marshal(api.ExamUploadRequest{
+		Programid: 1,
+		Version:   "PO2023",
+		ExamDate:  "2025-07-01",
+		MimeType:  api.Applicationpdf,
+		Nbytes:    len(data),
+		Checksum:  sha256Hex(data),
+	})
+
+	codes := make([]int, 5)
+	var wg sync.WaitGroup
+	for i := range codes {
+		wg.Go(func() {
+			codes[i] = ts.do(http.MethodPost, "/exams/uploads", body, http.Header{"Content-Type": {"application/json"}}).Code
+		})
+	}
+	wg.Wait()
+
+	created := 0
+	for _, code := range codes {
+		switch code {
+		case http.StatusCreated:
+			created++
+		case http.StatusRequestEntityTooLarge:
+		default:
+			t.Errorf("POST /exams/uploads = %d", code)
+		}
+	}
+	if created != 2 {
+		t.Errorf("%d uploads started, want 2 within the quota of 250 bytes", created)
+	}
+}
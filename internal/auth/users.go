@@ -0,0 +1,288 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/fachschaftinformatik/web/internal/api"
+	"github.com/fachschaftinformatik/web/internal/database"
+)
+
+var errLastAdmin = errors.New("cannot remove the last active admin")
+
+func (s *Server) PatchUsersId(w http.ResponseWriter, r *http.Request, id string, params api.PatchUsersIdParams) {
+	_, authUser, err := s.authenticate(w, r, scopeUsersWrite)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if authUser.Role != "admin" {
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	// verified_until distinguishes between "absent" (keep) and "null" (no
+	// expiry), which the generated struct cannot express on its own.
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.jsonError(w, r, "invalid_request_body", "Could not read request body", http.StatusBadRequest)
+		return
+	}
+	var payload api.UserUpdate
+	var present map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		s.jsonError(w, r, "invalid_request_body", "Could not decode JSON body", http.StatusBadRequest)
+		return
+	}
+	if err := json.Unmarshal(body, &present); err != nil {
+		s.jsonError(w, r, "invalid_request_body", "Could not decode JSON body", http.StatusBadRequest)
+		return
+	}
+	_, windowChanged := present["verified_until"]
+
+	if payload.Role != nil {
+		switch *payload.Role {
+		case api.UserUpdateRoleUser, api.UserUpdateRoleEditor, api.UserUpdateRoleAdmin:
+		default:
+			s.jsonError(w, r, "invalid_role", "Role must be one of user, editor or admin", http.StatusBadRequest)
+			return
+		}
+	}
+	if payload.Active != nil && *payload.Active != 0 && *payload.Active != 1 {
+		s.jsonError(w, r, "invalid_active", "Active must be 0 or 1", http.StatusBadRequest)
+		return
+	}
+	if payload.Verified != nil && *payload.Verified != 0 && *payload.Verified != 1 {
+		s.jsonError(w, r, "invalid_verified", "Verified must be 0 or 1", http.StatusBadRequest)
+		return
+	}
+
+	if id == deletedUserID {
+		s.jsonError(w, r, "forbidden", "The deleted account placeholder cannot be changed", http.StatusForbidden)
+		return
+	}
+
+	ctx := r.Context()
+	dbUser, err := s.DB.GetUser(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.jsonError(w, r, "not_found", "User not found", http.StatusNotFound)
+		} else {
+			s.Log.Printf("Failed to get user: %v", err)
+			s.jsonError(w, r, "database_error", "Database error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	demote := payload.Role != nil && string(*payload.Role) != "admin"
+	deactivate := payload.Active != nil && *payload.Active == 0
+	if dbUser.ID == authUser.ID && (demote || deactivate) {
+		s.jsonError(w, r, "forbidden", "You cannot demote or deactivate yourself", http.StatusForbidden)
+		return
+	}
+
+	// The admin check is repeated in the transaction, so that two admins
+	// cannot demote each other at the same time.
+	err = s.DB.WithTx(ctx, func(q database.Querier) error {
+		user, err := q.GetUser(ctx, id)
+		if err != nil {
+			return err
+		}
+		if isActiveAdmin(user) && (demote || deactivate) {
+			if err := ensureOtherAdmin(ctx, q); err != nil {
+				return err
+			}
+		}
+
+		if payload.Role != nil && string(*payload.Role) != user.Role {
+			if user, err = q.SetUserRole(ctx, database.SetUserRoleParams{
+				ID:   user.ID,
+				Role: string(*payload.Role),
+			}); err != nil {
+				return err
+			}
+		}
+
+		if payload.Active != nil && int64(*payload.Active) != user.Active {
+			if user, err = q.SetUserActive(ctx, database.SetUserActiveParams{
+				ID:     user.ID,
+				Active: int64(*payload.Active),
+			}); err != nil {
+				return err
+			}
+		}
+
+		// Tokens are kept for when the user is reactivated,
+		// authenticateToken rejects them in the meantime.
+		if user.Active == 0 {
+			if err := q.DeleteUserSessions(ctx, user.ID); err != nil {
+				return err
+			}
+		}
+
+		verifiedUntil := user.VerifiedUntil
+		if windowChanged {
+			verifiedUntil = database.NullTime{}
+			if payload.VerifiedUntil != nil {
+				verifiedUntil = database.NewNullTime(*payload.VerifiedUntil)
+			}
+		}
+
+		switch {
+		case payload.Verified != nil && *payload.Verified == 0:
+			if user.Verified == 1 {
+				if user, err = q.UnverifyUser(ctx, user.ID); err != nil {
+					return err
+				}
+			}
+			if windowChanged {
+				user, err = q.UpdateUserVerificationWindow(ctx, database.UpdateUserVerificationWindowParams{
+					ID:            user.ID,
+					VerifiedUntil: verifiedUntil,
+				})
+			}
+		case payload.Verified != nil && *payload.Verified == 1 && user.Verified == 0:
+			user, err = q.VerifyUser(ctx, database.VerifyUserParams{
+				ID:            user.ID,
+				VerifiedUntil: verifiedUntil,
+			})
+		case windowChanged:
+			user, err = q.UpdateUserVerificationWindow(ctx, database.UpdateUserVerificationWindowParams{
+				ID:            user.ID,
+				VerifiedUntil: verifiedUntil,
+			})
+		}
+		if err != nil {
+			return err
+		}
+		dbUser = user
+		return nil
+	})
+	if errors.Is(err, errLastAdmin) {
+		s.adminError(w, r, err)
+		return
+	}
+	if err != nil {
+		s.userUpdateError(w, r, err)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, dbUserToAPI(dbUser))
+}
+
+func (s *Server) DeleteUsersId(w http.ResponseWriter, r *http.Request, id string, params api.DeleteUsersIdParams) {
+	_, authUser, err := s.authenticate(w, r, scopeUsersWrite)
+	if err != nil {
+		s.authError(w, r, err)
+		return
+	}
+
+	if authUser.Role != "admin" {
+		s.jsonError(w, r, "forbidden", "You do not have permission to access this resource", http.StatusForbidden)
+		return
+	}
+
+	if err := s.checkCSRF(r); err != nil {
+		s.jsonError(w, r, "invalid_csrf", err.Error(), http.StatusForbidden)
+		return
+	}
+
+	if id == authUser.ID {
+		s.jsonError(w, r, "forbidden", "You cannot delete yourself", http.StatusForbidden)
+		return
+	}
+
+	if id == deletedUserID {
+		s.jsonError(w, r, "forbidden", "The deleted account placeholder cannot be changed", http.StatusForbidden)
+		return
+	}
+
+	ctx := r.Context()
+	dbUser, err := s.DB.GetUser(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.jsonError(w, r, "not_found", "User not found", http.StatusNotFound)
+		} else {
+			s.Log.Printf("Failed to get user: %v", err)
+			s.jsonError(w, r, "database_error", "Database error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Like a self-service deletion, the content of the user is kept and
+	// handed over to the deleted user placeholder. The admin check reads the
+	// user again in the transaction, so that it also holds if the user was
+	// promoted in the meantime.
+	err = s.DB.WithTx(ctx, func(q database.Querier) error {
+		user, err := q.GetUser(ctx, id)
+		if err != nil {
+			return err
+		}
+		if isActiveAdmin(user) {
+			if err := ensureOtherAdmin(ctx, q); err != nil {
+				return err
+			}
+		}
+		return anonymiseUser(ctx, q, user.ID)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errLastAdmin):
+			s.adminError(w, r, err)
+		case errors.Is(err, sql.ErrNoRows):
+			s.jsonError(w, r, "not_found", "User not found", http.StatusNotFound)
+		default:
+			s.Log.Printf("Failed to delete user %s: %v", dbUser.ID, err)
+			s.jsonError(w, r, "database_error", "Could not delete user", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ensureOtherAdmin fails with errLastAdmin unless there is at least one
+// other active admin besides the one about to be removed.
+func ensureOtherAdmin(ctx context.Context, q database.Querier) error {
+	admins, err := q.CountActiveAdmins(ctx)
+	if err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return errLastAdmin
+	}
+	return nil
+}
+
+func (s *Server) adminError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, errLastAdmin) {
+		s.jsonError(w, r, "last_admin", "The last active admin cannot be removed", http.StatusConflict)
+		return
+	}
+	s.Log.Printf("Failed to count admins: %v", err)
+	s.jsonError(w, r, "database_error", "Database error", http.StatusInternalServerError)
+}
+
+func (s *Server) userUpdateError(w http.ResponseWriter, r *http.Request, err error) {
+	if strings.Contains(err.Error(), "CHECK constraint failed") {
+		s.jsonError(w, r, "invalid_verification_window", "The verification window must not end before the user was verified", http.StatusBadRequest)
+		return
+	}
+	s.Log.Printf("Failed to update user: %v", err)
+	s.jsonError(w, r, "database_error", "Could not update user", http.StatusInternalServerError)
+}
+
+func isActiveAdmin(user database.User) bool {
+	return user.Role == "admin" && user.Active == 1
+}
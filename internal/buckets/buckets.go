@@ -4,12 +4,18 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fachschaftinformatik/web/internal/config"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 )
 
+// Client stores objects in an S3 bucket.
 type Client struct {
 	minioClient *minio.Client
 	bucket      string
@@ -47,17 +53,139 @@ func (c *Client) EnsureBucket(ctx context.Context) error {
 	return nil
 }
 
-func (c *Client) Upload(ctx context.Context, objectName string, reader io.Reader, objectSize int64, contentType string) error {
-	_, err := c.minioClient.PutObject(ctx, c.bucket, objectName, reader, objectSize, minio.PutObjectOptions{
+var _ Storage = (*Client)(nil)
+
+func (c *Client) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	_, err := c.minioClient.PutObject(ctx, c.bucket, key, r, size, minio.PutObjectOptions{
 		ContentType: contentType,
 	})
 	return err
 }
 
-func (c *Client) GetObject(ctx context.Context, objectName string) (*minio.Object, error) {
-	return c.minioClient.GetObject(ctx, c.bucket, objectName, minio.GetObjectOptions{})
+func (c *Client) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	object, err := c.minioClient.GetObject(ctx, c.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, ObjectInfo{}, mapError(err)
+	}
+	// GetObject is lazy, missing objects only show up once it is used.
+	stat, err := object.Stat()
+	if err != nil {
+		object.Close()
+		return nil, ObjectInfo{}, mapError(err)
+	}
+	return object, objectInfo(stat), nil
+}
+
+func (c *Client) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, ObjectInfo, error) {
+	// The info of a ranged GetObject only describes the range.
+	info, err := c.Stat(ctx, key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	// S3 rejects ranges that start at the end of the object.
+	if length == 0 || offset >= info.Size {
+		return io.NopCloser(strings.NewReader("")), info, nil
+	}
+	var opts minio.GetObjectOptions
+	if length > 0 {
+		err = opts.SetRange(offset, offset+length-1)
+	} else if offset > 0 {
+		err = opts.SetRange(offset, 0)
+	}
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	object, err := c.minioClient.GetObject(ctx, c.bucket, key, opts)
+	if err != nil {
+		return nil, ObjectInfo{}, mapError(err)
+	}
+	return limitedBody(object, length), info, nil
+}
+
+func (c *Client) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	stat, err := c.minioClient.StatObject(ctx, c.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return ObjectInfo{}, mapError(err)
+	}
+	return objectInfo(stat), nil
+}
+
+func (c *Client) Delete(ctx context.Context, key string) error {
+	return c.minioClient.RemoveObject(ctx, c.bucket, key, minio.RemoveObjectOptions{})
+}
+
+// Compose copies the sources on the server, the data never passes through
+// this process.
+func (c *Client) Compose(ctx context.Context, dst string, srcs []string, contentType string) error {
+	if err := checkKey(dst); err != nil {
+		return err
+	}
+	sources := make([]minio.CopySrcOptions, 0, len(srcs))
+	for _, src := range srcs {
+		sources = append(sources, minio.CopySrcOptions{Bucket: c.bucket, Object: src})
+	}
+	_, err := c.minioClient.ComposeObject(ctx, minio.CopyDestOptions{
+		Bucket:      c.bucket,
+		Object:      dst,
+		ContentType: contentType,
+	}, sources...)
+	return mapError(err)
+}
+
+func (c *Client) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	for object := range c.minioClient.ListObjects(ctx, c.bucket, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		objects = append(objects, objectInfo(object))
+	}
+	return objects, nil
+}
+
+func (c *Client) Presign(ctx context.Context, method, key string, expiry time.Duration) (*url.URL, error) {
+	if err := checkMethod(method); err != nil {
+		return nil, err
+	}
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	if method == http.MethodPut {
+		return c.minioClient.PresignedPutObject(ctx, c.bucket, key, expiry)
+	}
+	return c.minioClient.PresignedGetObject(ctx, c.bucket, key, expiry, nil)
+}
+
+// PresignPut signs the Content-Type and Content-Length headers, so S3
+// rejects uploads that do not match them.
+func (c *Client) PresignPut(ctx context.Context, key string, size int64, contentType string, expiry time.Duration) (*url.URL, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	return c.minioClient.PresignHeader(ctx, http.MethodPut, c.bucket, key, expiry, nil, http.Header{
+		"Content-Type":   {contentType},
+		"Content-Length": {strconv.FormatInt(size, 10)},
+	})
+}
+
+func objectInfo(object minio.ObjectInfo) ObjectInfo {
+	return ObjectInfo{
+		Key:          object.Key,
+		Size:         object.Size,
+		ContentType:  object.ContentType,
+		LastModified: object.LastModified,
+	}
 }
 
-func (c *Client) Delete(ctx context.Context, objectName string) error {
-	return c.minioClient.RemoveObject(ctx, c.bucket, objectName, minio.RemoveObjectOptions{})
+func mapError(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrNotFound
+	}
+	return err
 }
@@ -0,0 +1,598 @@
+package buckets
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/config"
+)
+
+// Objects are encrypted in chunks, so that a range can be read without
+// decrypting the whole object. Each chunk is sealed with AES-GCM under a
+// data key of its own object, its index and whether it is the last chunk
+// are authenticated, so chunks cannot be reordered or cut off.
+//
+// An encrypted object starts with encryptedMagic and a random object ID.
+// The data key is stored wrapped by a master key in a separate object below
+// keyPrefix, named after the object and its ID, so that rotating the master
+// key only rewrites those small objects.
+const (
+	encryptedMagic     = "FSE1"
+	objectIDSize       = 16
+	encryptedHeader    = len(encryptedMagic) + objectIDSize
+	encryptedChunkSize = 64 << 10
+	encryptedTagSize   = 16
+	encryptedBlockSize = encryptedChunkSize + encryptedTagSize
+	keyPrefix          = ".keys/"
+)
+
+var errCorrupt = errors.New("encrypted object is corrupt")
+
+// EncryptedStorage encrypts the objects of another storage. Objects stored
+// before encryption was enabled are read as they are.
+type EncryptedStorage struct {
+	base Storage
+	// keys[0] wraps new data keys, the others are only used to unwrap keys
+	// that were not rotated yet.
+	keys   []masterKey
+	signer *urlSigner
+
+	// Writes to a key are serialized, otherwise a Put would delete the data
+	// key of a concurrent Put to the same key, leaving whichever object ends
+	// up stored without its key.
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	sync.Mutex
+	waiters int
+}
+
+type masterKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// wrappedKey is the content of a key object.
+type wrappedKey struct {
+	KeyID string `json:"kid"`
+	Nonce []byte `json:"nonce"`
+	Key   []byte `json:"key"`
+}
+
+func NewEncryptedStorage(base Storage, keys []masterKey, signer *urlSigner) *EncryptedStorage {
+	return &EncryptedStorage{base: base, keys: keys, signer: signer, locks: make(map[string]*keyLock)}
+}
+
+// lock blocks writes to key until the returned function is called.
+func (s *EncryptedStorage) lock(key string) func() {
+	s.mu.Lock()
+	l, ok := s.locks[key]
+	if !ok {
+		l = &keyLock{}
+		s.locks[key] = l
+	}
+	l.waiters++
+	s.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		s.mu.Lock()
+		if l.waiters--; l.waiters == 0 {
+			delete(s.locks, key)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// loadMasterKeys reads the base64 encoded 32 byte master keys from the key
+// file, one per line, or from the comma separated list in the config. The
+// first key is the current one.
+func loadMasterKeys(cfg *config.Config) ([]masterKey, error) {
+	list := strings.Split(cfg.EncryptionKeys, ",")
+	if cfg.EncryptKeyFile != "" {
+		raw, err := os.ReadFile(cfg.EncryptKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read encryption key file: %w", err)
+		}
+		list = strings.Split(string(raw), "\n")
+	}
+
+	var keys []masterKey
+	for _, encoded := range list {
+		encoded = strings.TrimSpace(encoded)
+		if encoded == "" || strings.HasPrefix(encoded, "#") {
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(raw) != 32 {
+			return nil, errors.New("encryption keys must be base64 encoded 32 byte keys")
+		}
+		aead, err := newGCM(raw)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(raw)
+		keys = append(keys, masterKey{id: hex.EncodeToString(sum[:8]), aead: aead})
+	}
+	return keys, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func checkUserKey(key string) error {
+	if strings.HasPrefix(key, keyPrefix) {
+		return fmt.Errorf("object keys must not start with %s", keyPrefix)
+	}
+	return checkKey(key)
+}
+
+func keyObject(key string, objectID []byte) string {
+	return keyPrefix + key + "/" + hex.EncodeToString(objectID)
+}
+
+func (s *EncryptedStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := checkUserKey(key); err != nil {
+		return err
+	}
+
+	objectID := make([]byte, objectIDSize)
+	dataKey := make([]byte, 32)
+	rand.Read(objectID)
+	rand.Read(dataKey)
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return err
+	}
+
+	unlock := s.lock(key)
+	defer unlock()
+
+	// The key is stored first, a reader that sees the new object has to be
+	// able to decrypt it.
+	record := keyObject(key, objectID)
+	if err := s.putKey(ctx, record, dataKey); err != nil {
+		return err
+	}
+	body := newEncryptReader(r, size, aead, objectID)
+	if err := s.base.Put(ctx, key, body, encryptedSize(size), contentType); err != nil {
+		s.base.Delete(ctx, record)
+		return err
+	}
+	return s.deleteKeys(ctx, key, record)
+}
+
+func (s *EncryptedStorage) putKey(ctx context.Context, record string, dataKey []byte) error {
+	current := s.keys[0]
+	nonce := make([]byte, current.aead.NonceSize())
+	rand.Read(nonce)
+	raw, err := json.Marshal(wrappedKey{
+		KeyID: current.id,
+		Nonce: nonce,
+		Key:   current.aead.Seal(nil, nonce, dataKey, []byte(record)),
+	})
+	if err != nil {
+		return err
+	}
+	return s.base.Put(ctx, record, bytes.NewReader(raw), int64(len(raw)), "application/json")
+}
+
+func (s *EncryptedStorage) getKey(ctx context.Context, record string) ([]byte, string, error) {
+	body, _, err := s.base.Get(ctx, record)
+	if errors.Is(err, ErrNotFound) {
+		return nil, "", fmt.Errorf("data key %s is missing: %w", record, errCorrupt)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	defer body.Close()
+
+	var wrapped wrappedKey
+	if err := json.NewDecoder(body).Decode(&wrapped); err != nil {
+		return nil, "", fmt.Errorf("data key %s is invalid: %w", record, err)
+	}
+	for _, master := range s.keys {
+		if master.id != wrapped.KeyID {
+			continue
+		}
+		dataKey, err := master.aead.Open(nil, wrapped.Nonce, wrapped.Key, []byte(record))
+		if err != nil {
+			return nil, "", fmt.Errorf("data key %s: %w", record, errCorrupt)
+		}
+		return dataKey, wrapped.KeyID, nil
+	}
+	return nil, "", fmt.Errorf("data key %s is wrapped by unknown master key %s", record, wrapped.KeyID)
+}
+
+// deleteKeys deletes the key objects of key except keep.
+func (s *EncryptedStorage) deleteKeys(ctx context.Context, key, keep string) error {
+	records, err := s.base.List(ctx, keyPrefix+key+"/")
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		// The prefix also matches the keys of objects below key.
+		if record.Key == keep || path.Dir(record.Key) != keyPrefix+key {
+			continue
+		}
+		if err := s.base.Delete(ctx, record.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// header returns the ID of an encrypted object, or nil if the object is not
+// encrypted.
+func (s *EncryptedStorage) header(ctx context.Context, key string) ([]byte, ObjectInfo, error) {
+	body, info, err := s.base.GetRange(ctx, key, 0, int64(encryptedHeader))
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	defer body.Close()
+
+	header := make([]byte, encryptedHeader)
+	if _, err := io.ReadFull(body, header); errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, info, nil
+	} else if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	if string(header[:len(encryptedMagic)]) != encryptedMagic {
+		return nil, info, nil
+	}
+	info.Size = plainSize(info.Size)
+	return header[len(encryptedMagic):], info, nil
+}
+
+func (s *EncryptedStorage) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	return s.GetRange(ctx, key, 0, -1)
+}
+
+// GetRange only fetches and decrypts the chunks that overlap the range.
+func (s *EncryptedStorage) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, ObjectInfo, error) {
+	if err := checkUserKey(key); err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	objectID, info, err := s.header(ctx, key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	if objectID == nil {
+		return s.base.GetRange(ctx, key, offset, length)
+	}
+
+	if length < 0 || offset+length > info.Size {
+		length = max(info.Size-offset, 0)
+	}
+	if length == 0 {
+		return io.NopCloser(bytes.NewReader(nil)), info, nil
+	}
+
+	dataKey, _, err := s.getKey(ctx, keyObject(key, objectID))
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	first := offset / encryptedChunkSize
+	last := (offset + length - 1) / encryptedChunkSize
+	body, _, err := s.base.GetRange(ctx, key,
+		int64(encryptedHeader)+first*encryptedBlockSize,
+		(last-first+1)*encryptedBlockSize)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	return &decryptReader{
+		src:       body,
+		aead:      aead,
+		objectID:  objectID,
+		index:     first,
+		last:      encryptedChunks(info.Size) - 1,
+		skip:      offset - first*encryptedChunkSize,
+		remaining: length,
+		buf:       make([]byte, encryptedBlockSize),
+	}, info, nil
+}
+
+func (s *EncryptedStorage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	if err := checkUserKey(key); err != nil {
+		return ObjectInfo{}, err
+	}
+	_, info, err := s.header(ctx, key)
+	return info, err
+}
+
+func (s *EncryptedStorage) Delete(ctx context.Context, key string) error {
+	if err := checkUserKey(key); err != nil {
+		return err
+	}
+	unlock := s.lock(key)
+	defer unlock()
+
+	if err := s.base.Delete(ctx, key); err != nil {
+		return err
+	}
+	return s.deleteKeys(ctx, key, "")
+}
+
+// Compose decrypts the sources and encrypts them again as one object, the
+// chunks of the sources do not line up with those of dst.
+func (s *EncryptedStorage) Compose(ctx context.Context, dst string, srcs []string, contentType string) error {
+	var size int64
+	readers := make([]io.Reader, 0, len(srcs))
+	for _, src := range srcs {
+		body, info, err := s.Get(ctx, src)
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+		size += info.Size
+		readers = append(readers, body)
+	}
+	return s.Put(ctx, dst, io.MultiReader(readers...), size, contentType)
+}
+
+func (s *EncryptedStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	objects, err := s.base.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.base.List(ctx, keyPrefix+prefix)
+	if err != nil {
+		return nil, err
+	}
+	encrypted := make(map[string]bool, len(records))
+	for _, record := range records {
+		encrypted[strings.TrimPrefix(path.Dir(record.Key), keyPrefix)] = true
+	}
+
+	listed := objects[:0]
+	for _, object := range objects {
+		if strings.HasPrefix(object.Key, keyPrefix) {
+			continue
+		}
+		if encrypted[object.Key] {
+			object.Size = plainSize(object.Size)
+		}
+		listed = append(listed, object)
+	}
+	return listed, nil
+}
+
+func (s *EncryptedStorage) Presign(ctx context.Context, method, key string, expiry time.Duration) (*url.URL, error) {
+	if err := checkMethod(method); err != nil {
+		return nil, err
+	}
+	if err := checkUserKey(key); err != nil {
+		return nil, err
+	}
+	return s.signer.sign(method, key, expiry, -1, ""), nil
+}
+
+func (s *EncryptedStorage) PresignPut(ctx context.Context, key string, size int64, contentType string, expiry time.Duration) (*url.URL, error) {
+	if err := checkUserKey(key); err != nil {
+		return nil, err
+	}
+	return s.signer.sign(http.MethodPut, key, expiry, size, contentType), nil
+}
+
+// ServeHTTP serves the presigned URLs. They cannot point to the bucket, the
+// objects have to pass through this process to be encrypted.
+func (s *EncryptedStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveSigned(w, r, s, s.signer)
+}
+
+// RotateKeys wraps all data keys that are not wrapped by the current master
+// key yet with it. The objects themselves are not touched. It returns the
+// number of rewrapped keys.
+func (s *EncryptedStorage) RotateKeys(ctx context.Context) (int, error) {
+	records, err := s.base.List(ctx, keyPrefix)
+	if err != nil {
+		return 0, err
+	}
+	var rotated int
+	for _, record := range records {
+		dataKey, keyID, err := s.getKey(ctx, record.Key)
+		if err != nil {
+			return rotated, err
+		}
+		if keyID == s.keys[0].id {
+			continue
+		}
+		if err := s.putKey(ctx, record.Key, dataKey); err != nil {
+			return rotated, err
+		}
+		rotated++
+	}
+	return rotated, nil
+}
+
+// StaleKeys returns the key objects whose object does not exist or has
+// another ID, e.g. because the object was deleted from the bucket directly
+// or storing it failed half way. Key objects of uploads still in progress
+// look the same, callers have to leave recent ones alone.
+func (s *EncryptedStorage) StaleKeys(ctx context.Context) ([]ObjectInfo, error) {
+	records, err := s.base.List(ctx, keyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	var stale []ObjectInfo
+	for _, record := range records {
+		key := strings.TrimPrefix(path.Dir(record.Key), keyPrefix)
+		objectID, _, err := s.header(ctx, key)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		if hex.EncodeToString(objectID) != path.Base(record.Key) {
+			stale = append(stale, record)
+		}
+	}
+	return stale, nil
+}
+
+// DeleteStaleKey deletes a key object returned by StaleKeys.
+func (s *EncryptedStorage) DeleteStaleKey(ctx context.Context, record string) error {
+	if !strings.HasPrefix(record, keyPrefix) {
+		return fmt.Errorf("%s is not a key object", record)
+	}
+	return s.base.Delete(ctx, record)
+}
+
+// encryptedChunks returns the number of chunks of an object of size bytes.
+// Empty objects have one empty chunk, so that they are authenticated too.
+func encryptedChunks(size int64) int64 {
+	return max(1, (size+encryptedChunkSize-1)/encryptedChunkSize)
+}
+
+func encryptedSize(size int64) int64 {
+	return int64(encryptedHeader) + size + encryptedChunks(size)*encryptedTagSize
+}
+
+func plainSize(size int64) int64 {
+	body := size - int64(encryptedHeader)
+	chunks := (body + encryptedBlockSize - 1) / encryptedBlockSize
+	return max(body-chunks*encryptedTagSize, 0)
+}
+
+func chunkNonce(index int64) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce[4:], uint64(index))
+	return nonce
+}
+
+func chunkAAD(objectID []byte, index int64, final bool) []byte {
+	aad := binary.BigEndian.AppendUint64(bytes.Clone(objectID), uint64(index))
+	if final {
+		return append(aad, 1)
+	}
+	return append(aad, 0)
+}
+
+// encryptReader encrypts size bytes read from src.
+type encryptReader struct {
+	src       io.Reader
+	aead      cipher.AEAD
+	objectID  []byte
+	index     int64
+	last      int64
+	remaining int64
+	buf       []byte
+	out       []byte
+}
+
+func newEncryptReader(src io.Reader, size int64, aead cipher.AEAD, objectID []byte) *encryptReader {
+	return &encryptReader{
+		src:       src,
+		aead:      aead,
+		objectID:  objectID,
+		last:      encryptedChunks(size) - 1,
+		remaining: size,
+		buf:       make([]byte, encryptedBlockSize),
+		out:       append([]byte(encryptedMagic), objectID...),
+	}
+}
+
+func (e *encryptReader) Read(p []byte) (int, error) {
+	for len(e.out) == 0 {
+		if e.index > e.last {
+			return 0, io.EOF
+		}
+		chunk := e.buf[:min(encryptedChunkSize, e.remaining)]
+		if _, err := io.ReadFull(e.src, chunk); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		e.remaining -= int64(len(chunk))
+		final := e.index == e.last
+		if final {
+			var extra [1]byte
+			if n, _ := io.ReadFull(e.src, extra[:]); n > 0 {
+				return 0, errors.New("the object is larger than announced")
+			}
+		}
+		e.out = e.aead.Seal(chunk[:0], chunkNonce(e.index), chunk, chunkAAD(e.objectID, e.index, final))
+		e.index++
+	}
+	n := copy(p, e.out)
+	e.out = e.out[n:]
+	return n, nil
+}
+
+// decryptReader decrypts the chunks read from src, which starts at chunk
+// index, and returns remaining bytes after skipping the first skip bytes.
+type decryptReader struct {
+	src       io.ReadCloser
+	aead      cipher.AEAD
+	objectID  []byte
+	index     int64
+	last      int64
+	skip      int64
+	remaining int64
+	buf       []byte
+	plain     []byte
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.remaining == 0 {
+			return 0, io.EOF
+		}
+		n, err := io.ReadFull(d.src, d.buf)
+		if errors.Is(err, io.EOF) {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if err != nil && !(errors.Is(err, io.ErrUnexpectedEOF) && d.index == d.last) {
+			return 0, err
+		}
+		plain, err := d.aead.Open(d.buf[:0], chunkNonce(d.index), d.buf[:n], chunkAAD(d.objectID, d.index, d.index == d.last))
+		if err != nil {
+			return 0, errCorrupt
+		}
+		d.index++
+		if d.skip > int64(len(plain)) {
+			return 0, errCorrupt
+		}
+		plain = plain[d.skip:]
+		d.skip = 0
+		d.plain = plain[:min(int64(len(plain)), d.remaining)]
+	}
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+	d.remaining -= int64(n)
+	return n, nil
+}
+
+func (d *decryptReader) Close() error {
+	return d.src.Close()
+}
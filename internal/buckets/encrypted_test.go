@@ -0,0 +1,286 @@
+package buckets
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testMasterKey(t *testing.T) masterKey {
+	t.Helper()
+	raw := make([]byte, 32)
+	rand.Read(raw)
+	aead, err := newGCM(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(raw)
+	return masterKey{id: hex.EncodeToString(sum[:8]), aead: aead}
+}
+
+func randomBytes(n int) []byte {
+	data := make([]byte, n)
+	rand.Read(data)
+	return data
+}
+
+// readAll returns a function reading the whole body returned by Get or
+// GetRange.
+func readAll(t *testing.T) func(io.ReadCloser, ObjectInfo, error) []byte {
+	return func(body io.ReadCloser, _ ObjectInfo, err error) []byte {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+}
+
+func TestEncryptedRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	base := NewMemoryStorage(nil)
+	s := NewEncryptedStorage(base, []masterKey{testMasterKey(t)}, nil)
+
+	sizes := []int{0, 1, encryptedChunkSize - 1, encryptedChunkSize, encryptedChunkSize + 1, 3*encryptedChunkSize + 5}
+	for _, size := range sizes {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			key := fmt.Sprintf("objects/%d", size)
+			data := randomBytes(size)
+			if err := s.Put(ctx, key, bytes.NewReader(data), int64(size), "application/pdf"); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+
+			body, info, err := s.Get(ctx, key)
+			if got := readAll(t)(body, info, err); !bytes.Equal(got, data) {
+				t.Errorf("Get returned %d bytes that differ from the %d stored", len(got), size)
+			}
+			if info.Size != int64(size) || info.ContentType != "application/pdf" {
+				t.Errorf("Get info = %d bytes of %s, want %d of application/pdf", info.Size, info.ContentType, size)
+			}
+			if info, err := s.Stat(ctx, key); err != nil || info.Size != int64(size) {
+				t.Errorf("Stat = %d bytes, %v, want %d", info.Size, err, size)
+			}
+
+			raw := readAll(t)(base.Get(ctx, key))
+			if int64(len(raw)) != encryptedSize(int64(size)) || !bytes.HasPrefix(raw, []byte(encryptedMagic)) {
+				t.Errorf("stored %d bytes, want %d starting with %s", len(raw), encryptedSize(int64(size)), encryptedMagic)
+			}
+			if size > 16 && bytes.Contains(raw, data[:16]) {
+				t.Error("stored object contains the plain text")
+			}
+		})
+	}
+
+	objects, err := s.List(ctx, "objects/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != len(sizes) {
+		t.Fatalf("List returned %d objects, want %d", len(objects), len(sizes))
+	}
+	for _, object := range objects {
+		if want := fmt.Sprintf("objects/%d", object.Size); object.Key != want {
+			t.Errorf("List reports %s with %d bytes", object.Key, object.Size)
+		}
+	}
+}
+
+func TestEncryptedGetRange(t *testing.T) {
+	ctx := context.Background()
+	s := NewEncryptedStorage(NewMemoryStorage(nil), []masterKey{testMasterKey(t)}, nil)
+	const chunk = encryptedChunkSize
+	size := int64(3*chunk + 100)
+	data := randomBytes(int(size))
+	if err := s.Put(ctx, "object", bytes.NewReader(data), size, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		offset, length int64
+	}{
+		{0, 10},
+		{0, -1},
+		{chunk - 5, 10},
+		{chunk, chunk},
+		{chunk - 1, chunk + 2},
+		{2*chunk - 1, chunk + 2},
+		{10, 3 * chunk},
+		{size - 1, 1},
+		{size - 10, -1},
+		{size - 10, 100},
+		{size, 10},
+		{size + 10, 10},
+	}
+	for _, tt := range tests {
+		got := readAll(t)(s.GetRange(ctx, "object", tt.offset, tt.length))
+		want := data[min(tt.offset, size):]
+		if tt.length >= 0 {
+			want = want[:min(tt.length, int64(len(want)))]
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("GetRange(%d, %d) returned %d bytes, want %d", tt.offset, tt.length, len(got), len(want))
+		}
+	}
+}
+
+func TestEncryptedTampering(t *testing.T) {
+	ctx := context.Background()
+	base := NewMemoryStorage(nil)
+	s := NewEncryptedStorage(base, []masterKey{testMasterKey(t)}, nil)
+	size := int64(2*encryptedChunkSize + 1)
+	if err := s.Put(ctx, "object", bytes.NewReader(randomBytes(int(size))), size, ""); err != nil {
+		t.Fatal(err)
+	}
+	raw := readAll(t)(base.Get(ctx, "object"))
+
+	tests := []struct {
+		name string
+		raw  []byte
+	}{
+		{"flipped bit", func() []byte {
+			b := bytes.Clone(raw)
+			b[encryptedHeader+encryptedBlockSize+1] ^= 1
+			return b
+		}()},
+		{"last chunk cut off", raw[:encryptedHeader+2*encryptedBlockSize]},
+		{"chunks swapped", func() []byte {
+			b := bytes.Clone(raw)
+			first := b[encryptedHeader : encryptedHeader+encryptedBlockSize]
+			second := b[encryptedHeader+encryptedBlockSize : encryptedHeader+2*encryptedBlockSize]
+			tmp := bytes.Clone(first)
+			copy(first, second)
+			copy(second, tmp)
+			return b
+		}()},
+	}
+	for _, tt := range tests {
+		if err := base.Put(ctx, "object", bytes.NewReader(tt.raw), int64(len(tt.raw)), ""); err != nil {
+			t.Fatal(err)
+		}
+		body, _, err := s.Get(ctx, "object")
+		if err != nil {
+			t.Fatalf("%s: Get: %v", tt.name, err)
+		}
+		_, err = io.ReadAll(body)
+		body.Close()
+		if err == nil {
+			t.Errorf("%s: reading succeeded", tt.name)
+		}
+	}
+}
+
+// Objects stored before encryption was enabled are read as they are.
+func TestEncryptedReadsPlainObjects(t *testing.T) {
+	ctx := context.Background()
+	base := NewMemoryStorage(nil)
+	data := []byte("stored before encryption")
+	if err := base.Put(ctx, "plain", bytes.NewReader(data), int64(len(data)), ""); err != nil {
+		t.Fatal(err)
+	}
+	s := NewEncryptedStorage(base, []masterKey{testMasterKey(t)}, nil)
+	if got := readAll(t)(s.GetRange(ctx, "plain", 7, 6)); string(got) != "before" {
+		t.Errorf("GetRange = %q, want %q", got, "before")
+	}
+	if err := s.Put(ctx, keyPrefix+"x", bytes.NewReader(nil), 0, ""); err == nil {
+		t.Errorf("Put below %s succeeded", keyPrefix)
+	}
+}
+
+func TestRotateKeys(t *testing.T) {
+	ctx := context.Background()
+	base := NewMemoryStorage(nil)
+	oldKey, newKey := testMasterKey(t), testMasterKey(t)
+
+	old := NewEncryptedStorage(base, []masterKey{oldKey}, nil)
+	objects := map[string][]byte{"a": randomBytes(10), "b/c": randomBytes(encryptedChunkSize + 1)}
+	for key, data := range objects {
+		if err := old.Put(ctx, key, bytes.NewReader(data), int64(len(data)), ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rotating := NewEncryptedStorage(base, []masterKey{newKey, oldKey}, nil)
+	if n, err := rotating.RotateKeys(ctx); err != nil || n != len(objects) {
+		t.Fatalf("RotateKeys = %d, %v, want %d", n, err, len(objects))
+	}
+	if n, err := rotating.RotateKeys(ctx); err != nil || n != 0 {
+		t.Errorf("second RotateKeys = %d, %v, want 0", n, err)
+	}
+
+	rotated := NewEncryptedStorage(base, []masterKey{newKey}, nil)
+	for key, data := range objects {
+		if got := readAll(t)(rotated.Get(ctx, key)); !bytes.Equal(got, data) {
+			t.Errorf("%s differs after rotating", key)
+		}
+	}
+	if _, _, err := old.Get(ctx, "a"); err == nil {
+		t.Error("the old master key still unwraps rotated keys")
+	}
+}
+
+// slowReader lets concurrent writes interleave.
+type slowReader struct {
+	r io.Reader
+}
+
+func (r slowReader) Read(p []byte) (int, error) {
+	time.Sleep(time.Millisecond)
+	return r.r.Read(p)
+}
+
+// Concurrent writes to a key must leave the stored object with its data key.
+func TestEncryptedConcurrentPut(t *testing.T) {
+	ctx := context.Background()
+	base := NewMemoryStorage(nil)
+	s := NewEncryptedStorage(base, []masterKey{testMasterKey(t)}, nil)
+
+	payloads := make(map[string]bool)
+	var wg sync.WaitGroup
+	for range 20 {
+		data := randomBytes(encryptedChunkSize + 1)
+		payloads[string(data)] = true
+		wg.Go(func() {
+			if err := s.Put(ctx, "object", slowReader{bytes.NewReader(data)}, int64(len(data)), ""); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := readAll(t)(s.Get(ctx, "object")); !payloads[string(got)] {
+		t.Error("stored object is none of the payloads")
+	}
+	records, err := base.List(ctx, keyPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Errorf("%d data keys left, want 1", len(records))
+	}
+	if stale, err := s.StaleKeys(ctx); err != nil || len(stale) != 0 {
+		t.Errorf("StaleKeys = %v, %v, want none", stale, err)
+	}
+
+	if err := s.Delete(ctx, "object"); err != nil {
+		t.Fatal(err)
+	}
+	if records, _ := base.List(ctx, ""); len(records) != 0 {
+		t.Errorf("%d objects left after Delete", len(records))
+	}
+	if _, _, err := s.Get(ctx, "object"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete: %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,235 @@
+package buckets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalStorage keeps objects as files below Dir/objects. The content type of
+// each object is kept next to it in Dir/meta.
+type LocalStorage struct {
+	Dir    string
+	signer *urlSigner
+}
+
+type localMeta struct {
+	ContentType string `json:"content_type"`
+}
+
+func NewLocalStorage(dir string, signer *urlSigner) (*LocalStorage, error) {
+	for _, sub := range []string{"objects", "meta", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create storage directory: %w", err)
+		}
+	}
+	return &LocalStorage{Dir: dir, signer: signer}, nil
+}
+
+func (s *LocalStorage) objectPath(key string) string {
+	return filepath.Join(s.Dir, "objects", filepath.FromSlash(key))
+}
+
+func (s *LocalStorage) metaPath(key string) string {
+	return filepath.Join(s.Dir, "meta", filepath.FromSlash(key)+".json")
+}
+
+// Put writes the object to a temporary file first and renames it into
+// place, so readers never see a partial object.
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	if err := s.writeAtomic(s.objectPath(key), func(f *os.File) error {
+		n, err := io.Copy(f, io.LimitReader(r, size+1))
+		if err != nil {
+			return err
+		}
+		if n != size {
+			return fmt.Errorf("object %s has %d bytes, expected %d", key, n, size)
+		}
+		return ctx.Err()
+	}); err != nil {
+		return err
+	}
+
+	meta, err := json.Marshal(localMeta{ContentType: contentType})
+	if err == nil {
+		err = s.writeAtomic(s.metaPath(key), func(f *os.File) error {
+			_, err := f.Write(meta)
+			return err
+		})
+	}
+	if err != nil {
+		os.Remove(s.objectPath(key))
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStorage) writeAtomic(path string, write func(f *os.File) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Join(s.Dir, "tmp"), "put-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	return s.GetRange(ctx, key, 0, -1)
+}
+
+func (s *LocalStorage) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, ObjectInfo, error) {
+	info, err := s.Stat(ctx, key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	f, err := os.Open(s.objectPath(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ObjectInfo{}, ErrNotFound
+	}
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, ObjectInfo{}, err
+	}
+	return limitedBody(f, length), info, nil
+}
+
+func (s *LocalStorage) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	if err := checkKey(key); err != nil {
+		return ObjectInfo{}, err
+	}
+	fi, err := os.Stat(s.objectPath(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ObjectInfo{}, ErrNotFound
+	}
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if fi.IsDir() {
+		return ObjectInfo{}, ErrNotFound
+	}
+	return s.info(key, fi), nil
+}
+
+func (s *LocalStorage) info(key string, fi fs.FileInfo) ObjectInfo {
+	info := ObjectInfo{
+		Key:          key,
+		Size:         fi.Size(),
+		ContentType:  "application/octet-stream",
+		LastModified: fi.ModTime(),
+	}
+	if raw, err := os.ReadFile(s.metaPath(key)); err == nil {
+		var meta localMeta
+		if json.Unmarshal(raw, &meta) == nil && meta.ContentType != "" {
+			info.ContentType = meta.ContentType
+		}
+	}
+	return info
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	if err := os.Remove(s.objectPath(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.Remove(s.metaPath(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStorage) Compose(ctx context.Context, dst string, srcs []string, contentType string) error {
+	var size int64
+	readers := make([]io.Reader, 0, len(srcs))
+	for _, src := range srcs {
+		body, info, err := s.Get(ctx, src)
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+		size += info.Size
+		readers = append(readers, body)
+	}
+	return s.Put(ctx, dst, io.MultiReader(readers...), size, contentType)
+}
+
+func (s *LocalStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	root := filepath.Join(s.Dir, "objects")
+	var objects []ObjectInfo
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return ctx.Err()
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, s.info(key, fi))
+		return nil
+	})
+	return objects, err
+}
+
+func (s *LocalStorage) Presign(ctx context.Context, method, key string, expiry time.Duration) (*url.URL, error) {
+	if err := checkMethod(method); err != nil {
+		return nil, err
+	}
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	return s.signer.sign(method, key, expiry, -1, ""), nil
+}
+
+func (s *LocalStorage) PresignPut(ctx context.Context, key string, size int64, contentType string, expiry time.Duration) (*url.URL, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	return s.signer.sign(http.MethodPut, key, expiry, size, contentType), nil
+}
+
+func (s *LocalStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveSigned(w, r, s, s.signer)
+}
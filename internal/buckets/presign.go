@@ -0,0 +1,181 @@
+package buckets
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const signedTransferTimeout = 30 * time.Minute
+
+// urlSigner presigns URLs for the backends without an S3 API of their own.
+// The URLs point to the storage handler, which main mounts at /storage.
+type urlSigner struct {
+	base *url.URL
+	key  []byte
+}
+
+// sign presigns a URL for method and key. PUT URLs may be bound to a size
+// and content type, a negative size leaves them unbound.
+func (s *urlSigner) sign(method, key string, expiry time.Duration, size int64, contentType string) *url.URL {
+	expires := time.Now().Add(expiry).Unix()
+	query := url.Values{
+		"method":  {method},
+		"expires": {strconv.FormatInt(expires, 10)},
+	}
+	if size >= 0 {
+		query.Set("size", strconv.FormatInt(size, 10))
+		query.Set("type", contentType)
+	}
+	query.Set("signature", hex.EncodeToString(s.mac(key, query)))
+
+	u := s.base.JoinPath(key)
+	u.RawQuery = query.Encode()
+	return u
+}
+
+// verify checks that r is allowed by the URL it was sent to.
+func (s *urlSigner) verify(r *http.Request, key string) error {
+	query := r.URL.Query()
+	signature, err := hex.DecodeString(query.Get("signature"))
+	if err != nil || !hmac.Equal(signature, s.mac(key, query)) {
+		return errors.New("invalid signature")
+	}
+	if query.Get("method") != r.Method {
+		return errors.New("the URL was not signed for this method")
+	}
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return errors.New("the URL has expired")
+	}
+	if query.Has("size") {
+		if strconv.FormatInt(r.ContentLength, 10) != query.Get("size") {
+			return errors.New("the URL was signed for a different size")
+		}
+		if r.Header.Get("Content-Type") != query.Get("type") {
+			return errors.New("the URL was signed for a different content type")
+		}
+	}
+	return nil
+}
+
+func (s *urlSigner) mac(key string, query url.Values) []byte {
+	h := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(h, "%s\n%s\n%s", query.Get("method"), key, query.Get("expires"))
+	if query.Has("size") {
+		fmt.Fprintf(h, "\n%s\n%s", query.Get("size"), query.Get("type"))
+	}
+	return h.Sum(nil)
+}
+
+// serveSigned answers requests to URLs presigned by signer, the way S3
+// answers requests to its presigned URLs.
+func serveSigned(w http.ResponseWriter, r *http.Request, storage Storage, signer *urlSigner) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	if err := checkKey(key); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := signer.verify(r, key); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	// Transfers of large objects take longer than the server timeouts meant
+	// for API requests allow.
+	rc := http.NewResponseController(w)
+	rc.SetReadDeadline(time.Now().Add(signedTransferTimeout))
+	rc.SetWriteDeadline(time.Now().Add(signedTransferTimeout))
+
+	switch r.Method {
+	case http.MethodGet:
+		info, err := storage.Stat(r.Context(), key)
+		if errors.Is(err, ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, "could not read object", http.StatusInternalServerError)
+			return
+		}
+		object := &objectReader{ctx: r.Context(), storage: storage, key: key, size: info.Size}
+		defer object.Close()
+		w.Header().Set("Content-Type", info.ContentType)
+		http.ServeContent(w, r, "", info.LastModified, object)
+	case http.MethodPut:
+		if r.ContentLength < 0 {
+			http.Error(w, "Content-Length is required", http.StatusLengthRequired)
+			return
+		}
+		if err := storage.Put(r.Context(), key, r.Body, r.ContentLength, r.Header.Get("Content-Type")); err != nil {
+			http.Error(w, "could not store object", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// objectReader reads an object lazily from the current offset, so that
+// http.ServeContent answers range requests with GetRange instead of reading
+// the whole object.
+type objectReader struct {
+	ctx     context.Context
+	storage Storage
+	key     string
+	size    int64
+	offset  int64
+	body    io.ReadCloser
+}
+
+func (o *objectReader) Read(p []byte) (int, error) {
+	if o.offset >= o.size {
+		return 0, io.EOF
+	}
+	if o.body == nil {
+		body, _, err := o.storage.GetRange(o.ctx, o.key, o.offset, -1)
+		if err != nil {
+			return 0, err
+		}
+		o.body = body
+	}
+	n, err := o.body.Read(p)
+	o.offset += int64(n)
+	return n, err
+}
+
+func (o *objectReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += o.offset
+	case io.SeekEnd:
+		offset += o.size
+	}
+	if offset < 0 {
+		return 0, errors.New("negative offset")
+	}
+	if offset != o.offset {
+		o.Close()
+		o.offset = offset
+	}
+	return offset, nil
+}
+
+func (o *objectReader) Close() error {
+	if o.body == nil {
+		return nil
+	}
+	err := o.body.Close()
+	o.body = nil
+	return err
+}
@@ -0,0 +1,150 @@
+package buckets
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/config"
+)
+
+// Storage keeps objects such as exam PDFs under slash separated keys.
+type Storage interface {
+	// Put stores size bytes read from r under key, replacing any object
+	// that was stored there before.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get returns the content of the object, which the caller has to close.
+	Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error)
+	// GetRange is like Get, but only returns length bytes starting at
+	// offset. A negative length reads to the end of the object. The
+	// ObjectInfo describes the whole object.
+	GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, ObjectInfo, error)
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// Delete succeeds if there is no object under key.
+	Delete(ctx context.Context, key string) error
+	// Compose concatenates the objects srcs into a new object dst. All
+	// sources but the last must have at least MinComposePart bytes.
+	Compose(ctx context.Context, dst string, srcs []string, contentType string) error
+	// List returns the objects whose keys start with prefix.
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// Presign returns a URL that allows anyone to GET or PUT the object
+	// until expiry has passed.
+	Presign(ctx context.Context, method, key string, expiry time.Duration) (*url.URL, error)
+	// PresignPut is like Presign for PUT requests, but the URL only accepts
+	// an object of exactly size bytes with the given content type.
+	PresignPut(ctx context.Context, key string, size int64, contentType string, expiry time.Duration) (*url.URL, error)
+}
+
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+var ErrNotFound = errors.New("object not found")
+
+// limitedBody reads at most n bytes from body, or all of it if n is
+// negative.
+func limitedBody(body io.ReadCloser, n int64) io.ReadCloser {
+	if n < 0 {
+		return body
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(body, n), body}
+}
+
+// MinComposePart is the smallest part S3 accepts in a multipart upload.
+const MinComposePart = 5 << 20
+
+// New returns the storage selected by cfg.Storage. The s3 backend creates
+// its bucket if needed. If encryption keys are configured, the storage
+// encrypts its objects.
+func New(ctx context.Context, cfg *config.Config) (Storage, error) {
+	storage, err := newBackend(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := loadMasterKeys(cfg)
+	if err != nil || len(keys) == 0 {
+		return storage, err
+	}
+	signer, err := newURLSigner(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewEncryptedStorage(storage, keys, signer), nil
+}
+
+func newBackend(ctx context.Context, cfg *config.Config) (Storage, error) {
+	switch cfg.Storage {
+	case "s3":
+		client, err := NewClient(cfg)
+		if err != nil {
+			return nil, err
+		}
+		if err := client.EnsureBucket(ctx); err != nil {
+			return nil, err
+		}
+		return client, nil
+	case "local":
+		signer, err := newURLSigner(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return NewLocalStorage(cfg.StorageDir, signer)
+	case "memory":
+		signer, err := newURLSigner(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return NewMemoryStorage(signer), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q, expected s3, local or memory", cfg.Storage)
+	}
+}
+
+// checkKey rejects keys that would escape the storage directory of the
+// local backend. The other backends check them too, so that keys work
+// everywhere.
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("invalid object key %q", key)
+	}
+	for segment := range strings.SplitSeq(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("invalid object key %q", key)
+		}
+	}
+	return nil
+}
+
+func checkMethod(method string) error {
+	if method != http.MethodGet && method != http.MethodPut {
+		return fmt.Errorf("cannot presign %s requests", method)
+	}
+	return nil
+}
+
+// newURLSigner signs with the configured key. Without one, a random key is
+// used, so presigned URLs stop working when the process restarts.
+func newURLSigner(cfg *config.Config) (*urlSigner, error) {
+	key := []byte(cfg.StorageKey)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		rand.Read(key)
+	}
+	base, err := url.Parse(cfg.Domain + "/api/storage/")
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain %q: %w", cfg.Domain, err)
+	}
+	return &urlSigner{base: base, key: key}, nil
+}
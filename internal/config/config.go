@@ -1,41 +1,116 @@
 package config
 
-import "os"
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
 
 type Config struct {
-	HTTPPort      string
-	SecureCookies bool
-	DatabaseUrl   string
-	Domain	      string
-	SMTPHost      string
-	SMTPPort      string
-	SMTPUser      string
-	SMTPPass      string
-	SMTPFrom      string
-	S3Endpoint    string
-	S3Bucket      string
-	S3AccessKey   string
-	S3SecretKey   string
-	S3UseSSL      bool
+	HTTPPort       string
+	SecureCookies  bool
+	DevMode        bool
+	DatabaseUrl    string
+	Domain         string
+	SMTPHost       string
+	SMTPPort       string
+	SMTPUser       string
+	SMTPPass       string
+	SMTPFrom       string
+	SMTPTLS        string
+	SMTPAuth       string
+	DKIMDomain     string
+	DKIMSelector   string
+	DKIMKeyFile    string
+	MailTransport  string
+	MailDir        string
+	Storage        string
+	StorageDir     string
+	StorageKey     string
+	EncryptionKeys string
+	EncryptKeyFile string
+	ExamMaxBytes   int64
+	UploadsPerUser int
+	QuotaUser      int64
+	QuotaEditor    int64
+	QuotaAdmin     int64
+	QuotaTotal     int64
+	OrphanGrace    int
+	DeleteOrphans  bool
+	Scanner        string
+	ClamdAddr      string
+	S3Endpoint     string
+	S3Bucket       string
+	S3AccessKey    string
+	S3SecretKey    string
+	S3UseSSL       bool
+	Argon2Memory   uint32
+	Argon2Time     uint32
+	Argon2Threads  uint8
+	SignupsVerify  bool
+	SignupsDomains []string
+	ReverifyRemind int
 }
 
-func New() *Config {
-	return &Config{
-		HTTPPort:      getEnv("HTTP_PORT", "80"),
-		SecureCookies: getEnv("SECURE_COOKIES", "true") == "true",
-		DatabaseUrl:	 getEnv("DATABASE_URL", "file:/data/sqlite.db?_journal_mode=WAL&_foreign_keys=on&_recursive_triggers=off&_busy_timeout=5000"),
-		Domain:        getEnv("DOMAIN", "http://localhost:5173"),
-		SMTPHost:      getEnv("SMTP_HOST", ""),
-		SMTPPort:      getEnv("SMTP_PORT", ""),
-		SMTPUser:      getEnv("SMTP_USERNAME", ""),
-		SMTPPass:      getEnv("SMTP_PASSWORD", ""),
-		SMTPFrom:      getEnv("SMTP_FROM", ""),
-		S3Endpoint:    getEnv("S3_ENDPOINT", ""),
-		S3Bucket:      getEnv("S3_BUCKET", ""),
-		S3AccessKey:   getEnv("S3_ACCESS_KEY", ""),
-		S3SecretKey:   getEnv("S3_SECRET_KEY", ""),
-		S3UseSSL:      getEnv("S3_USE_SSL", "false") == "true",
+// New reads the configuration from the environment. It fails if a value
+// that would otherwise break the server at runtime is out of range.
+func New() (*Config, error) {
+	// argon2.IDKey panics with zero threads or passes, and a wrapped value
+	// would only surface on the first login.
+	argon2Memory, memoryErr := getEnvBounded("PASSWORD_ARGON2_MEMORY", 64*1024, 8, 4<<20)
+	argon2Time, timeErr := getEnvBounded("PASSWORD_ARGON2_TIME", 3, 1, 100)
+	argon2Threads, threadsErr := getEnvBounded("PASSWORD_ARGON2_THREADS", 4, 1, 255)
+	if err := errors.Join(memoryErr, timeErr, threadsErr); err != nil {
+		return nil, err
 	}
+
+	return &Config{
+		HTTPPort:       getEnv("HTTP_PORT", "80"),
+		SecureCookies:  getEnv("SECURE_COOKIES", "true") == "true",
+		DevMode:        getEnv("DEV_MODE", "false") == "true",
+		DatabaseUrl:    getEnv("DATABASE_URL", "file:/data/sqlite.db?_pragma=journal_mode(WAL)&_pragma=recursive_triggers(0)&_pragma=busy_timeout(5000)"),
+		Domain:         getEnv("DOMAIN", "http://localhost:5173"),
+		SMTPHost:       getEnv("SMTP_HOST", ""),
+		SMTPPort:       getEnv("SMTP_PORT", ""),
+		SMTPUser:       getEnv("SMTP_USERNAME", ""),
+		SMTPPass:       getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:       getEnv("SMTP_FROM", ""),
+		SMTPTLS:        getEnv("SMTP_TLS", "auto"),
+		SMTPAuth:       getEnv("SMTP_AUTH", "plain"),
+		DKIMDomain:     getEnv("DKIM_DOMAIN", ""),
+		DKIMSelector:   getEnv("DKIM_SELECTOR", ""),
+		DKIMKeyFile:    getEnv("DKIM_PRIVATE_KEY_FILE", ""),
+		MailTransport:  getEnv("MAIL_TRANSPORT", "smtp"),
+		MailDir:        getEnv("MAIL_DIR", "/data/mail"),
+		Storage:        getEnv("STORAGE_BACKEND", "s3"),
+		StorageDir:     getEnv("STORAGE_DIR", "/data/objects"),
+		StorageKey:     getEnv("STORAGE_SIGNING_KEY", ""),
+		EncryptionKeys: getEnv("STORAGE_ENCRYPTION_KEYS", ""),
+		EncryptKeyFile: getEnv("STORAGE_ENCRYPTION_KEY_FILE", ""),
+		ExamMaxBytes:   int64(getEnvInt("EXAM_MAX_BYTES", 50<<20)),
+		UploadsPerUser: getEnvInt("UPLOADS_PER_USER", 3),
+		QuotaUser:      int64(getEnvInt("STORAGE_QUOTA_USER_MIB", 500)) << 20,
+		QuotaEditor:    int64(getEnvInt("STORAGE_QUOTA_EDITOR_MIB", 2048)) << 20,
+		QuotaAdmin:     int64(getEnvInt("STORAGE_QUOTA_ADMIN_MIB", 4096)) << 20,
+		QuotaTotal:     int64(getEnvInt("STORAGE_QUOTA_TOTAL_MIB", 8192)) << 20,
+		OrphanGrace:    getEnvInt("STORAGE_ORPHAN_GRACE_HOURS", 24),
+		DeleteOrphans:  getEnv("STORAGE_DELETE_ORPHANS", "false") == "true",
+		Scanner:        getEnv("SCANNER", "none"),
+		ClamdAddr:      getEnv("CLAMD_ADDR", "clamav:3310"),
+		S3Endpoint:     getEnv("S3_ENDPOINT", ""),
+		S3Bucket:       getEnv("S3_BUCKET", ""),
+		S3AccessKey:    getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:    getEnv("S3_SECRET_KEY", ""),
+		S3UseSSL:       getEnv("S3_USE_SSL", "false") == "true",
+		Argon2Memory:   uint32(argon2Memory),
+		Argon2Time:     uint32(argon2Time),
+		Argon2Threads:  uint8(argon2Threads),
+		SignupsVerify:  getEnv("SIGNUPS_VERIFY", "true") == "true",
+		SignupsDomains: getEnvList("SIGNUPS_DOMAINS_WHITELIST"),
+		ReverifyRemind: getEnvInt("REVERIFY_REMIND_DAYS", 14),
+	}, nil
 }
 
 func getEnv(key, fallback string) string {
@@ -44,3 +119,37 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvInt(key string, fallback int) int {
+	if value, ok := os.LookupEnv(key); ok {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			return n
+		}
+	}
+	return fallback
+}
+
+// getEnvBounded parses an integer between min and max. Unlike getEnvInt, it
+// fails on an invalid value instead of falling back to the default.
+func getEnvBounded(key string, fallback, min, max int) (int, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("%s must be between %d and %d, got %q", key, min, max, value)
+	}
+	return n, nil
+}
+
+// getEnvList parses a comma separated list, ignoring empty entries.
+func getEnvList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(getEnv(key, ""), ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,151 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: api_tokens.sql
+
+package database
+
+import (
+	"context"
+)
+
+const createAPIToken = `-- name: CreateAPIToken :one
+INSERT INTO api_tokens (id, userid, name, tokenhash, scopes, expires_at)
+VALUES (
+  ?1, ?2, ?3,
+  ?4, ?5, ?6
+)
+RETURNING id, userid, name, tokenhash, scopes, created_at, last_used, expires_at
+`
+
+type CreateAPITokenParams struct {
+	ID        string `json:"id"`
+	Userid    string `json:"userid"`
+	Name      string `json:"name"`
+	Tokenhash string `json:"tokenhash"`
+	Scopes    string `json:"scopes"`
+	ExpiresAt Time   `json:"expires_at"`
+}
+
+func (q *Queries) CreateAPIToken(ctx context.Context, arg CreateAPITokenParams) (ApiToken, error) {
+	row := q.db.QueryRowContext(ctx, createAPIToken,
+		arg.ID,
+		arg.Userid,
+		arg.Name,
+		arg.Tokenhash,
+		arg.Scopes,
+		arg.ExpiresAt,
+	)
+	var i ApiToken
+	err := row.Scan(
+		&i.ID,
+		&i.Userid,
+		&i.Name,
+		&i.Tokenhash,
+		&i.Scopes,
+		&i.CreatedAt,
+		&i.LastUsed,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const deleteExpiredAPITokens = `-- name: DeleteExpiredAPITokens :exec
+DELETE FROM api_tokens
+WHERE expires_at < strftime('%Y-%m-%dT%H:%M:%fZ','now')
+`
+
+func (q *Queries) DeleteExpiredAPITokens(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteExpiredAPITokens)
+	return err
+}
+
+const deleteUserAPIToken = `-- name: DeleteUserAPIToken :execrows
+DELETE FROM api_tokens
+WHERE id = ?1
+  AND userid = ?2
+`
+
+type DeleteUserAPITokenParams struct {
+	ID     string `json:"id"`
+	Userid string `json:"userid"`
+}
+
+func (q *Queries) DeleteUserAPIToken(ctx context.Context, arg DeleteUserAPITokenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteUserAPIToken, arg.ID, arg.Userid)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getAPITokenByHash = `-- name: GetAPITokenByHash :one
+SELECT id, userid, name, tokenhash, scopes, created_at, last_used, expires_at FROM api_tokens
+WHERE tokenhash = ?1
+LIMIT 1
+`
+
+func (q *Queries) GetAPITokenByHash(ctx context.Context, tokenhash string) (ApiToken, error) {
+	row := q.db.QueryRowContext(ctx, getAPITokenByHash, tokenhash)
+	var i ApiToken
+	err := row.Scan(
+		&i.ID,
+		&i.Userid,
+		&i.Name,
+		&i.Tokenhash,
+		&i.Scopes,
+		&i.CreatedAt,
+		&i.LastUsed,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const listUserAPITokens = `-- name: ListUserAPITokens :many
+SELECT id, userid, name, tokenhash, scopes, created_at, last_used, expires_at FROM api_tokens
+WHERE userid = ?1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListUserAPITokens(ctx context.Context, userid string) ([]ApiToken, error) {
+	rows, err := q.db.QueryContext(ctx, listUserAPITokens, userid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApiToken
+	for rows.Next() {
+		var i ApiToken
+		if err := rows.Scan(
+			&i.ID,
+			&i.Userid,
+			&i.Name,
+			&i.Tokenhash,
+			&i.Scopes,
+			&i.CreatedAt,
+			&i.LastUsed,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const touchAPIToken = `-- name: TouchAPIToken :exec
+UPDATE api_tokens
+SET last_used = strftime('%Y-%m-%dT%H:%M:%fZ','now')
+WHERE id = ?1
+`
+
+func (q *Queries) TouchAPIToken(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, touchAPIToken, id)
+	return err
+}
@@ -0,0 +1,62 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: comments.sql
+
+package database
+
+import (
+	"context"
+)
+
+const listUserComments = `-- name: ListUserComments :many
+SELECT id, postid, userid, body, created_at, updated_at FROM comments
+WHERE userid = ?1
+ORDER BY created_at
+`
+
+func (q *Queries) ListUserComments(ctx context.Context, userid string) ([]Comment, error) {
+	rows, err := q.db.QueryContext(ctx, listUserComments, userid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Comment
+	for rows.Next() {
+		var i Comment
+		if err := rows.Scan(
+			&i.ID,
+			&i.Postid,
+			&i.Userid,
+			&i.Body,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reassignUserComments = `-- name: ReassignUserComments :exec
+UPDATE comments
+SET userid = ?1
+WHERE userid = ?2
+`
+
+type ReassignUserCommentsParams struct {
+	NewUserid string `json:"new_userid"`
+	Userid    string `json:"userid"`
+}
+
+func (q *Queries) ReassignUserComments(ctx context.Context, arg ReassignUserCommentsParams) error {
+	_, err := q.db.ExecContext(ctx, reassignUserComments, arg.NewUserid, arg.Userid)
+	return err
+}
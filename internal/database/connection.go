@@ -4,10 +4,82 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
 )
 
+// legacyParams maps the DSN parameters of mattn/go-sqlite3 to the pragmas
+// they set. modernc.org/sqlite ignores them and only applies
+// _pragma=name(value), so a DSN with _busy_timeout=5000 failed right away
+// when the database was locked.
+var legacyParams = map[string]string{
+	"_busy_timeout":       "busy_timeout",
+	"_timeout":            "busy_timeout",
+	"_journal_mode":       "journal_mode",
+	"_journal":            "journal_mode",
+	"_recursive_triggers": "recursive_triggers",
+	"_rt":                 "recursive_triggers",
+	"_synchronous":        "synchronous",
+	"_sync":               "synchronous",
+}
+
+// requiredPragmas are applied unless the DSN sets them. WithTx relies on
+// busy_timeout to wait for the write lock.
+var requiredPragmas = []string{"busy_timeout(5000)"}
+
+// normalizeDSN rewrites legacy parameters to _pragma parameters, replaces
+// the pragmas set by overrides and adds requiredPragmas.
+func normalizeDSN(dsn string, overrides ...string) (string, error) {
+	path, query, _ := strings.Cut(dsn, "?")
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return "", fmt.Errorf("invalid database URL: %w", err)
+	}
+
+	for param, pragma := range legacyParams {
+		for _, value := range params[param] {
+			params.Add("_pragma", fmt.Sprintf("%s(%s)", pragma, value))
+		}
+		params.Del(param)
+	}
+	for _, override := range overrides {
+		name, _, _ := strings.Cut(override, "(")
+		params["_pragma"] = slices.DeleteFunc(params["_pragma"], func(pragma string) bool {
+			return isPragma(pragma, name)
+		})
+		params.Add("_pragma", override)
+	}
+	for _, required := range requiredPragmas {
+		name, _, _ := strings.Cut(required, "(")
+		if !hasPragma(params["_pragma"], name) {
+			params.Add("_pragma", required)
+		}
+	}
+
+	return path + "?" + params.Encode(), nil
+}
+
+func hasPragma(pragmas []string, name string) bool {
+	return slices.ContainsFunc(pragmas, func(pragma string) bool {
+		return isPragma(pragma, name)
+	})
+}
+
+func isPragma(pragma, name string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(pragma)), name)
+}
+
+func open(dsn string, overrides ...string) (*sql.DB, error) {
+	dsn, err := normalizeDSN(dsn, overrides...)
+	if err != nil {
+		return nil, err
+	}
+	return sql.Open("sqlite", dsn)
+}
+
 func NewConnection(dsn string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite", dsn)
+	db, err := open(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeDSN(t *testing.T) {
+	tests := []struct {
+		name      string
+		dsn       string
+		overrides []string
+		want      []string
+	}{
+		{"defaults", "file:test.db", nil, []string{"busy_timeout(5000)"}},
+		{"legacy params", "file:test.db?_busy_timeout=100&_journal_mode=WAL", nil, []string{"busy_timeout(100)", "journal_mode(WAL)"}},
+		{"pragmas are kept", "file:test.db?_pragma=foreign_keys(1)&_pragma=busy_timeout(0)", nil, []string{"foreign_keys(1)", "busy_timeout(0)"}},
+		{"overrides", "file:test.db?_pragma=foreign_keys(1)", []string{"foreign_keys(0)"}, []string{"foreign_keys(0)", "busy_timeout(5000)"}},
+	}
+	for _, tt := range tests {
+		dsn, err := normalizeDSN(tt.dsn, tt.overrides...)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		path, query, _ := strings.Cut(dsn, "?")
+		params, err := url.ParseQuery(query)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got := params["_pragma"]
+		slices.Sort(got)
+		want := slices.Sorted(slices.Values(tt.want))
+		if path != "file:test.db" || !slices.Equal(got, want) || len(params) != 1 {
+			t.Errorf("%s: normalizeDSN(%q) = %q, want the pragmas %v", tt.name, tt.dsn, dsn, want)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: email_outbox.sql
+
+package database
+
+import (
+	"context"
+	"database/sql"
+)
+
+const claimDueEmails = `-- name: ClaimDueEmails :many
+UPDATE email_outbox
+SET status = 'sending',
+    attempts = attempts + 1
+WHERE id IN (
+  SELECT id FROM email_outbox
+  WHERE status = 'pending'
+    AND next_attempt_at <= strftime('%Y-%m-%dT%H:%M:%fZ','now')
+  ORDER BY next_attempt_at
+  LIMIT ?1
+)
+RETURNING id, recipient, subject, body, status, attempts, last_error, next_attempt_at, created_at, sent_at, text_body, unsubscribe
+`
+
+func (q *Queries) ClaimDueEmails(ctx context.Context, limit int64) ([]EmailOutbox, error) {
+	rows, err := q.db.QueryContext(ctx, claimDueEmails, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EmailOutbox
+	for rows.Next() {
+		var i EmailOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.Recipient,
+			&i.Subject,
+			&i.Body,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+			&i.SentAt,
+			&i.TextBody,
+			&i.Unsubscribe,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteFinishedEmails = `-- name: DeleteFinishedEmails :exec
+DELETE FROM email_outbox
+WHERE (status = 'sent' AND sent_at < ?1)
+   OR (status = 'dead' AND created_at < ?1)
+`
+
+func (q *Queries) DeleteFinishedEmails(ctx context.Context, before NullTime) error {
+	_, err := q.db.ExecContext(ctx, deleteFinishedEmails, before)
+	return err
+}
+
+const enqueueEmail = `-- name: EnqueueEmail :one
+INSERT INTO email_outbox (id, recipient, subject, body, text_body, unsubscribe)
+VALUES (
+  ?1, ?2, ?3,
+  ?4, ?5, ?6
+)
+RETURNING id, recipient, subject, body, status, attempts, last_error, next_attempt_at, created_at, sent_at, text_body, unsubscribe
+`
+
+type EnqueueEmailParams struct {
+	ID          string `json:"id"`
+	Recipient   string `json:"recipient"`
+	Subject     string `json:"subject"`
+	Body        string `json:"body"`
+	TextBody    string `json:"text_body"`
+	Unsubscribe string `json:"unsubscribe"`
+}
+
+func (q *Queries) EnqueueEmail(ctx context.Context, arg EnqueueEmailParams) (EmailOutbox, error) {
+	row := q.db.QueryRowContext(ctx, enqueueEmail,
+		arg.ID,
+		arg.Recipient,
+		arg.Subject,
+		arg.Body,
+		arg.TextBody,
+		arg.Unsubscribe,
+	)
+	var i EmailOutbox
+	err := row.Scan(
+		&i.ID,
+		&i.Recipient,
+		&i.Subject,
+		&i.Body,
+		&i.Status,
+		&i.Attempts,
+		&i.LastError,
+		&i.NextAttemptAt,
+		&i.CreatedAt,
+		&i.SentAt,
+		&i.TextBody,
+		&i.Unsubscribe,
+	)
+	return i, err
+}
+
+const getEmail = `-- name: GetEmail :one
+SELECT id, recipient, subject, body, status, attempts, last_error, next_attempt_at, created_at, sent_at, text_body, unsubscribe FROM email_outbox
+WHERE id = ?1
+`
+
+func (q *Queries) GetEmail(ctx context.Context, id string) (EmailOutbox, error) {
+	row := q.db.QueryRowContext(ctx, getEmail, id)
+	var i EmailOutbox
+	err := row.Scan(
+		&i.ID,
+		&i.Recipient,
+		&i.Subject,
+		&i.Body,
+		&i.Status,
+		&i.Attempts,
+		&i.LastError,
+		&i.NextAttemptAt,
+		&i.CreatedAt,
+		&i.SentAt,
+		&i.TextBody,
+		&i.Unsubscribe,
+	)
+	return i, err
+}
+
+const listEmailOutbox = `-- name: ListEmailOutbox :many
+SELECT id, recipient, subject, body, status, attempts, last_error, next_attempt_at, created_at, sent_at, text_body, unsubscribe FROM email_outbox
+WHERE (status = ?1 OR ?1 IS NULL)
+ORDER BY created_at DESC
+LIMIT ?2
+`
+
+type ListEmailOutboxParams struct {
+	Status sql.NullString `json:"status"`
+	Limit  int64          `json:"limit"`
+}
+
+func (q *Queries) ListEmailOutbox(ctx context.Context, arg ListEmailOutboxParams) ([]EmailOutbox, error) {
+	rows, err := q.db.QueryContext(ctx, listEmailOutbox, arg.Status, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EmailOutbox
+	for rows.Next() {
+		var i EmailOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.Recipient,
+			&i.Subject,
+			&i.Body,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+			&i.SentAt,
+			&i.TextBody,
+			&i.Unsubscribe,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markEmailFailed = `-- name: MarkEmailFailed :exec
+UPDATE email_outbox
+SET status = ?1,
+    last_error = ?2,
+    next_attempt_at = ?3
+WHERE id = ?4
+`
+
+type MarkEmailFailedParams struct {
+	Status        string         `json:"status"`
+	LastError     sql.NullString `json:"last_error"`
+	NextAttemptAt Time           `json:"next_attempt_at"`
+	ID            string         `json:"id"`
+}
+
+func (q *Queries) MarkEmailFailed(ctx context.Context, arg MarkEmailFailedParams) error {
+	_, err := q.db.ExecContext(ctx, markEmailFailed,
+		arg.Status,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.ID,
+	)
+	return err
+}
+
+const markEmailSent = `-- name: MarkEmailSent :exec
+UPDATE email_outbox
+SET status = 'sent',
+    body = '',
+    text_body = '',
+    last_error = NULL,
+    sent_at = strftime('%Y-%m-%dT%H:%M:%fZ','now')
+WHERE id = ?1
+`
+
+func (q *Queries) MarkEmailSent(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, markEmailSent, id)
+	return err
+}
+
+const requeueEmail = `-- name: RequeueEmail :one
+UPDATE email_outbox
+SET status = 'pending',
+    attempts = 0,
+    next_attempt_at = strftime('%Y-%m-%dT%H:%M:%fZ','now')
+WHERE id = ?1
+  AND status IN ('pending','dead')
+RETURNING id, recipient, subject, body, status, attempts, last_error, next_attempt_at, created_at, sent_at, text_body, unsubscribe
+`
+
+func (q *Queries) RequeueEmail(ctx context.Context, id string) (EmailOutbox, error) {
+	row := q.db.QueryRowContext(ctx, requeueEmail, id)
+	var i EmailOutbox
+	err := row.Scan(
+		&i.ID,
+		&i.Recipient,
+		&i.Subject,
+		&i.Body,
+		&i.Status,
+		&i.Attempts,
+		&i.LastError,
+		&i.NextAttemptAt,
+		&i.CreatedAt,
+		&i.SentAt,
+		&i.TextBody,
+		&i.Unsubscribe,
+	)
+	return i, err
+}
+
+const resetSendingEmails = `-- name: ResetSendingEmails :exec
+UPDATE email_outbox
+SET status = 'pending'
+WHERE status = 'sending'
+`
+
+func (q *Queries) ResetSendingEmails(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, resetSendingEmails)
+	return err
+}
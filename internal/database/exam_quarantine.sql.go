@@ -0,0 +1,164 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: exam_quarantine.sql
+
+package database
+
+import (
+	"context"
+)
+
+const deleteQuarantinedExam = `-- name: DeleteQuarantinedExam :exec
+DELETE FROM exam_quarantine
+WHERE id = ?1
+`
+
+func (q *Queries) DeleteQuarantinedExam(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteQuarantinedExam, id)
+	return err
+}
+
+const getQuarantinedExam = `-- name: GetQuarantinedExam :one
+SELECT id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum, signature, quarantined_at FROM exam_quarantine
+WHERE id = ?1
+`
+
+func (q *Queries) GetQuarantinedExam(ctx context.Context, id string) (ExamQuarantine, error) {
+	row := q.db.QueryRowContext(ctx, getQuarantinedExam, id)
+	var i ExamQuarantine
+	err := row.Scan(
+		&i.ID,
+		&i.Userid,
+		&i.Programid,
+		&i.Version,
+		&i.ExamDate,
+		&i.Accesskey,
+		&i.MimeType,
+		&i.Nbytes,
+		&i.Checksum,
+		&i.Signature,
+		&i.QuarantinedAt,
+	)
+	return i, err
+}
+
+const listQuarantineObjects = `-- name: ListQuarantineObjects :many
+SELECT accesskey FROM exam_quarantine
+`
+
+func (q *Queries) ListQuarantineObjects(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listQuarantineObjects)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var accesskey string
+		if err := rows.Scan(&accesskey); err != nil {
+			return nil, err
+		}
+		items = append(items, accesskey)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQuarantinedExams = `-- name: ListQuarantinedExams :many
+SELECT id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum, signature, quarantined_at FROM exam_quarantine
+ORDER BY quarantined_at DESC
+`
+
+func (q *Queries) ListQuarantinedExams(ctx context.Context) ([]ExamQuarantine, error) {
+	rows, err := q.db.QueryContext(ctx, listQuarantinedExams)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ExamQuarantine
+	for rows.Next() {
+		var i ExamQuarantine
+		if err := rows.Scan(
+			&i.ID,
+			&i.Userid,
+			&i.Programid,
+			&i.Version,
+			&i.ExamDate,
+			&i.Accesskey,
+			&i.MimeType,
+			&i.Nbytes,
+			&i.Checksum,
+			&i.Signature,
+			&i.QuarantinedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const quarantineExamUpload = `-- name: QuarantineExamUpload :one
+INSERT INTO exam_quarantine (
+  id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum, signature
+) VALUES (
+  ?1, ?2, ?3, ?4, ?5,
+  ?6, ?7, ?8, ?9, ?10
+)
+RETURNING id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum, signature, quarantined_at
+`
+
+type QuarantineExamUploadParams struct {
+	ID        string `json:"id"`
+	Userid    string `json:"userid"`
+	Programid int64  `json:"programid"`
+	Version   string `json:"version"`
+	ExamDate  string `json:"exam_date"`
+	Accesskey string `json:"accesskey"`
+	MimeType  string `json:"mime_type"`
+	Nbytes    int64  `json:"nbytes"`
+	Checksum  string `json:"checksum"`
+	Signature string `json:"signature"`
+}
+
+func (q *Queries) QuarantineExamUpload(ctx context.Context, arg QuarantineExamUploadParams) (ExamQuarantine, error) {
+	row := q.db.QueryRowContext(ctx, quarantineExamUpload,
+		arg.ID,
+		arg.Userid,
+		arg.Programid,
+		arg.Version,
+		arg.ExamDate,
+		arg.Accesskey,
+		arg.MimeType,
+		arg.Nbytes,
+		arg.Checksum,
+		arg.Signature,
+	)
+	var i ExamQuarantine
+	err := row.Scan(
+		&i.ID,
+		&i.Userid,
+		&i.Programid,
+		&i.Version,
+		&i.ExamDate,
+		&i.Accesskey,
+		&i.MimeType,
+		&i.Nbytes,
+		&i.Checksum,
+		&i.Signature,
+		&i.QuarantinedAt,
+	)
+	return i, err
+}
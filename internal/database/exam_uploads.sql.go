@@ -0,0 +1,285 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: exam_uploads.sql
+
+package database
+
+import (
+	"context"
+)
+
+const countUserExamUploads = `-- name: CountUserExamUploads :one
+SELECT COUNT(*) FROM exam_uploads
+WHERE userid = ?1 AND expires_at >= ?2
+`
+
+type CountUserExamUploadsParams struct {
+	Userid string `json:"userid"`
+	Now    Time   `json:"now"`
+}
+
+func (q *Queries) CountUserExamUploads(ctx context.Context, arg CountUserExamUploadsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUserExamUploads, arg.Userid, arg.Now)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createExamUpload = `-- name: CreateExamUpload :one
+INSERT INTO exam_uploads (
+  id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum, expires_at
+) VALUES (
+  ?1, ?2, ?3, ?4, ?5,
+  ?6, ?7, ?8, ?9, ?10
+)
+RETURNING id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum, created_at, expires_at
+`
+
+type CreateExamUploadParams struct {
+	ID        string `json:"id"`
+	Userid    string `json:"userid"`
+	Programid int64  `json:"programid"`
+	Version   string `json:"version"`
+	ExamDate  string `json:"exam_date"`
+	Accesskey string `json:"accesskey"`
+	MimeType  string `json:"mime_type"`
+	Nbytes    int64  `json:"nbytes"`
+	Checksum  string `json:"checksum"`
+	ExpiresAt Time   `json:"expires_at"`
+}
+
+func (q *Queries) CreateExamUpload(ctx context.Context, arg CreateExamUploadParams) (ExamUpload, error) {
+	row := q.db.QueryRowContext(ctx, createExamUpload,
+		arg.ID,
+		arg.Userid,
+		arg.Programid,
+		arg.Version,
+		arg.ExamDate,
+		arg.Accesskey,
+		arg.MimeType,
+		arg.Nbytes,
+		arg.Checksum,
+		arg.ExpiresAt,
+	)
+	var i ExamUpload
+	err := row.Scan(
+		&i.ID,
+		&i.Userid,
+		&i.Programid,
+		&i.Version,
+		&i.ExamDate,
+		&i.Accesskey,
+		&i.MimeType,
+		&i.Nbytes,
+		&i.Checksum,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const deleteExamUpload = `-- name: DeleteExamUpload :exec
+DELETE FROM exam_uploads
+WHERE id = ?1
+`
+
+func (q *Queries) DeleteExamUpload(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteExamUpload, id)
+	return err
+}
+
+const deleteExamUploadChunks = `-- name: DeleteExamUploadChunks :exec
+DELETE FROM exam_upload_chunks
+WHERE uploadid = ?1
+`
+
+func (q *Queries) DeleteExamUploadChunks(ctx context.Context, uploadid string) error {
+	_, err := q.db.ExecContext(ctx, deleteExamUploadChunks, uploadid)
+	return err
+}
+
+const extendExamUpload = `-- name: ExtendExamUpload :exec
+UPDATE exam_uploads
+SET expires_at = ?1
+WHERE id = ?2
+`
+
+type ExtendExamUploadParams struct {
+	ExpiresAt Time   `json:"expires_at"`
+	ID        string `json:"id"`
+}
+
+func (q *Queries) ExtendExamUpload(ctx context.Context, arg ExtendExamUploadParams) error {
+	_, err := q.db.ExecContext(ctx, extendExamUpload, arg.ExpiresAt, arg.ID)
+	return err
+}
+
+const getExamUpload = `-- name: GetExamUpload :one
+SELECT id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum, created_at, expires_at FROM exam_uploads
+WHERE id = ?1 AND userid = ?2
+`
+
+type GetExamUploadParams struct {
+	ID     string `json:"id"`
+	Userid string `json:"userid"`
+}
+
+func (q *Queries) GetExamUpload(ctx context.Context, arg GetExamUploadParams) (ExamUpload, error) {
+	row := q.db.QueryRowContext(ctx, getExamUpload, arg.ID, arg.Userid)
+	var i ExamUpload
+	err := row.Scan(
+		&i.ID,
+		&i.Userid,
+		&i.Programid,
+		&i.Version,
+		&i.ExamDate,
+		&i.Accesskey,
+		&i.MimeType,
+		&i.Nbytes,
+		&i.Checksum,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const listExamUploadChunks = `-- name: ListExamUploadChunks :many
+SELECT uploadid, idx, nbytes, checksum, received_at FROM exam_upload_chunks
+WHERE uploadid = ?1
+ORDER BY idx
+`
+
+func (q *Queries) ListExamUploadChunks(ctx context.Context, uploadid string) ([]ExamUploadChunk, error) {
+	rows, err := q.db.QueryContext(ctx, listExamUploadChunks, uploadid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ExamUploadChunk
+	for rows.Next() {
+		var i ExamUploadChunk
+		if err := rows.Scan(
+			&i.Uploadid,
+			&i.Idx,
+			&i.Nbytes,
+			&i.Checksum,
+			&i.ReceivedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExamUploadObjects = `-- name: ListExamUploadObjects :many
+SELECT id, accesskey FROM exam_uploads
+`
+
+type ListExamUploadObjectsRow struct {
+	ID        string `json:"id"`
+	Accesskey string `json:"accesskey"`
+}
+
+func (q *Queries) ListExamUploadObjects(ctx context.Context) ([]ListExamUploadObjectsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listExamUploadObjects)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListExamUploadObjectsRow
+	for rows.Next() {
+		var i ListExamUploadObjectsRow
+		if err := rows.Scan(&i.ID, &i.Accesskey); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExpiredExamUploads = `-- name: ListExpiredExamUploads :many
+SELECT id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum, created_at, expires_at FROM exam_uploads
+WHERE expires_at < ?1
+ORDER BY expires_at
+LIMIT ?2
+`
+
+type ListExpiredExamUploadsParams struct {
+	Before Time  `json:"before"`
+	Limit  int64 `json:"limit"`
+}
+
+func (q *Queries) ListExpiredExamUploads(ctx context.Context, arg ListExpiredExamUploadsParams) ([]ExamUpload, error) {
+	rows, err := q.db.QueryContext(ctx, listExpiredExamUploads, arg.Before, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ExamUpload
+	for rows.Next() {
+		var i ExamUpload
+		if err := rows.Scan(
+			&i.ID,
+			&i.Userid,
+			&i.Programid,
+			&i.Version,
+			&i.ExamDate,
+			&i.Accesskey,
+			&i.MimeType,
+			&i.Nbytes,
+			&i.Checksum,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertExamUploadChunk = `-- name: UpsertExamUploadChunk :exec
+INSERT INTO exam_upload_chunks (uploadid, idx, nbytes, checksum)
+VALUES (?1, ?2, ?3, ?4)
+ON CONFLICT (uploadid, idx) DO UPDATE
+SET nbytes = excluded.nbytes,
+    checksum = excluded.checksum,
+    received_at = strftime('%Y-%m-%dT%H:%M:%fZ','now')
+`
+
+type UpsertExamUploadChunkParams struct {
+	Uploadid string `json:"uploadid"`
+	Idx      int64  `json:"idx"`
+	Nbytes   int64  `json:"nbytes"`
+	Checksum string `json:"checksum"`
+}
+
+func (q *Queries) UpsertExamUploadChunk(ctx context.Context, arg UpsertExamUploadChunkParams) error {
+	_, err := q.db.ExecContext(ctx, upsertExamUploadChunk,
+		arg.Uploadid,
+		arg.Idx,
+		arg.Nbytes,
+		arg.Checksum,
+	)
+	return err
+}
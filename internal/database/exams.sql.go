@@ -0,0 +1,202 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: exams.sql
+
+package database
+
+import (
+	"context"
+)
+
+const clearExamMissing = `-- name: ClearExamMissing :exec
+UPDATE exams
+SET missing_since = NULL
+WHERE id = ?1
+`
+
+func (q *Queries) ClearExamMissing(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, clearExamMissing, id)
+	return err
+}
+
+const createExam = `-- name: CreateExam :one
+INSERT INTO exams (
+  id, userid, programid, version, exam_date, accesskey, mime_type, nbytes, checksum
+) VALUES (
+  ?1, ?2, ?3, ?4, ?5,
+  ?6, ?7, ?8, ?9
+)
+RETURNING id, userid, programid, version, exam_date, uploaded_at, accesskey, mime_type, nbytes, checksum, missing_since
+`
+
+type CreateExamParams struct {
+	ID        string `json:"id"`
+	Userid    string `json:"userid"`
+	Programid int64  `json:"programid"`
+	Version   string `json:"version"`
+	ExamDate  string `json:"exam_date"`
+	Accesskey string `json:"accesskey"`
+	MimeType  string `json:"mime_type"`
+	Nbytes    int64  `json:"nbytes"`
+	Checksum  string `json:"checksum"`
+}
+
+func (q *Queries) CreateExam(ctx context.Context, arg CreateExamParams) (Exam, error) {
+	row := q.db.QueryRowContext(ctx, createExam,
+		arg.ID,
+		arg.Userid,
+		arg.Programid,
+		arg.Version,
+		arg.ExamDate,
+		arg.Accesskey,
+		arg.MimeType,
+		arg.Nbytes,
+		arg.Checksum,
+	)
+	var i Exam
+	err := row.Scan(
+		&i.ID,
+		&i.Userid,
+		&i.Programid,
+		&i.Version,
+		&i.ExamDate,
+		&i.UploadedAt,
+		&i.Accesskey,
+		&i.MimeType,
+		&i.Nbytes,
+		&i.Checksum,
+		&i.MissingSince,
+	)
+	return i, err
+}
+
+const getExam = `-- name: GetExam :one
+SELECT id, userid, programid, version, exam_date, uploaded_at, accesskey, mime_type, nbytes, checksum, missing_since FROM exams
+WHERE id = ?1
+`
+
+func (q *Queries) GetExam(ctx context.Context, id string) (Exam, error) {
+	row := q.db.QueryRowContext(ctx, getExam, id)
+	var i Exam
+	err := row.Scan(
+		&i.ID,
+		&i.Userid,
+		&i.Programid,
+		&i.Version,
+		&i.ExamDate,
+		&i.UploadedAt,
+		&i.Accesskey,
+		&i.MimeType,
+		&i.Nbytes,
+		&i.Checksum,
+		&i.MissingSince,
+	)
+	return i, err
+}
+
+const listExamObjects = `-- name: ListExamObjects :many
+SELECT id, accesskey, missing_since FROM exams
+`
+
+type ListExamObjectsRow struct {
+	ID           string   `json:"id"`
+	Accesskey    string   `json:"accesskey"`
+	MissingSince NullTime `json:"missing_since"`
+}
+
+func (q *Queries) ListExamObjects(ctx context.Context) ([]ListExamObjectsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listExamObjects)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListExamObjectsRow
+	for rows.Next() {
+		var i ListExamObjectsRow
+		if err := rows.Scan(&i.ID, &i.Accesskey, &i.MissingSince); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserExams = `-- name: ListUserExams :many
+SELECT id, userid, programid, version, exam_date, uploaded_at, accesskey, mime_type, nbytes, checksum, missing_since FROM exams
+WHERE userid = ?1
+ORDER BY uploaded_at
+`
+
+func (q *Queries) ListUserExams(ctx context.Context, userid string) ([]Exam, error) {
+	rows, err := q.db.QueryContext(ctx, listUserExams, userid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Exam
+	for rows.Next() {
+		var i Exam
+		if err := rows.Scan(
+			&i.ID,
+			&i.Userid,
+			&i.Programid,
+			&i.Version,
+			&i.ExamDate,
+			&i.UploadedAt,
+			&i.Accesskey,
+			&i.MimeType,
+			&i.Nbytes,
+			&i.Checksum,
+			&i.MissingSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markExamMissing = `-- name: MarkExamMissing :exec
+UPDATE exams
+SET missing_since = COALESCE(missing_since, ?1)
+WHERE id = ?2
+`
+
+type MarkExamMissingParams struct {
+	Now NullTime `json:"now"`
+	ID  string   `json:"id"`
+}
+
+func (q *Queries) MarkExamMissing(ctx context.Context, arg MarkExamMissingParams) error {
+	_, err := q.db.ExecContext(ctx, markExamMissing, arg.Now, arg.ID)
+	return err
+}
+
+const reassignUserExams = `-- name: ReassignUserExams :exec
+UPDATE exams
+SET userid = ?1
+WHERE userid = ?2
+`
+
+type ReassignUserExamsParams struct {
+	NewUserid string `json:"new_userid"`
+	Userid    string `json:"userid"`
+}
+
+func (q *Queries) ReassignUserExams(ctx context.Context, arg ReassignUserExamsParams) error {
+	_, err := q.db.ExecContext(ctx, reassignUserExams, arg.NewUserid, arg.Userid)
+	return err
+}
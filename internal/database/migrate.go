@@ -1,33 +1,164 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 
+	"github.com/fachschaftinformatik/web/database/migrations"
 	"github.com/pressly/goose/v3"
 	_ "modernc.org/sqlite"
 )
 
-func Migrate(dsn string, logger *log.Logger) error {
-	logger.Println("Running database migrations...")
-	db, err := sql.Open("sqlite", dsn)
+// ErrSchemaTooNew is returned when the database has migrations applied that
+// this binary does not know, e.g. after a rollback to an older release.
+var ErrSchemaTooNew = errors.New("database schema is newer than this binary")
+
+// migrationPragmas replace the pragmas of the DSN for migrations. Rebuilding
+// a table drops it, which with foreign keys on deletes the rows referencing
+// it.
+var migrationPragmas = []string{"foreign_keys(0)"}
+
+// Migrator runs the migrations embedded in the binary.
+type Migrator struct {
+	db       *sql.DB
+	provider *goose.Provider
+	logger   *log.Logger
+}
+
+func NewMigrator(dsn string, logger *log.Logger) (*Migrator, error) {
+	db, err := open(dsn, migrationPragmas...)
 	if err != nil {
-		return fmt.Errorf("goose: failed to open DB: %w", err)
+		return nil, fmt.Errorf("goose: failed to open DB: %w", err)
 	}
-	defer db.Close()
 
 	if err := db.Ping(); err != nil {
-		return fmt.Errorf("goose: failed to ping DB: %w", err)
+		db.Close()
+		return nil, fmt.Errorf("goose: failed to ping DB: %w", err)
+	}
+
+	provider, err := goose.NewProvider(goose.DialectSQLite3, db, migrations.FS)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("goose: failed to create provider: %w", err)
+	}
+
+	return &Migrator{db: db, provider: provider, logger: logger}, nil
+}
+
+func (m *Migrator) Close() error {
+	return m.db.Close()
+}
+
+// Check returns ErrSchemaTooNew if the database is at a version above the
+// newest embedded migration.
+func (m *Migrator) Check(ctx context.Context) error {
+	current, err := m.provider.GetDBVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("goose: failed to get version: %w", err)
+	}
+	if latest := m.latest(); current > latest {
+		return fmt.Errorf("%w (database at version %d, binary at %d)", ErrSchemaTooNew, current, latest)
+	}
+	return nil
+}
+
+func (m *Migrator) latest() int64 {
+	sources := m.provider.ListSources()
+	if len(sources) == 0 {
+		return 0
+	}
+	return sources[len(sources)-1].Version
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	if err := m.Check(ctx); err != nil {
+		return err
+	}
+	results, err := m.provider.Up(ctx)
+	m.logResults(results...)
+	if err != nil {
+		return fmt.Errorf("goose: up failed: %w", err)
 	}
+	return m.Version(ctx)
+}
 
-	if err := goose.SetDialect("sqlite3"); err != nil {
-		return fmt.Errorf("goose: failed to set dialect: %w", err)
+// Down rolls back the most recently applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	if err := m.Check(ctx); err != nil {
+		return err
+	}
+	result, err := m.provider.Down(ctx)
+	if err != nil {
+		return fmt.Errorf("goose: down failed: %w", err)
 	}
+	m.logResults(result)
+	return m.Version(ctx)
+}
 
-	if err := goose.Up(db, "database/migrations"); err != nil {
+// Redo rolls back the most recently applied migration and applies it again.
+func (m *Migrator) Redo(ctx context.Context) error {
+	if err := m.Down(ctx); err != nil {
+		return err
+	}
+	result, err := m.provider.UpByOne(ctx)
+	if err != nil {
 		return fmt.Errorf("goose: up failed: %w", err)
 	}
+	m.logResults(result)
+	return m.Version(ctx)
+}
+
+// Status logs every migration with whether and when it was applied.
+func (m *Migrator) Status(ctx context.Context) error {
+	statuses, err := m.provider.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("goose: status failed: %w", err)
+	}
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.State == goose.StateApplied {
+			appliedAt = status.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+		}
+		m.logger.Printf("%-19s %s", appliedAt, status.Source.Path)
+	}
+	return m.Check(ctx)
+}
+
+// Version logs the version of the database.
+func (m *Migrator) Version(ctx context.Context) error {
+	current, err := m.provider.GetDBVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("goose: failed to get version: %w", err)
+	}
+	m.logger.Printf("Database is at version %d, the newest migration is %d.", current, m.latest())
+	return nil
+}
+
+func (m *Migrator) logResults(results ...*goose.MigrationResult) {
+	for _, result := range results {
+		if result != nil {
+			m.logger.Println(result)
+		}
+	}
+}
+
+// Migrate applies all pending migrations. It fails without changing the
+// database if its schema is newer than the binary.
+func Migrate(dsn string, logger *log.Logger) error {
+	logger.Println("Running database migrations...")
+	m, err := NewMigrator(dsn, logger)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Up(context.Background()); err != nil {
+		return err
+	}
 
 	logger.Println("Migrations applied successfully.")
 	return nil
@@ -0,0 +1,36 @@
+package database
+
+import (
+	"context"
+	"io"
+	"log"
+	"path/filepath"
+	"testing"
+)
+
+func TestMigratorTurnsOffForeignKeys(t *testing.T) {
+	ctx := context.Background()
+	dsn := "file:" + filepath.Join(t.TempDir(), "test.db") + "?_pragma=foreign_keys(1)"
+	m, err := NewMigrator(dsn, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	// Every connection of the pool has to come up without foreign keys, so
+	// hold several at once.
+	for range 3 {
+		conn, err := m.db.Conn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		var enabled int
+		if err := conn.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&enabled); err != nil {
+			t.Fatal(err)
+		}
+		if enabled != 0 {
+			t.Errorf("PRAGMA foreign_keys = %d on a migrator connection, want 0", enabled)
+		}
+	}
+}
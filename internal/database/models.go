@@ -8,36 +8,99 @@ import (
 	"database/sql"
 )
 
+type ApiToken struct {
+	ID        string   `json:"id"`
+	Userid    string   `json:"userid"`
+	Name      string   `json:"name"`
+	Tokenhash string   `json:"tokenhash"`
+	Scopes    string   `json:"scopes"`
+	CreatedAt Time     `json:"created_at"`
+	LastUsed  NullTime `json:"last_used"`
+	ExpiresAt Time     `json:"expires_at"`
+}
+
 type Comment struct {
 	ID        string `json:"id"`
 	Postid    string `json:"postid"`
 	Userid    string `json:"userid"`
 	Body      string `json:"body"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	CreatedAt Time   `json:"created_at"`
+	UpdatedAt Time   `json:"updated_at"`
+}
+
+type EmailOutbox struct {
+	ID            string         `json:"id"`
+	Recipient     string         `json:"recipient"`
+	Subject       string         `json:"subject"`
+	Body          string         `json:"body"`
+	Status        string         `json:"status"`
+	Attempts      int64          `json:"attempts"`
+	LastError     sql.NullString `json:"last_error"`
+	NextAttemptAt Time           `json:"next_attempt_at"`
+	CreatedAt     Time           `json:"created_at"`
+	SentAt        NullTime       `json:"sent_at"`
+	TextBody      string         `json:"text_body"`
+	Unsubscribe   string         `json:"unsubscribe"`
 }
 
 type Exam struct {
-	ID         string `json:"id"`
-	Userid     string `json:"userid"`
-	Programid  int64  `json:"programid"`
-	Version    string `json:"version"`
-	ExamDate   string `json:"exam_date"`
-	UploadedAt string `json:"uploaded_at"`
-	Accesskey  string `json:"accesskey"`
-	MimeType   string `json:"mime_type"`
+	ID           string   `json:"id"`
+	Userid       string   `json:"userid"`
+	Programid    int64    `json:"programid"`
+	Version      string   `json:"version"`
+	ExamDate     string   `json:"exam_date"`
+	UploadedAt   Time     `json:"uploaded_at"`
+	Accesskey    string   `json:"accesskey"`
+	MimeType     string   `json:"mime_type"`
+	Nbytes       int64    `json:"nbytes"`
+	Checksum     string   `json:"checksum"`
+	MissingSince NullTime `json:"missing_since"`
+}
+
+type ExamQuarantine struct {
+	ID            string `json:"id"`
+	Userid        string `json:"userid"`
+	Programid     int64  `json:"programid"`
+	Version       string `json:"version"`
+	ExamDate      string `json:"exam_date"`
+	Accesskey     string `json:"accesskey"`
+	MimeType      string `json:"mime_type"`
+	Nbytes        int64  `json:"nbytes"`
+	Checksum      string `json:"checksum"`
+	Signature     string `json:"signature"`
+	QuarantinedAt Time   `json:"quarantined_at"`
+}
+
+type ExamUpload struct {
+	ID        string `json:"id"`
+	Userid    string `json:"userid"`
+	Programid int64  `json:"programid"`
+	Version   string `json:"version"`
+	ExamDate  string `json:"exam_date"`
+	Accesskey string `json:"accesskey"`
+	MimeType  string `json:"mime_type"`
+	Nbytes    int64  `json:"nbytes"`
+	Checksum  string `json:"checksum"`
+	CreatedAt Time   `json:"created_at"`
+	ExpiresAt Time   `json:"expires_at"`
+}
+
+type ExamUploadChunk struct {
+	Uploadid   string `json:"uploadid"`
+	Idx        int64  `json:"idx"`
 	Nbytes     int64  `json:"nbytes"`
 	Checksum   string `json:"checksum"`
+	ReceivedAt string `json:"received_at"`
 }
 
 type Post struct {
-	ID        string         `json:"id"`
-	Userid    string         `json:"userid"`
-	Title     string         `json:"title"`
-	Body      string         `json:"body"`
-	CreatedAt string         `json:"created_at"`
-	UpdatedAt string         `json:"updated_at"`
-	Deleted   sql.NullString `json:"deleted"`
+	ID        string   `json:"id"`
+	Userid    string   `json:"userid"`
+	Title     string   `json:"title"`
+	Body      string   `json:"body"`
+	CreatedAt Time     `json:"created_at"`
+	UpdatedAt Time     `json:"updated_at"`
+	Deleted   NullTime `json:"deleted"`
 }
 
 type Program struct {
@@ -54,9 +117,13 @@ type ProgramVersion struct {
 type Session struct {
 	ID        string `json:"id"`
 	Userid    string `json:"userid"`
-	CreatedAt string `json:"created_at"`
-	LastSeen  string `json:"last_seen"`
-	ExpiresAt string `json:"expires_at"`
+	CreatedAt Time   `json:"created_at"`
+	LastSeen  Time   `json:"last_seen"`
+	ExpiresAt Time   `json:"expires_at"`
+	Publicid  string `json:"publicid"`
+	UserAgent string `json:"user_agent"`
+	Ip        string `json:"ip"`
+	Device    string `json:"device"`
 }
 
 type User struct {
@@ -67,10 +134,28 @@ type User struct {
 	Role              string         `json:"role"`
 	Active            int64          `json:"active"`
 	Verified          int64          `json:"verified"`
-	VerifiedAt        sql.NullString `json:"verified_at"`
-	VerifiedUntil     sql.NullString `json:"verified_until"`
+	VerifiedAt        NullTime       `json:"verified_at"`
+	VerifiedUntil     NullTime       `json:"verified_until"`
 	Programid         int64          `json:"programid"`
-	CreatedAt         string         `json:"created_at"`
-	UpdatedAt         string         `json:"updated_at"`
+	CreatedAt         Time           `json:"created_at"`
+	UpdatedAt         Time           `json:"updated_at"`
 	VerificationToken sql.NullString `json:"verification_token"`
+	Locale            string         `json:"locale"`
+	Reminders         int64          `json:"reminders"`
+	UnsubscribeToken  sql.NullString `json:"unsubscribe_token"`
+}
+
+type VerificationPolicy struct {
+	Domain       string        `json:"domain"`
+	Kind         string        `json:"kind"`
+	Boundaries   string        `json:"boundaries"`
+	DurationDays sql.NullInt64 `json:"duration_days"`
+	CreatedAt    Time          `json:"created_at"`
+	UpdatedAt    Time          `json:"updated_at"`
+}
+
+type VerificationReminder struct {
+	Userid        string `json:"userid"`
+	VerifiedUntil Time   `json:"verified_until"`
+	SentAt        Time   `json:"sent_at"`
 }
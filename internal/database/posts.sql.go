@@ -0,0 +1,63 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: posts.sql
+
+package database
+
+import (
+	"context"
+)
+
+const listUserPosts = `-- name: ListUserPosts :many
+SELECT id, userid, title, body, created_at, updated_at, deleted FROM posts
+WHERE userid = ?1
+ORDER BY created_at
+`
+
+func (q *Queries) ListUserPosts(ctx context.Context, userid string) ([]Post, error) {
+	rows, err := q.db.QueryContext(ctx, listUserPosts, userid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Post
+	for rows.Next() {
+		var i Post
+		if err := rows.Scan(
+			&i.ID,
+			&i.Userid,
+			&i.Title,
+			&i.Body,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Deleted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reassignUserPosts = `-- name: ReassignUserPosts :exec
+UPDATE posts
+SET userid = ?1
+WHERE userid = ?2
+`
+
+type ReassignUserPostsParams struct {
+	NewUserid string `json:"new_userid"`
+	Userid    string `json:"userid"`
+}
+
+func (q *Queries) ReassignUserPosts(ctx context.Context, arg ReassignUserPostsParams) error {
+	_, err := q.db.ExecContext(ctx, reassignUserPosts, arg.NewUserid, arg.Userid)
+	return err
+}
@@ -10,26 +10,90 @@ import (
 )
 
 type Querier interface {
+	ClaimDueEmails(ctx context.Context, limit int64) ([]EmailOutbox, error)
+	ClaimVerificationReminder(ctx context.Context, arg ClaimVerificationReminderParams) (int64, error)
+	ClearExamMissing(ctx context.Context, id string) error
+	CountActiveAdmins(ctx context.Context) (int64, error)
+	CountUserExamUploads(ctx context.Context, arg CountUserExamUploadsParams) (int64, error)
+	CountUsers(ctx context.Context, arg CountUsersParams) (int64, error)
+	CreateAPIToken(ctx context.Context, arg CreateAPITokenParams) (ApiToken, error)
+	CreateExam(ctx context.Context, arg CreateExamParams) (Exam, error)
+	CreateExamUpload(ctx context.Context, arg CreateExamUploadParams) (ExamUpload, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DeleteExamUpload(ctx context.Context, id string) error
+	DeleteExamUploadChunks(ctx context.Context, uploadid string) error
+	DeleteExpiredAPITokens(ctx context.Context) error
 	DeleteExpiredSessions(ctx context.Context) error
+	DeleteFinishedEmails(ctx context.Context, before NullTime) error
+	DeleteOtherUserSessions(ctx context.Context, arg DeleteOtherUserSessionsParams) error
+	DeletePastVerificationReminders(ctx context.Context) error
+	DeleteQuarantinedExam(ctx context.Context, id string) error
 	DeleteSession(ctx context.Context, id string) error
+	DeleteUser(ctx context.Context, id string) error
+	DeleteUserAPIToken(ctx context.Context, arg DeleteUserAPITokenParams) (int64, error)
+	DeleteUserSession(ctx context.Context, arg DeleteUserSessionParams) (int64, error)
 	DeleteUserSessions(ctx context.Context, userid string) error
+	DeleteVerificationPolicy(ctx context.Context, domain string) (int64, error)
+	EnqueueEmail(ctx context.Context, arg EnqueueEmailParams) (EmailOutbox, error)
+	ExtendExamUpload(ctx context.Context, arg ExtendExamUploadParams) error
+	GetAPITokenByHash(ctx context.Context, tokenhash string) (ApiToken, error)
+	GetEmail(ctx context.Context, id string) (EmailOutbox, error)
+	GetExam(ctx context.Context, id string) (Exam, error)
+	GetExamUpload(ctx context.Context, arg GetExamUploadParams) (ExamUpload, error)
 	GetProgramWithVersions(ctx context.Context, id int64) ([]GetProgramWithVersionsRow, error)
+	GetQuarantinedExam(ctx context.Context, id string) (ExamQuarantine, error)
 	GetSession(ctx context.Context, id string) (Session, error)
+	GetTotalStorageUsage(ctx context.Context, now Time) (GetTotalStorageUsageRow, error)
 	GetUser(ctx context.Context, id string) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByVerificationToken(ctx context.Context, verificationToken sql.NullString) (User, error)
+	// Pending uploads reserve their announced size until they are finalized or
+	// expire.
+	GetUserStorageUsage(ctx context.Context, arg GetUserStorageUsageParams) (GetUserStorageUsageRow, error)
+	ListEmailOutbox(ctx context.Context, arg ListEmailOutboxParams) ([]EmailOutbox, error)
+	ListExamObjects(ctx context.Context) ([]ListExamObjectsRow, error)
+	ListExamUploadChunks(ctx context.Context, uploadid string) ([]ExamUploadChunk, error)
+	ListExamUploadObjects(ctx context.Context) ([]ListExamUploadObjectsRow, error)
+	ListExpiredExamUploads(ctx context.Context, arg ListExpiredExamUploadsParams) ([]ExamUpload, error)
 	ListProgramsWithVersions(ctx context.Context) ([]ListProgramsWithVersionsRow, error)
-	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	ListQuarantineObjects(ctx context.Context) ([]string, error)
+	ListQuarantinedExams(ctx context.Context) ([]ExamQuarantine, error)
+	ListStorageUsageByType(ctx context.Context) ([]ListStorageUsageByTypeRow, error)
+	ListTopStorageUsers(ctx context.Context, limit int64) ([]ListTopStorageUsersRow, error)
+	ListUserAPITokens(ctx context.Context, userid string) ([]ApiToken, error)
+	ListUserComments(ctx context.Context, userid string) ([]Comment, error)
+	ListUserExams(ctx context.Context, userid string) ([]Exam, error)
+	ListUserPosts(ctx context.Context, userid string) ([]Post, error)
+	ListUserSessions(ctx context.Context, userid string) ([]Session, error)
+	ListUsersDueForReminder(ctx context.Context, remindBefore NullTime) ([]User, error)
+	ListVerificationPolicies(ctx context.Context) ([]VerificationPolicy, error)
+	MarkEmailFailed(ctx context.Context, arg MarkEmailFailedParams) error
+	MarkEmailSent(ctx context.Context, id string) error
+	MarkExamMissing(ctx context.Context, arg MarkExamMissingParams) error
+	QuarantineExamUpload(ctx context.Context, arg QuarantineExamUploadParams) (ExamQuarantine, error)
+	ReassignUserComments(ctx context.Context, arg ReassignUserCommentsParams) error
+	ReassignUserExams(ctx context.Context, arg ReassignUserExamsParams) error
+	ReassignUserPosts(ctx context.Context, arg ReassignUserPostsParams) error
+	RequeueEmail(ctx context.Context, id string) (EmailOutbox, error)
+	ResetSendingEmails(ctx context.Context) error
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error)
 	SetUserActive(ctx context.Context, arg SetUserActiveParams) (User, error)
 	SetUserRole(ctx context.Context, arg SetUserRoleParams) (User, error)
 	SlideSession(ctx context.Context, arg SlideSessionParams) (Session, error)
-	SweepExpiredVerifications(ctx context.Context) error
+	SweepExpiredVerifications(ctx context.Context) ([]string, error)
+	TouchAPIToken(ctx context.Context, id string) error
 	TouchSession(ctx context.Context, id string) (Session, error)
+	UnsubscribeUser(ctx context.Context, unsubscribeToken sql.NullString) (int64, error)
 	UnverifyUser(ctx context.Context, id string) (User, error)
+	UpdateUserLocale(ctx context.Context, arg UpdateUserLocaleParams) (User, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	UpdateUserReminders(ctx context.Context, arg UpdateUserRemindersParams) (User, error)
 	UpdateUserToken(ctx context.Context, arg UpdateUserTokenParams) error
+	UpdateUserUnsubscribeToken(ctx context.Context, arg UpdateUserUnsubscribeTokenParams) error
 	UpdateUserVerificationWindow(ctx context.Context, arg UpdateUserVerificationWindowParams) (User, error)
+	UpsertExamUploadChunk(ctx context.Context, arg UpsertExamUploadChunkParams) error
+	UpsertVerificationPolicy(ctx context.Context, arg UpsertVerificationPolicyParams) (VerificationPolicy, error)
 	VerifyUser(ctx context.Context, arg VerifyUserParams) (User, error)
 }
 
@@ -10,19 +10,38 @@ import (
 )
 
 const createSession = `-- name: CreateSession :one
-INSERT INTO sessions (id, userid, expires_at)
-VALUES (?1, ?2, ?3)
-RETURNING id, userid, created_at, last_seen, expires_at
+INSERT INTO sessions (
+  id, publicid, userid, user_agent, ip, device, created_at, last_seen, expires_at
+) VALUES (
+  ?1, ?2, ?3,
+  ?4, ?5, ?6,
+  strftime('%Y-%m-%dT%H:%M:%fZ','now'),
+  strftime('%Y-%m-%dT%H:%M:%fZ','now'),
+  ?7
+)
+RETURNING id, userid, created_at, last_seen, expires_at, publicid, user_agent, ip, device
 `
 
 type CreateSessionParams struct {
 	ID        string `json:"id"`
+	Publicid  string `json:"publicid"`
 	Userid    string `json:"userid"`
-	ExpiresAt string `json:"expires_at"`
+	UserAgent string `json:"user_agent"`
+	Ip        string `json:"ip"`
+	Device    string `json:"device"`
+	ExpiresAt Time   `json:"expires_at"`
 }
 
 func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
-	row := q.db.QueryRowContext(ctx, createSession, arg.ID, arg.Userid, arg.ExpiresAt)
+	row := q.db.QueryRowContext(ctx, createSession,
+		arg.ID,
+		arg.Publicid,
+		arg.Userid,
+		arg.UserAgent,
+		arg.Ip,
+		arg.Device,
+		arg.ExpiresAt,
+	)
 	var i Session
 	err := row.Scan(
 		&i.ID,
@@ -30,6 +49,10 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		&i.CreatedAt,
 		&i.LastSeen,
 		&i.ExpiresAt,
+		&i.Publicid,
+		&i.UserAgent,
+		&i.Ip,
+		&i.Device,
 	)
 	return i, err
 }
@@ -44,6 +67,22 @@ func (q *Queries) DeleteExpiredSessions(ctx context.Context) error {
 	return err
 }
 
+const deleteOtherUserSessions = `-- name: DeleteOtherUserSessions :exec
+DELETE FROM sessions
+WHERE userid = ?1
+  AND id != ?2
+`
+
+type DeleteOtherUserSessionsParams struct {
+	Userid string `json:"userid"`
+	ID     string `json:"id"`
+}
+
+func (q *Queries) DeleteOtherUserSessions(ctx context.Context, arg DeleteOtherUserSessionsParams) error {
+	_, err := q.db.ExecContext(ctx, deleteOtherUserSessions, arg.Userid, arg.ID)
+	return err
+}
+
 const deleteSession = `-- name: DeleteSession :exec
 DELETE FROM sessions WHERE id = ?1
 `
@@ -53,6 +92,25 @@ func (q *Queries) DeleteSession(ctx context.Context, id string) error {
 	return err
 }
 
+const deleteUserSession = `-- name: DeleteUserSession :execrows
+DELETE FROM sessions
+WHERE publicid = ?1
+  AND userid = ?2
+`
+
+type DeleteUserSessionParams struct {
+	Publicid string `json:"publicid"`
+	Userid   string `json:"userid"`
+}
+
+func (q *Queries) DeleteUserSession(ctx context.Context, arg DeleteUserSessionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteUserSession, arg.Publicid, arg.Userid)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteUserSessions = `-- name: DeleteUserSessions :exec
 DELETE FROM sessions WHERE userid = ?1
 `
@@ -63,7 +121,7 @@ func (q *Queries) DeleteUserSessions(ctx context.Context, userid string) error {
 }
 
 const getSession = `-- name: GetSession :one
-SELECT id, userid, created_at, last_seen, expires_at FROM sessions
+SELECT id, userid, created_at, last_seen, expires_at, publicid, user_agent, ip, device FROM sessions
 WHERE id = ?1
 LIMIT 1
 `
@@ -77,20 +135,64 @@ func (q *Queries) GetSession(ctx context.Context, id string) (Session, error) {
 		&i.CreatedAt,
 		&i.LastSeen,
 		&i.ExpiresAt,
+		&i.Publicid,
+		&i.UserAgent,
+		&i.Ip,
+		&i.Device,
 	)
 	return i, err
 }
 
+const listUserSessions = `-- name: ListUserSessions :many
+SELECT id, userid, created_at, last_seen, expires_at, publicid, user_agent, ip, device FROM sessions
+WHERE userid = ?1
+  AND expires_at >= strftime('%Y-%m-%dT%H:%M:%fZ','now')
+ORDER BY last_seen DESC
+`
+
+func (q *Queries) ListUserSessions(ctx context.Context, userid string) ([]Session, error) {
+	rows, err := q.db.QueryContext(ctx, listUserSessions, userid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Session
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.Userid,
+			&i.CreatedAt,
+			&i.LastSeen,
+			&i.ExpiresAt,
+			&i.Publicid,
+			&i.UserAgent,
+			&i.Ip,
+			&i.Device,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const slideSession = `-- name: SlideSession :one
 UPDATE sessions
 SET last_seen = strftime('%Y-%m-%dT%H:%M:%fZ','now'),
     expires_at = ?1
 WHERE id = ?2
-RETURNING id, userid, created_at, last_seen, expires_at
+RETURNING id, userid, created_at, last_seen, expires_at, publicid, user_agent, ip, device
 `
 
 type SlideSessionParams struct {
-	ExpiresAt string `json:"expires_at"`
+	ExpiresAt Time   `json:"expires_at"`
 	ID        string `json:"id"`
 }
 
@@ -103,6 +205,10 @@ func (q *Queries) SlideSession(ctx context.Context, arg SlideSessionParams) (Ses
 		&i.CreatedAt,
 		&i.LastSeen,
 		&i.ExpiresAt,
+		&i.Publicid,
+		&i.UserAgent,
+		&i.Ip,
+		&i.Device,
 	)
 	return i, err
 }
@@ -111,7 +217,7 @@ const touchSession = `-- name: TouchSession :one
 UPDATE sessions
 SET last_seen = strftime('%Y-%m-%dT%H:%M:%fZ','now')
 WHERE id = ?1
-RETURNING id, userid, created_at, last_seen, expires_at
+RETURNING id, userid, created_at, last_seen, expires_at, publicid, user_agent, ip, device
 `
 
 func (q *Queries) TouchSession(ctx context.Context, id string) (Session, error) {
@@ -123,6 +229,10 @@ func (q *Queries) TouchSession(ctx context.Context, id string) (Session, error)
 		&i.CreatedAt,
 		&i.LastSeen,
 		&i.ExpiresAt,
+		&i.Publicid,
+		&i.UserAgent,
+		&i.Ip,
+		&i.Device,
 	)
 	return i, err
 }
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+const (
+	txAttempts = 5
+	txBackoff  = 25 * time.Millisecond
+)
+
+// Store runs queries like Querier and groups them into transactions.
+type Store interface {
+	Querier
+	// WithTx runs fn in a write transaction, which is committed if fn returns
+	// nil and rolled back otherwise. If the database is busy, fn is run
+	// again, so it must not have effects other than through q.
+	WithTx(ctx context.Context, fn func(q Querier) error) error
+}
+
+type sqlStore struct {
+	*Queries
+	db *sql.DB
+}
+
+func NewStore(db *sql.DB) Store {
+	return &sqlStore{Queries: New(db), db: db}
+}
+
+func (s *sqlStore) WithTx(ctx context.Context, fn func(q Querier) error) error {
+	backoff := txBackoff
+	for attempt := 1; ; attempt++ {
+		err := s.runTx(ctx, fn)
+		if !isBusy(err) || attempt == txAttempts {
+			return err
+		}
+		select {
+		case <-time.After(backoff + rand.N(backoff)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+func (s *sqlStore) runTx(ctx context.Context, fn func(q Querier) error) (err error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// database/sql only starts deferred transactions, which take the write
+	// lock at their first write. If another connection holds it by then,
+	// SQLite fails right away instead of waiting for busy_timeout, because
+	// the transaction may already have read data that is about to change.
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			rollback(conn)
+			panic(p)
+		}
+	}()
+
+	if err := fn(New(conn)); err != nil {
+		rollback(conn)
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		rollback(conn)
+		return err
+	}
+	return nil
+}
+
+// rollback ends the transaction on conn. If that fails, the connection is
+// discarded, so that it does not return to the pool with the transaction
+// still open.
+func rollback(conn *sql.Conn) {
+	if _, err := conn.ExecContext(context.Background(), "ROLLBACK"); err != nil {
+		conn.Raw(func(any) error { return driver.ErrBadConn })
+	}
+}
+
+func isBusy(err error) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code()&0xff == sqlite3.SQLITE_BUSY
+}
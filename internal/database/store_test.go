@@ -0,0 +1,144 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestStore returns a store on a fresh database and the path of the
+// database file. The store does not wait for the write lock, so that busy
+// errors reach WithTx right away.
+func newTestStore(t *testing.T) (Store, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.db")
+	if err := Migrate("file:"+path, log.New(io.Discard, "", 0)); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	db, err := NewConnection("file:" + path + "?_pragma=busy_timeout(0)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewStore(db), path
+}
+
+// lockDatabase takes the write lock of the database at path from another
+// connection and returns a function releasing it.
+func lockDatabase(t *testing.T, path string) func() {
+	t.Helper()
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		t.Fatal(err)
+	}
+	return func() {
+		if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+			t.Error(err)
+		}
+		conn.Close()
+	}
+}
+
+func insertPolicy(ctx context.Context, q Querier, domain string) error {
+	_, err := q.UpsertVerificationPolicy(ctx, UpsertVerificationPolicyParams{
+		Domain: domain,
+		Kind:   "never",
+	})
+	return err
+}
+
+func hasPolicy(t *testing.T, q Querier, domain string) bool {
+	t.Helper()
+	policies, err := q.ListVerificationPolicies(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, policy := range policies {
+		if policy.Domain == domain {
+			return true
+		}
+	}
+	return false
+}
+
+func TestWithTxRetriesWhileBusy(t *testing.T) {
+	ctx := context.Background()
+	store, path := newTestStore(t)
+
+	unlock := lockDatabase(t, path)
+	released := make(chan struct{})
+	go func() {
+		time.Sleep(2 * txBackoff)
+		unlock()
+		close(released)
+	}()
+
+	calls := 0
+	err := store.WithTx(ctx, func(q Querier) error {
+		calls++
+		return insertPolicy(ctx, q, "example.org")
+	})
+	<-released
+	if err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("fn ran %d times, want once", calls)
+	}
+	if !hasPolicy(t, store, "example.org") {
+		t.Error("the transaction was not committed")
+	}
+}
+
+func TestWithTxGivesUp(t *testing.T) {
+	ctx := context.Background()
+	store, path := newTestStore(t)
+
+	unlock := lockDatabase(t, path)
+	defer unlock()
+
+	err := store.WithTx(ctx, func(q Querier) error {
+		t.Error("fn ran without the write lock")
+		return nil
+	})
+	if !isBusy(err) {
+		t.Errorf("WithTx error = %v, want SQLITE_BUSY", err)
+	}
+}
+
+func TestWithTxRollsBack(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestStore(t)
+
+	errAbort := errors.New("abort")
+	err := store.WithTx(ctx, func(q Querier) error {
+		if err := insertPolicy(ctx, q, "example.org"); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("WithTx error = %v, want %v", err, errAbort)
+	}
+	if hasPolicy(t, store, "example.org") {
+		t.Error("the transaction was not rolled back")
+	}
+	// The connection went back to the pool without an open transaction.
+	if err := store.WithTx(ctx, func(q Querier) error { return insertPolicy(ctx, q, "example.com") }); err != nil {
+		t.Errorf("WithTx after rollback: %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// TimeFormat is the format of timestamps in the database. It matches
+// strftime('%Y-%m-%dT%H:%M:%fZ', ...), so that timestamps written by queries
+// and by the server compare correctly as text.
+const TimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// Time is a timestamp column. It is always written in UTC with TimeFormat,
+// sqlc uses it for the columns with a CHECK on the format.
+type Time struct {
+	time.Time
+}
+
+func NewTime(t time.Time) Time {
+	return Time{Time: t}
+}
+
+func (t Time) Value() (driver.Value, error) {
+	return t.UTC().Format(TimeFormat), nil
+}
+
+func (t *Time) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case time.Time:
+		t.Time = v
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into database.Time", src)
+	}
+	parsed, err := ParseTime(s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+func (t Time) String() string {
+	return t.UTC().Format(TimeFormat)
+}
+
+// ParseTime parses a timestamp written by the database or by the server.
+func ParseTime(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q: %w", s, err)
+	}
+	return t, nil
+}
+
+// NullTime is a nullable timestamp column, written like Time.
+type NullTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+func NewNullTime(t time.Time) NullTime {
+	return NullTime{Time: t, Valid: true}
+}
+
+func (t NullTime) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return Time{Time: t.Time}.Value()
+}
+
+func (t *NullTime) Scan(src any) error {
+	if src == nil {
+		*t = NullTime{}
+		return nil
+	}
+	var v Time
+	if err := v.Scan(src); err != nil {
+		return err
+	}
+	*t = NullTime{Time: v.Time, Valid: true}
+	return nil
+}
+
+// String formats t like Time, or returns "" if it is NULL.
+func (t NullTime) String() string {
+	if !t.Valid {
+		return ""
+	}
+	return Time{Time: t.Time}.String()
+}
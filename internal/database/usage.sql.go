@@ -0,0 +1,142 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: usage.sql
+
+package database
+
+import (
+	"context"
+)
+
+const getTotalStorageUsage = `-- name: GetTotalStorageUsage :one
+SELECT
+  CAST((SELECT COALESCE(SUM(nbytes), 0) FROM exams) AS INTEGER) AS stored_bytes,
+  CAST((SELECT COUNT(*) FROM exams) AS INTEGER) AS objects,
+  CAST((SELECT COALESCE(SUM(nbytes), 0) FROM exam_uploads
+        WHERE expires_at >= ?1) AS INTEGER) AS reserved_bytes
+`
+
+type GetTotalStorageUsageRow struct {
+	StoredBytes   int64 `json:"stored_bytes"`
+	Objects       int64 `json:"objects"`
+	ReservedBytes int64 `json:"reserved_bytes"`
+}
+
+func (q *Queries) GetTotalStorageUsage(ctx context.Context, now Time) (GetTotalStorageUsageRow, error) {
+	row := q.db.QueryRowContext(ctx, getTotalStorageUsage, now)
+	var i GetTotalStorageUsageRow
+	err := row.Scan(&i.StoredBytes, &i.Objects, &i.ReservedBytes)
+	return i, err
+}
+
+const getUserStorageUsage = `-- name: GetUserStorageUsage :one
+SELECT
+  CAST((SELECT COALESCE(SUM(nbytes), 0) FROM exams WHERE exams.userid = ?1) AS INTEGER) AS stored_bytes,
+  CAST((SELECT COALESCE(SUM(nbytes), 0) FROM exam_uploads
+        WHERE exam_uploads.userid = ?1 AND expires_at >= ?2) AS INTEGER) AS reserved_bytes
+`
+
+type GetUserStorageUsageParams struct {
+	Userid string `json:"userid"`
+	Now    Time   `json:"now"`
+}
+
+type GetUserStorageUsageRow struct {
+	StoredBytes   int64 `json:"stored_bytes"`
+	ReservedBytes int64 `json:"reserved_bytes"`
+}
+
+// Pending uploads reserve their announced size until they are finalized or
+// expire.
+func (q *Queries) GetUserStorageUsage(ctx context.Context, arg GetUserStorageUsageParams) (GetUserStorageUsageRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserStorageUsage, arg.Userid, arg.Now)
+	var i GetUserStorageUsageRow
+	err := row.Scan(&i.StoredBytes, &i.ReservedBytes)
+	return i, err
+}
+
+const listStorageUsageByType = `-- name: ListStorageUsageByType :many
+SELECT mime_type, COUNT(*) AS objects, CAST(SUM(nbytes) AS INTEGER) AS nbytes
+FROM exams
+GROUP BY mime_type
+ORDER BY nbytes DESC
+`
+
+type ListStorageUsageByTypeRow struct {
+	MimeType string `json:"mime_type"`
+	Objects  int64  `json:"objects"`
+	Nbytes   int64  `json:"nbytes"`
+}
+
+func (q *Queries) ListStorageUsageByType(ctx context.Context) ([]ListStorageUsageByTypeRow, error) {
+	rows, err := q.db.QueryContext(ctx, listStorageUsageByType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListStorageUsageByTypeRow
+	for rows.Next() {
+		var i ListStorageUsageByTypeRow
+		if err := rows.Scan(&i.MimeType, &i.Objects, &i.Nbytes); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTopStorageUsers = `-- name: ListTopStorageUsers :many
+SELECT users.id, users.name, users.email, users.role,
+       COUNT(*) AS objects, CAST(SUM(exams.nbytes) AS INTEGER) AS nbytes
+FROM exams
+JOIN users ON users.id = exams.userid
+GROUP BY users.id
+ORDER BY nbytes DESC
+LIMIT ?1
+`
+
+type ListTopStorageUsersRow struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Role    string `json:"role"`
+	Objects int64  `json:"objects"`
+	Nbytes  int64  `json:"nbytes"`
+}
+
+func (q *Queries) ListTopStorageUsers(ctx context.Context, limit int64) ([]ListTopStorageUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTopStorageUsers, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTopStorageUsersRow
+	for rows.Next() {
+		var i ListTopStorageUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Role,
+			&i.Objects,
+			&i.Nbytes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
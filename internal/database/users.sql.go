@@ -10,18 +10,72 @@ import (
 	"database/sql"
 )
 
+const countActiveAdmins = `-- name: CountActiveAdmins :one
+SELECT COUNT(*)
+FROM users
+WHERE role = 'admin'
+  AND active = 1
+`
+
+func (q *Queries) CountActiveAdmins(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countActiveAdmins)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUsers = `-- name: CountUsers :one
+SELECT COUNT(*)
+FROM users
+WHERE (CAST(?1 AS TEXT) IS NULL
+       OR instr(lower(name), lower(?1)) > 0
+       OR instr(lower(email), lower(?1)) > 0)
+  AND (role = ?2 OR ?2 IS NULL)
+  AND (programid = ?3 OR ?3 IS NULL)
+  AND (verified = ?4 OR ?4 IS NULL)
+  AND (active = ?5 OR ?5 IS NULL)
+  AND (verified_until >= ?6 OR ?6 IS NULL)
+  AND (verified_until < ?7 OR ?7 IS NULL)
+`
+
+type CountUsersParams struct {
+	Query               sql.NullString `json:"query"`
+	Role                sql.NullString `json:"role"`
+	Programid           sql.NullInt64  `json:"programid"`
+	Verified            sql.NullInt64  `json:"verified"`
+	Active              sql.NullInt64  `json:"active"`
+	VerifiedUntilAfter  NullTime       `json:"verified_until_after"`
+	VerifiedUntilBefore NullTime       `json:"verified_until_before"`
+}
+
+func (q *Queries) CountUsers(ctx context.Context, arg CountUsersParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUsers,
+		arg.Query,
+		arg.Role,
+		arg.Programid,
+		arg.Verified,
+		arg.Active,
+		arg.VerifiedUntilAfter,
+		arg.VerifiedUntilBefore,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
-  id, email, name, password, role, active, verified, programid, verification_token
+  id, email, name, password, role, active, verified, programid, verification_token, locale
 ) VALUES (
   ?1, ?2, ?3, ?4,
   COALESCE(?5, 'user'),
   COALESCE(?6, 0),
   0,
   ?7,
-  ?8
+  ?8,
+  ?9
 )
-RETURNING id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token
+RETURNING id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token, locale, reminders, unsubscribe_token
 `
 
 type CreateUserParams struct {
@@ -33,6 +87,7 @@ type CreateUserParams struct {
 	Active            interface{}    `json:"active"`
 	Programid         int64          `json:"programid"`
 	VerificationToken sql.NullString `json:"verification_token"`
+	Locale            string         `json:"locale"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
@@ -45,6 +100,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		arg.Active,
 		arg.Programid,
 		arg.VerificationToken,
+		arg.Locale,
 	)
 	var i User
 	err := row.Scan(
@@ -61,12 +117,24 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.VerificationToken,
+		&i.Locale,
+		&i.Reminders,
+		&i.UnsubscribeToken,
 	)
 	return i, err
 }
 
+const deleteUser = `-- name: DeleteUser :exec
+DELETE FROM users WHERE id = ?1
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteUser, id)
+	return err
+}
+
 const getUser = `-- name: GetUser :one
-SELECT id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token
+SELECT id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token, locale, reminders, unsubscribe_token
 FROM users
 WHERE id = ?1
 LIMIT 1
@@ -89,12 +157,15 @@ func (q *Queries) GetUser(ctx context.Context, id string) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.VerificationToken,
+		&i.Locale,
+		&i.Reminders,
+		&i.UnsubscribeToken,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token
+SELECT id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token, locale, reminders, unsubscribe_token
 FROM users
 WHERE lower(email) = lower(?1)
 LIMIT 1
@@ -117,12 +188,15 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.VerificationToken,
+		&i.Locale,
+		&i.Reminders,
+		&i.UnsubscribeToken,
 	)
 	return i, err
 }
 
 const getUserByVerificationToken = `-- name: GetUserByVerificationToken :one
-SELECT id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token
+SELECT id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token, locale, reminders, unsubscribe_token
 FROM users
 WHERE verification_token = ?1
 LIMIT 1
@@ -145,24 +219,58 @@ func (q *Queries) GetUserByVerificationToken(ctx context.Context, verificationTo
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.VerificationToken,
+		&i.Locale,
+		&i.Reminders,
+		&i.UnsubscribeToken,
 	)
 	return i, err
 }
 
-const listUsers = `-- name: ListUsers :many
-SELECT id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token
+const searchUsers = `-- name: SearchUsers :many
+SELECT id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token, locale, reminders, unsubscribe_token
 FROM users
-ORDER BY created_at DESC
-LIMIT ?2 OFFSET ?1
+WHERE (CAST(?1 AS TEXT) IS NULL
+       OR instr(lower(name), lower(?1)) > 0
+       OR instr(lower(email), lower(?1)) > 0)
+  AND (role = ?2 OR ?2 IS NULL)
+  AND (programid = ?3 OR ?3 IS NULL)
+  AND (verified = ?4 OR ?4 IS NULL)
+  AND (active = ?5 OR ?5 IS NULL)
+  AND (verified_until >= ?6 OR ?6 IS NULL)
+  AND (verified_until < ?7 OR ?7 IS NULL)
+  AND (CAST(?8 AS TEXT) IS NULL
+       OR created_at < ?8
+       OR (created_at = ?8 AND id < ?9))
+ORDER BY created_at DESC, id DESC
+LIMIT ?10
 `
 
-type ListUsersParams struct {
-	Offset int64 `json:"offset"`
-	Limit  int64 `json:"limit"`
+type SearchUsersParams struct {
+	Query               sql.NullString `json:"query"`
+	Role                sql.NullString `json:"role"`
+	Programid           sql.NullInt64  `json:"programid"`
+	Verified            sql.NullInt64  `json:"verified"`
+	Active              sql.NullInt64  `json:"active"`
+	VerifiedUntilAfter  NullTime       `json:"verified_until_after"`
+	VerifiedUntilBefore NullTime       `json:"verified_until_before"`
+	AfterCreatedAt      sql.NullString `json:"after_created_at"`
+	AfterID             sql.NullString `json:"after_id"`
+	Limit               int64          `json:"limit"`
 }
 
-func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
-	rows, err := q.db.QueryContext(ctx, listUsers, arg.Offset, arg.Limit)
+func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, searchUsers,
+		arg.Query,
+		arg.Role,
+		arg.Programid,
+		arg.Verified,
+		arg.Active,
+		arg.VerifiedUntilAfter,
+		arg.VerifiedUntilBefore,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -184,6 +292,9 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.VerificationToken,
+			&i.Locale,
+			&i.Reminders,
+			&i.UnsubscribeToken,
 		); err != nil {
 			return nil, err
 		}
@@ -202,7 +313,7 @@ const setUserActive = `-- name: SetUserActive :one
 UPDATE users
 SET active = ?1
 WHERE id = ?2
-RETURNING id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token
+RETURNING id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token, locale, reminders, unsubscribe_token
 `
 
 type SetUserActiveParams struct {
@@ -227,6 +338,9 @@ func (q *Queries) SetUserActive(ctx context.Context, arg SetUserActiveParams) (U
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.VerificationToken,
+		&i.Locale,
+		&i.Reminders,
+		&i.UnsubscribeToken,
 	)
 	return i, err
 }
@@ -235,7 +349,7 @@ const setUserRole = `-- name: SetUserRole :one
 UPDATE users
 SET role = ?1
 WHERE id = ?2
-RETURNING id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token
+RETURNING id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token, locale, reminders, unsubscribe_token
 `
 
 type SetUserRoleParams struct {
@@ -260,28 +374,64 @@ func (q *Queries) SetUserRole(ctx context.Context, arg SetUserRoleParams) (User,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.VerificationToken,
+		&i.Locale,
+		&i.Reminders,
+		&i.UnsubscribeToken,
 	)
 	return i, err
 }
 
-const sweepExpiredVerifications = `-- name: SweepExpiredVerifications :exec
+const sweepExpiredVerifications = `-- name: SweepExpiredVerifications :many
 UPDATE users
 SET verified = 0
 WHERE verified = 1
   AND verified_until IS NOT NULL
   AND verified_until < strftime('%Y-%m-%dT%H:%M:%fZ','now')
+RETURNING id
 `
 
-func (q *Queries) SweepExpiredVerifications(ctx context.Context) error {
-	_, err := q.db.ExecContext(ctx, sweepExpiredVerifications)
-	return err
+func (q *Queries) SweepExpiredVerifications(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, sweepExpiredVerifications)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const unsubscribeUser = `-- name: UnsubscribeUser :execrows
+UPDATE users
+SET reminders = 0
+WHERE unsubscribe_token = ?1
+`
+
+func (q *Queries) UnsubscribeUser(ctx context.Context, unsubscribeToken sql.NullString) (int64, error) {
+	result, err := q.db.ExecContext(ctx, unsubscribeUser, unsubscribeToken)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const unverifyUser = `-- name: UnverifyUser :one
 UPDATE users
 SET verified = 0
 WHERE id = ?1
-RETURNING id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token
+RETURNING id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token, locale, reminders, unsubscribe_token
 `
 
 func (q *Queries) UnverifyUser(ctx context.Context, id string) (User, error) {
@@ -301,6 +451,97 @@ func (q *Queries) UnverifyUser(ctx context.Context, id string) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.VerificationToken,
+		&i.Locale,
+		&i.Reminders,
+		&i.UnsubscribeToken,
+	)
+	return i, err
+}
+
+const updateUserLocale = `-- name: UpdateUserLocale :one
+UPDATE users
+SET locale = ?1
+WHERE id = ?2
+RETURNING id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token, locale, reminders, unsubscribe_token
+`
+
+type UpdateUserLocaleParams struct {
+	Locale string `json:"locale"`
+	ID     string `json:"id"`
+}
+
+func (q *Queries) UpdateUserLocale(ctx context.Context, arg UpdateUserLocaleParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserLocale, arg.Locale, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.Password,
+		&i.Role,
+		&i.Active,
+		&i.Verified,
+		&i.VerifiedAt,
+		&i.VerifiedUntil,
+		&i.Programid,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VerificationToken,
+		&i.Locale,
+		&i.Reminders,
+		&i.UnsubscribeToken,
+	)
+	return i, err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users
+SET password = ?1
+WHERE id = ?2
+`
+
+type UpdateUserPasswordParams struct {
+	Password string `json:"password"`
+	ID       string `json:"id"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserPassword, arg.Password, arg.ID)
+	return err
+}
+
+const updateUserReminders = `-- name: UpdateUserReminders :one
+UPDATE users
+SET reminders = ?1
+WHERE id = ?2
+RETURNING id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token, locale, reminders, unsubscribe_token
+`
+
+type UpdateUserRemindersParams struct {
+	Reminders int64  `json:"reminders"`
+	ID        string `json:"id"`
+}
+
+func (q *Queries) UpdateUserReminders(ctx context.Context, arg UpdateUserRemindersParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserReminders, arg.Reminders, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.Password,
+		&i.Role,
+		&i.Active,
+		&i.Verified,
+		&i.VerifiedAt,
+		&i.VerifiedUntil,
+		&i.Programid,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VerificationToken,
+		&i.Locale,
+		&i.Reminders,
+		&i.UnsubscribeToken,
 	)
 	return i, err
 }
@@ -321,16 +562,32 @@ func (q *Queries) UpdateUserToken(ctx context.Context, arg UpdateUserTokenParams
 	return err
 }
 
+const updateUserUnsubscribeToken = `-- name: UpdateUserUnsubscribeToken :exec
+UPDATE users
+SET unsubscribe_token = ?1
+WHERE id = ?2
+`
+
+type UpdateUserUnsubscribeTokenParams struct {
+	UnsubscribeToken sql.NullString `json:"unsubscribe_token"`
+	ID               string         `json:"id"`
+}
+
+func (q *Queries) UpdateUserUnsubscribeToken(ctx context.Context, arg UpdateUserUnsubscribeTokenParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserUnsubscribeToken, arg.UnsubscribeToken, arg.ID)
+	return err
+}
+
 const updateUserVerificationWindow = `-- name: UpdateUserVerificationWindow :one
 UPDATE users
 SET verified_until = ?1
 WHERE id = ?2
-RETURNING id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token
+RETURNING id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token, locale, reminders, unsubscribe_token
 `
 
 type UpdateUserVerificationWindowParams struct {
-	VerifiedUntil sql.NullString `json:"verified_until"`
-	ID            string         `json:"id"`
+	VerifiedUntil NullTime `json:"verified_until"`
+	ID            string   `json:"id"`
 }
 
 func (q *Queries) UpdateUserVerificationWindow(ctx context.Context, arg UpdateUserVerificationWindowParams) (User, error) {
@@ -350,6 +607,9 @@ func (q *Queries) UpdateUserVerificationWindow(ctx context.Context, arg UpdateUs
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.VerificationToken,
+		&i.Locale,
+		&i.Reminders,
+		&i.UnsubscribeToken,
 	)
 	return i, err
 }
@@ -361,12 +621,12 @@ SET verified = 1,
     verified_until = ?1,
     verification_token = NULL
 WHERE id = ?2
-RETURNING id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token
+RETURNING id, email, name, password, role, active, verified, verified_at, verified_until, programid, created_at, updated_at, verification_token, locale, reminders, unsubscribe_token
 `
 
 type VerifyUserParams struct {
-	VerifiedUntil sql.NullString `json:"verified_until"`
-	ID            string         `json:"id"`
+	VerifiedUntil NullTime `json:"verified_until"`
+	ID            string   `json:"id"`
 }
 
 func (q *Queries) VerifyUser(ctx context.Context, arg VerifyUserParams) (User, error) {
@@ -386,6 +646,9 @@ func (q *Queries) VerifyUser(ctx context.Context, arg VerifyUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.VerificationToken,
+		&i.Locale,
+		&i.Reminders,
+		&i.UnsubscribeToken,
 	)
 	return i, err
 }
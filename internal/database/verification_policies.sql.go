@@ -0,0 +1,97 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: verification_policies.sql
+
+package database
+
+import (
+	"context"
+	"database/sql"
+)
+
+const deleteVerificationPolicy = `-- name: DeleteVerificationPolicy :execrows
+DELETE FROM verification_policies
+WHERE domain = ?1
+`
+
+func (q *Queries) DeleteVerificationPolicy(ctx context.Context, domain string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteVerificationPolicy, domain)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const listVerificationPolicies = `-- name: ListVerificationPolicies :many
+SELECT domain, kind, boundaries, duration_days, created_at, updated_at FROM verification_policies
+ORDER BY domain
+`
+
+func (q *Queries) ListVerificationPolicies(ctx context.Context) ([]VerificationPolicy, error) {
+	rows, err := q.db.QueryContext(ctx, listVerificationPolicies)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []VerificationPolicy
+	for rows.Next() {
+		var i VerificationPolicy
+		if err := rows.Scan(
+			&i.Domain,
+			&i.Kind,
+			&i.Boundaries,
+			&i.DurationDays,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertVerificationPolicy = `-- name: UpsertVerificationPolicy :one
+INSERT INTO verification_policies (domain, kind, boundaries, duration_days)
+VALUES (
+  ?1, ?2, ?3, ?4
+)
+ON CONFLICT (domain) DO UPDATE
+SET kind = excluded.kind,
+    boundaries = excluded.boundaries,
+    duration_days = excluded.duration_days
+RETURNING domain, kind, boundaries, duration_days, created_at, updated_at
+`
+
+type UpsertVerificationPolicyParams struct {
+	Domain       string        `json:"domain"`
+	Kind         string        `json:"kind"`
+	Boundaries   string        `json:"boundaries"`
+	DurationDays sql.NullInt64 `json:"duration_days"`
+}
+
+func (q *Queries) UpsertVerificationPolicy(ctx context.Context, arg UpsertVerificationPolicyParams) (VerificationPolicy, error) {
+	row := q.db.QueryRowContext(ctx, upsertVerificationPolicy,
+		arg.Domain,
+		arg.Kind,
+		arg.Boundaries,
+		arg.DurationDays,
+	)
+	var i VerificationPolicy
+	err := row.Scan(
+		&i.Domain,
+		&i.Kind,
+		&i.Boundaries,
+		&i.DurationDays,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
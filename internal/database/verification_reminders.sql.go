@@ -0,0 +1,97 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: verification_reminders.sql
+
+package database
+
+import (
+	"context"
+)
+
+const claimVerificationReminder = `-- name: ClaimVerificationReminder :execrows
+INSERT INTO verification_reminders (userid, verified_until)
+VALUES (?1, ?2)
+ON CONFLICT (userid, verified_until) DO NOTHING
+`
+
+type ClaimVerificationReminderParams struct {
+	Userid        string `json:"userid"`
+	VerifiedUntil Time   `json:"verified_until"`
+}
+
+func (q *Queries) ClaimVerificationReminder(ctx context.Context, arg ClaimVerificationReminderParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, claimVerificationReminder, arg.Userid, arg.VerifiedUntil)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deletePastVerificationReminders = `-- name: DeletePastVerificationReminders :exec
+DELETE FROM verification_reminders
+WHERE verified_until < strftime('%Y-%m-%dT%H:%M:%fZ','now')
+`
+
+func (q *Queries) DeletePastVerificationReminders(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deletePastVerificationReminders)
+	return err
+}
+
+const listUsersDueForReminder = `-- name: ListUsersDueForReminder :many
+SELECT u.id, u.email, u.name, u.password, u.role, u.active, u.verified, u.verified_at, u.verified_until, u.programid, u.created_at, u.updated_at, u.verification_token, u.locale, u.reminders, u.unsubscribe_token
+FROM users u
+WHERE u.verified = 1
+  AND u.active = 1
+  AND u.reminders = 1
+  AND u.verified_until IS NOT NULL
+  AND u.verified_until >= strftime('%Y-%m-%dT%H:%M:%fZ','now')
+  AND u.verified_until <= ?1
+  AND NOT EXISTS (
+    SELECT 1
+    FROM verification_reminders r
+    WHERE r.userid = u.id
+      AND r.verified_until = u.verified_until
+  )
+ORDER BY u.verified_until
+`
+
+func (q *Queries) ListUsersDueForReminder(ctx context.Context, remindBefore NullTime) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersDueForReminder, remindBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.Name,
+			&i.Password,
+			&i.Role,
+			&i.Active,
+			&i.Verified,
+			&i.VerifiedAt,
+			&i.VerifiedUntil,
+			&i.Programid,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.VerificationToken,
+			&i.Locale,
+			&i.Reminders,
+			&i.UnsubscribeToken,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
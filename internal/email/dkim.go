@@ -0,0 +1,110 @@
+package email
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"strings"
+
+	"github.com/emersion/go-msgauth/dkim"
+	"github.com/fachschaftinformatik/web/internal/config"
+)
+
+// dkimHeaders are the header fields covered by signatures, following the
+// recommendation of RFC 6376 section 5.4.1. RFC 8058 requires both
+// List-Unsubscribe fields to be signed for one-click unsubscribes.
+var dkimHeaders = []string{
+	"From", "To", "Subject", "Date", "Message-ID",
+	"MIME-Version", "Content-Type", "Content-Transfer-Encoding",
+	"List-Unsubscribe", "List-Unsubscribe-Post",
+}
+
+// newDKIMOptions loads the signing key configured in cfg. It returns nil if
+// DKIM signing is disabled, i.e. no selector is configured.
+func newDKIMOptions(cfg *config.Config) (*dkim.SignOptions, error) {
+	if cfg.DKIMSelector == "" {
+		return nil, nil
+	}
+	if cfg.DKIMKeyFile == "" {
+		return nil, errors.New("DKIM selector given without a key file")
+	}
+
+	domain := cfg.DKIMDomain
+	if domain == "" {
+		from, err := mail.ParseAddress(cfg.SMTPFrom)
+		if err != nil {
+			return nil, fmt.Errorf("cannot derive DKIM domain from sender address: %w", err)
+		}
+		_, domain, _ = strings.Cut(from.Address, "@")
+	}
+
+	pemBytes, err := os.ReadFile(cfg.DKIMKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read DKIM key: %w", err)
+	}
+	signer, err := parseDKIMKey(pemBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dkim.SignOptions{
+		Domain:                 domain,
+		Selector:               cfg.DKIMSelector,
+		Signer:                 signer,
+		Hash:                   crypto.SHA256,
+		HeaderCanonicalization: dkim.CanonicalizationRelaxed,
+		BodyCanonicalization:   dkim.CanonicalizationRelaxed,
+		HeaderKeys:             dkimHeaders,
+	}, nil
+}
+
+// parseDKIMKey accepts RSA keys in PKCS #1 or PKCS #8 form and Ed25519 keys
+// in PKCS #8 form, as written by openssl genpkey.
+func parseDKIMKey(pemBytes []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("DKIM key is not PEM encoded")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported DKIM key type %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DKIM key: %w", err)
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		// Receivers ignore signatures made with shorter keys.
+		if key.N.BitLen() < 1024 {
+			return nil, errors.New("DKIM RSA keys must have at least 1024 bits")
+		}
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported DKIM key algorithm %T", key)
+	}
+}
+
+func (s *Sender) sign(raw []byte) ([]byte, error) {
+	var signed bytes.Buffer
+	if err := dkim.Sign(&signed, bytes.NewReader(raw), s.dkim); err != nil {
+		return nil, fmt.Errorf("failed to sign email: %w", err)
+	}
+	return signed.Bytes(), nil
+}
@@ -0,0 +1,196 @@
+//go:debug rsa1024min=0
+
+package email
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emersion/go-msgauth/dkim"
+	"github.com/fachschaftinformatik/web/internal/config"
+	"github.com/fachschaftinformatik/web/internal/i18n"
+)
+
+const dkimSelector = "mail"
+
+// writeKey stores key as PEM in a temporary file and returns its path.
+func writeKey(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "dkim.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func pkcs8(t *testing.T, key any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+// dkimRecord returns the DNS TXT record publishing the public key of key.
+func dkimRecord(t *testing.T, key crypto.Signer) string {
+	t.Helper()
+	switch pub := key.Public().(type) {
+	case *rsa.PublicKey:
+		der, err := x509.MarshalPKIXPublicKey(pub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "v=DKIM1; k=rsa; p=" + base64.StdEncoding.EncodeToString(der)
+	case ed25519.PublicKey:
+		return "v=DKIM1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(pub)
+	default:
+		t.Fatalf("unexpected key type %T", pub)
+		return ""
+	}
+}
+
+// sendSigned sends a reminder through a sender signing with the key at
+// keyFile and returns the message as delivered.
+func sendSigned(t *testing.T, keyFile string) string {
+	t.Helper()
+	cfg := &config.Config{
+		Domain:       "https://fsv.example",
+		SMTPFrom:     "FSV Informatik <noreply@fsv.example>",
+		DKIMSelector: dkimSelector,
+		DKIMKeyFile:  keyFile,
+	}
+	transport := NewMemoryTransport()
+	s, err := NewSender(cfg, transport)
+	if err != nil {
+		t.Fatalf("NewSender: %v", err)
+	}
+
+	msg, err := s.ReverificationEmail(i18n.German, "erika@example.org", "Erika", "verify-token", "unsubscribe-token", time.Now())
+	if err != nil {
+		t.Fatalf("ReverificationEmail: %v", err)
+	}
+	if err := s.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	captured, _ := transport.Captured()
+	if len(captured) != 1 {
+		t.Fatalf("captured %d mails, want 1", len(captured))
+	}
+	return captured[0].Raw
+}
+
+func verifyDKIM(raw, record string) ([]*dkim.Verification, error) {
+	return dkim.VerifyWithOptions(strings.NewReader(raw), &dkim.VerifyOptions{
+		LookupTXT: func(domain string) ([]string, error) {
+			if domain != dkimSelector+"._domainkey.fsv.example" {
+				return nil, fmt.Errorf("unexpected lookup of %s", domain)
+			}
+			return []string{record}, nil
+		},
+	})
+}
+
+func TestDKIMRoundTrip(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		key       crypto.Signer
+		blockType string
+		der       []byte
+	}{
+		{"rsa pkcs1", rsaKey, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)},
+		{"rsa pkcs8", rsaKey, "PRIVATE KEY", pkcs8(t, rsaKey)},
+		{"ed25519", edKey, "PRIVATE KEY", pkcs8(t, edKey)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := sendSigned(t, writeKey(t, tt.blockType, tt.der))
+			record := dkimRecord(t, tt.key)
+
+			verifications, err := verifyDKIM(raw, record)
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if len(verifications) != 1 {
+				t.Fatalf("got %d signatures, want 1", len(verifications))
+			}
+			v := verifications[0]
+			if v.Err != nil {
+				t.Fatalf("signature invalid: %v", v.Err)
+			}
+			if v.Domain != "fsv.example" {
+				t.Errorf("signed for %q, want fsv.example", v.Domain)
+			}
+			for _, key := range []string{"from", "subject", "list-unsubscribe", "list-unsubscribe-post"} {
+				if !containsFold(v.HeaderKeys, key) {
+					t.Errorf("header %s is not signed, signed are %v", key, v.HeaderKeys)
+				}
+			}
+
+			// Changing the body after signing breaks the signature.
+			tampered := strings.Replace(raw, "Hallo Erika", "Hallo Mallory", 1)
+			if tampered == raw {
+				t.Fatal("body to tamper with not found")
+			}
+			verifications, err = verifyDKIM(tampered, record)
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if verifications[0].Err == nil {
+				t.Error("tampered message verified")
+			}
+		})
+	}
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestParseDKIMKey(t *testing.T) {
+	shortKey, err := rsa.GenerateKey(rand.Reader, 512)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		pem  []byte
+	}{
+		{"not pem", []byte("not a key")},
+		{"short rsa key", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(shortKey)})},
+		{"unsupported block", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte{0}})},
+		{"garbage der", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte{0}})},
+	}
+	for _, tt := range tests {
+		if _, err := parseDKIMKey(tt.pem); err == nil {
+			t.Errorf("%s: parseDKIMKey succeeded, want error", tt.name)
+		}
+	}
+}
@@ -2,32 +2,118 @@ package email
 
 import (
 	"bytes"
+	"context"
 	"embed"
+	"errors"
 	"fmt"
 	"html/template"
-	"net/smtp"
+	"io/fs"
+	"net/mail"
+	"time"
 
+	"github.com/emersion/go-msgauth/dkim"
 	"github.com/fachschaftinformatik/web/internal/config"
+	"github.com/fachschaftinformatik/web/internal/i18n"
 )
 
-//go:embed templates/*.html
+//go:embed templates
 var templateFS embed.FS
 
+// Templates are the names of the emails in templates/<locale>/. Each defines
+// the "title" and "content" blocks of templates/layout.html. The text part
+// of a message is generated from its HTML.
+var Templates = []string{"verification", "reverification"}
+
+// ErrUnknownTemplate is returned when previewing a template that does not
+// exist.
+var ErrUnknownTemplate = errors.New("unknown email template")
+
+// Message is a rendered email ready to be queued or sent.
+type Message struct {
+	// ID is the local part of the Message-ID, random if empty.
+	ID string
+	// Date defaults to the time the message is built.
+	Date    time.Time
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+	// Unsubscribe is the List-Unsubscribe URL of notification mails.
+	// Transactional mails such as verifications leave it empty.
+	Unsubscribe string
+}
+
+// templateKey identifies the translation of a template.
+type templateKey struct {
+	locale i18n.Locale
+	name   string
+}
+
 type Sender struct {
-	cfg *config.Config
-	tpl *template.Template
+	cfg       *config.Config
+	tpl       map[templateKey]*template.Template
+	transport Transport
+	dkim      *dkim.SignOptions
 }
 
-func NewSender(cfg *config.Config) *Sender {
-	tpl, err := template.ParseFS(templateFS, "templates/*.html")
+func NewSender(cfg *config.Config, transport Transport) (*Sender, error) {
+	s := &Sender{
+		cfg:       cfg,
+		tpl:       make(map[templateKey]*template.Template),
+		transport: transport,
+	}
+
+	for _, locale := range i18n.Supported {
+		funcs := map[string]any{
+			"t":      func(msg string) string { return i18n.T(locale, msg) },
+			"locale": func() string { return string(locale) },
+		}
+		for _, name := range Templates {
+			path := fmt.Sprintf("templates/%s/%s", locale, name)
+			// Missing translations fall back to the default locale.
+			if _, err := fs.Stat(templateFS, path+".html"); errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			key := templateKey{locale, name}
+			tpl, err := template.New("layout.html").Funcs(funcs).ParseFS(templateFS, "templates/layout.html", path+".html")
+			if err != nil {
+				panic(fmt.Errorf("failed to parse email templates: %w", err))
+			}
+			s.tpl[key] = tpl
+		}
+	}
+	for _, name := range Templates {
+		if _, ok := s.tpl[templateKey{i18n.Default, name}]; !ok {
+			panic(fmt.Errorf("email template %s has no %s translation", name, i18n.Default))
+		}
+	}
+
+	dkimOptions, err := newDKIMOptions(cfg)
 	if err != nil {
-		panic(fmt.Errorf("failed to parse email templates: %w", err))
+		return nil, err
 	}
+	s.dkim = dkimOptions
 
-	return &Sender{
-		cfg: cfg,
-		tpl: tpl,
+	return s, nil
+}
+
+// render executes the named template in locale, or in the default locale if
+// it has not been translated, and derives the text part from the result.
+func (s *Sender) render(locale i18n.Locale, name string, data any) (html, text string, err error) {
+	key := templateKey{locale, name}
+	if _, ok := s.tpl[key]; !ok {
+		key.locale = i18n.Default
+	}
+
+	var htmlBody bytes.Buffer
+	if err := s.tpl[key].Execute(&htmlBody, data); err != nil {
+		return "", "", fmt.Errorf("failed to execute email template: %w", err)
 	}
+	text, err = plainText(htmlBody.String())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to render text part: %w", err)
+	}
+	return htmlBody.String(), text, nil
 }
 
 type verificationData struct {
@@ -35,44 +121,107 @@ type verificationData struct {
 	VerifyLink string
 }
 
-func (s *Sender) SendVerificationEmail(toEmail, name, token string) error {
+// VerificationEmail renders the email asking a new user to confirm their
+// address.
+func (s *Sender) VerificationEmail(locale i18n.Locale, toEmail, name, token string) (Message, error) {
 	link := fmt.Sprintf("%s/api/auth/verify?token=%s", s.cfg.Domain, token)
-	
+
 	data := verificationData{
 		Name:       name,
 		VerifyLink: link,
 	}
 
-	var body bytes.Buffer
-	if err := s.tpl.ExecuteTemplate(&body, "verification.html", data); err != nil {
-		return fmt.Errorf("failed to execute email template: %w", err)
+	html, text, err := s.render(locale, "verification", data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	subject := i18n.T(locale, "Please confirm your email address")
+	return Message{To: toEmail, Subject: subject, HTML: html, Text: text}, nil
+}
+
+type reverificationData struct {
+	Name       string
+	VerifyLink string
+	Until      string
+}
+
+// ReverificationEmail renders the reminder that a user's verification ends
+// at until, linking to a fresh verification token. Reminders are notification
+// mails, unsubscribeToken stops further ones.
+func (s *Sender) ReverificationEmail(locale i18n.Locale, toEmail, name, token, unsubscribeToken string, until time.Time) (Message, error) {
+	link := fmt.Sprintf("%s/api/auth/verify?token=%s", s.cfg.Domain, token)
+
+	data := reverificationData{
+		Name:       name,
+		VerifyLink: link,
+		Until:      i18n.FormatDate(locale, until),
+	}
+
+	html, text, err := s.render(locale, "reverification", data)
+	if err != nil {
+		return Message{}, err
 	}
 
-	subject := "Bitte bestätige deine E-Mail-Adresse"
-	
-	headers := make(map[string]string)
-	headers["From"] = s.cfg.SMTPFrom
-	headers["To"] = toEmail
-	headers["Subject"] = subject
-	headers["MIME-Version"] = "1.0"
-	headers["Content-Type"] = "text/html; charset=\"UTF-8\""
+	subject := i18n.T(locale, "Please confirm your account again")
+	return Message{
+		To:          toEmail,
+		Subject:     subject,
+		HTML:        html,
+		Text:        text,
+		Unsubscribe: fmt.Sprintf("%s/api/auth/unsubscribe?token=%s", s.cfg.Domain, unsubscribeToken),
+	}, nil
+}
 
-	message := ""
-	for k, v := range headers {
-		message += fmt.Sprintf("%s: %s\r\n", k, v)
+// Preview renders the named template in locale with sample data, so editors
+// can check their wording.
+func (s *Sender) Preview(locale i18n.Locale, name string) (Message, error) {
+	const to = "erika.mustermann@example.org"
+	const sampleName = "Erika Mustermann"
+	switch name {
+	case "verification":
+		return s.VerificationEmail(locale, to, sampleName, "preview")
+	case "reverification":
+		return s.ReverificationEmail(locale, to, sampleName, "preview", "preview", time.Now().AddDate(0, 0, s.cfg.ReverifyRemind))
+	default:
+		return Message{}, ErrUnknownTemplate
 	}
-	message += "\r\n" + body.String()
+}
 
-	addr := fmt.Sprintf("%s:%s", s.cfg.SMTPHost, s.cfg.SMTPPort)
-	
-	var auth smtp.Auth
-	if s.cfg.SMTPUser != "" {
-		auth = smtp.PlainAuth("", s.cfg.SMTPUser, s.cfg.SMTPPass, s.cfg.SMTPHost)
+// Send delivers msg through the configured transport.
+func (s *Sender) Send(ctx context.Context, msg Message) error {
+	raw, err := s.Build(msg)
+	if err != nil {
+		return err
+	}
+	if s.dkim != nil {
+		if raw, err = s.sign(raw); err != nil {
+			return err
+		}
 	}
 
-	if err := smtp.SendMail(addr, auth, s.cfg.SMTPFrom, []string{toEmail}, []byte(message)); err != nil {
+	from, err := mail.ParseAddress(s.cfg.SMTPFrom)
+	if err != nil {
+		return fmt.Errorf("invalid sender address %q: %w", s.cfg.SMTPFrom, err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address %q: %w", msg.To, err)
+	}
+
+	if err := s.transport.Send(ctx, from.Address, []string{to.Address}, raw); err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 
 	return nil
 }
+
+// Captured returns the messages kept by the transport, or ErrNotRecorded if
+// it does not keep any.
+func (s *Sender) Captured() ([]CapturedMail, error) {
+	recorder, ok := s.transport.(Recorder)
+	if !ok {
+		return nil, ErrNotRecorded
+	}
+	return recorder.Captured()
+}
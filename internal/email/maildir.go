@@ -0,0 +1,104 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaildirTransport delivers every message into a local Maildir, which mail
+// clients such as mutt can open directly.
+type MaildirTransport struct {
+	Dir string
+}
+
+func NewMaildirTransport(dir string) (*MaildirTransport, error) {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create maildir: %w", err)
+		}
+	}
+	return &MaildirTransport{Dir: dir}, nil
+}
+
+func (t *MaildirTransport) Send(ctx context.Context, from string, to []string, msg []byte) error {
+	suffix := make([]byte, 8)
+	rand.Read(suffix)
+	hostname, _ := os.Hostname()
+	name := fmt.Sprintf("%d.%s.%s", time.Now().UnixNano(), hex.EncodeToString(suffix), strings.ReplaceAll(hostname, "/", "_"))
+
+	// The envelope is kept the way delivery agents record it.
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Return-Path: <%s>\r\n", from)
+	for _, rcpt := range to {
+		fmt.Fprintf(&buf, "Delivered-To: %s\r\n", rcpt)
+	}
+	buf.Write(msg)
+
+	// Messages only appear in new/ once completely written.
+	tmp := filepath.Join(t.Dir, "tmp", name)
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(t.Dir, "new", name))
+}
+
+// Captured returns the messages in new/ and cur/, newest first.
+func (t *MaildirTransport) Captured() ([]CapturedMail, error) {
+	var captured []CapturedMail
+	for _, sub := range []string{"new", "cur"} {
+		entries, err := os.ReadDir(filepath.Join(t.Dir, sub))
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			raw, err := os.ReadFile(filepath.Join(t.Dir, sub, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return nil, err
+			}
+
+			var from string
+			var to []string
+			if msg, err := mail.ReadMessage(bytes.NewReader(raw)); err == nil {
+				from = strings.Trim(msg.Header.Get("Return-Path"), "<>")
+				to = msg.Header["Delivered-To"]
+			}
+			captured = append(captured, captureMail(entry.Name(), from, to, info.ModTime().UTC(), raw))
+		}
+	}
+
+	slices.SortFunc(captured, func(a, b CapturedMail) int {
+		return b.ReceivedAt.Compare(a.ReceivedAt)
+	})
+	return captured, nil
+}
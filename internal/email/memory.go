@@ -0,0 +1,43 @@
+package email
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MemoryTransport keeps sent messages in memory instead of delivering them.
+type MemoryTransport struct {
+	mu    sync.Mutex
+	mails []CapturedMail
+}
+
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{}
+}
+
+func (t *MemoryTransport) Send(ctx context.Context, from string, to []string, msg []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	id := strconv.Itoa(len(t.mails) + 1)
+	t.mails = append(t.mails, captureMail(id, from, slices.Clone(to), time.Now().UTC(), msg))
+	return nil
+}
+
+// Captured returns the messages sent so far, newest first.
+func (t *MemoryTransport) Captured() ([]CapturedMail, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	captured := slices.Clone(t.mails)
+	slices.Reverse(captured)
+	return captured, nil
+}
+
+// Reset forgets all messages sent so far.
+func (t *MemoryTransport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.mails = nil
+}
@@ -0,0 +1,117 @@
+package email
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Build formats msg as an RFC 5322 message. Messages with a text part are
+// sent as multipart/alternative, so clients without HTML support show the
+// text instead.
+func (s *Sender) Build(msg Message) ([]byte, error) {
+	from, err := mail.ParseAddress(s.cfg.SMTPFrom)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", s.cfg.SMTPFrom, err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient address %q: %w", msg.To, err)
+	}
+
+	date := msg.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	id := msg.ID
+	if id == "" {
+		b := make([]byte, 16)
+		rand.Read(b)
+		id = hex.EncodeToString(b)
+	}
+
+	var buf bytes.Buffer
+	header := func(key, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+	}
+	header("Date", date.Format(time.RFC1123Z))
+	header("From", from.String())
+	header("To", to.String())
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Message-ID", fmt.Sprintf("<%s@%s>", id, s.messageIDDomain(from)))
+	if msg.Unsubscribe != "" {
+		// One-click unsubscribe as in RFC 8058, so clients can offer it
+		// without opening a page.
+		header("List-Unsubscribe", "<"+msg.Unsubscribe+">")
+		header("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+	}
+	header("MIME-Version", "1.0")
+
+	if msg.Text == "" {
+		header("Content-Type", `text/html; charset="utf-8"`)
+		header("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, msg.HTML); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&buf)
+	header("Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": parts.Boundary()}))
+	buf.WriteString("\r\n")
+
+	// Parts are ordered by increasing preference.
+	for _, part := range []struct{ contentType, body string }{
+		{`text/plain; charset="utf-8"`, msg.Text},
+		{`text/html; charset="utf-8"`, msg.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// messageIDDomain returns the host of the configured public URL, falling back
+// to the domain of the sender address.
+func (s *Sender) messageIDDomain(from *mail.Address) string {
+	if u, err := url.Parse(s.cfg.Domain); err == nil && u.Hostname() != "" {
+		return u.Hostname()
+	}
+	if _, domain, ok := strings.Cut(from.Address, "@"); ok {
+		return domain
+	}
+	return "localhost"
+}
+
+// writeQuotedPrintable encodes body, which also turns its line breaks into
+// the CRLF required by SMTP.
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
@@ -0,0 +1,166 @@
+package email
+
+import (
+	"bytes"
+	"flag"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/config"
+	"github.com/fachschaftinformatik/web/internal/i18n"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func newTestSender(t *testing.T) *Sender {
+	t.Helper()
+	cfg := &config.Config{
+		Domain:   "https://fsv.example",
+		SMTPFrom: "FSV Informatik <noreply@fsv.example>",
+	}
+	s, err := NewSender(cfg, NewMemoryTransport())
+	if err != nil {
+		t.Fatalf("NewSender: %v", err)
+	}
+	return s
+}
+
+// withFixedBoundary replaces the random multipart boundary of raw.
+func withFixedBoundary(t *testing.T, raw []byte) []byte {
+	t.Helper()
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("ParseMediaType: %v", err)
+	}
+	if boundary := params["boundary"]; boundary != "" {
+		raw = bytes.ReplaceAll(raw, []byte(boundary), []byte("BOUNDARY"))
+	}
+	return raw
+}
+
+func TestBuildGolden(t *testing.T) {
+	s := newTestSender(t)
+	date := time.Date(2025, time.October, 1, 9, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	verification, err := s.VerificationEmail(i18n.English, "Erika Mustermann <erika@example.org>", "Erika", "verify-token")
+	if err != nil {
+		t.Fatalf("VerificationEmail: %v", err)
+	}
+	reverification, err := s.ReverificationEmail(i18n.German, "erika@example.org", "Erika", "verify-token", "unsubscribe-token", time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ReverificationEmail: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		msg  Message
+	}{
+		{"verification_en", verification},
+		{"reverification_de", reverification},
+		{"html_only", Message{
+			To:      "erika@example.org",
+			Subject: "Bitte bestätige deine E-Mail-Adresse",
+			HTML:    "<p>Grüße</p>",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.msg.ID = "golden"
+			tt.msg.Date = date
+			raw, err := s.Build(tt.msg)
+			if err != nil {
+				t.Fatalf("Build: %v", err)
+			}
+			got := withFixedBoundary(t, raw)
+
+			path := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				if err := os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("reading golden file (run with -update to create it): %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Build(%s) does not match %s:\n%s", tt.name, path, got)
+			}
+		})
+	}
+}
+
+func TestBuildHeaders(t *testing.T) {
+	s := newTestSender(t)
+	msg, err := s.ReverificationEmail(i18n.German, "erika@example.org", "Erika", "verify-token", "unsubscribe-token", time.Now())
+	if err != nil {
+		t.Fatalf("ReverificationEmail: %v", err)
+	}
+	raw, err := s.Build(msg)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("DecodeHeader: %v", err)
+	}
+	if subject != msg.Subject {
+		t.Errorf("Subject = %q, want %q", subject, msg.Subject)
+	}
+	if got, want := parsed.Header.Get("List-Unsubscribe"), "<https://fsv.example/api/auth/unsubscribe?token=unsubscribe-token>"; got != want {
+		t.Errorf("List-Unsubscribe = %q, want %q", got, want)
+	}
+	if got := parsed.Header.Get("List-Unsubscribe-Post"); got != "List-Unsubscribe=One-Click" {
+		t.Errorf("List-Unsubscribe-Post = %q", got)
+	}
+	if _, err := parsed.Header.Date(); err != nil {
+		t.Errorf("Date: %v", err)
+	}
+	if id := parsed.Header.Get("Message-ID"); !bytes.HasSuffix([]byte(id), []byte("@fsv.example>")) {
+		t.Errorf("Message-ID = %q, want it on the configured domain", id)
+	}
+
+	verification, err := s.VerificationEmail(i18n.German, "erika@example.org", "Erika", "verify-token")
+	if err != nil {
+		t.Fatalf("VerificationEmail: %v", err)
+	}
+	if verification.Unsubscribe != "" {
+		t.Errorf("verification mails are transactional, got Unsubscribe %q", verification.Unsubscribe)
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		name, html, want string
+	}{
+		{"paragraphs", "<p>Hallo  Erika,</p>\n<p>\n  zweiter\n  Absatz\n</p>", "Hallo Erika,\n\nzweiter Absatz\n"},
+		{"inline elements", "<p>bei der <strong>FSV</strong> Informatik</p>", "bei der FSV Informatik\n"},
+		{"line break", "<div>erste<br/>zweite</div>", "erste\nzweite\n"},
+		{"link with text", `<a href="https://a.example/x">Bestätigen</a>`, "Bestätigen: https://a.example/x\n"},
+		{"link showing its target", `<a href="https://a.example/x">https://a.example/x</a>`, "https://a.example/x\n"},
+		{"styles are dropped", "<html><head><style>p { color: red; }</style></head><body><p>Text</p></body></html>", "Text\n"},
+		{"entities", "<p>&copy; 2025 FSV &amp; Co</p>", "© 2025 FSV & Co\n"},
+	}
+	for _, tt := range tests {
+		got, err := plainText(tt.html)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: plainText = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/database"
+	"github.com/google/uuid"
+)
+
+const (
+	outboxBatchSize    = 16
+	outboxPollInterval = 30 * time.Second
+	outboxDrainTimeout = 20 * time.Second
+	// Retries back off exponentially from outboxBaseBackoff, so a message
+	// is given up on after ten attempts, roughly four hours after it was
+	// queued.
+	outboxMaxAttempts = 10
+	outboxBaseBackoff = 30 * time.Second
+	outboxMaxBackoff  = 6 * time.Hour
+	outboxRetention   = 30 * 24 * time.Hour
+)
+
+// Outbox persists messages before they are sent, so that neither a failing
+// mail server nor a restart loses them.
+//
+// Messages are stored rendered, including the verification links in them.
+// The body is cleared as soon as a message is sent. Messages that were given
+// up on keep it, so that admins can requeue them, until they are deleted
+// together with the sent ones after outboxRetention. A token in a stored
+// link is replaced by the next one sent to the same user.
+type Outbox struct {
+	db     database.Querier
+	sender *Sender
+	log    *log.Logger
+	wake   chan struct{}
+}
+
+func NewOutbox(db database.Querier, sender *Sender, logger *log.Logger) *Outbox {
+	return &Outbox{
+		db:     db,
+		sender: sender,
+		log:    logger,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Enqueue stores msg for delivery by Run.
+func (o *Outbox) Enqueue(ctx context.Context, msg Message) error {
+	if err := o.Stage(ctx, o.db, msg); err != nil {
+		return err
+	}
+	o.Notify()
+	return nil
+}
+
+// Stage stores msg with q, so that it can be queued in a transaction. Notify
+// should be called once the transaction is committed, otherwise msg waits
+// for the next poll.
+func (o *Outbox) Stage(ctx context.Context, q database.Querier, msg Message) error {
+	_, err := q.EnqueueEmail(ctx, database.EnqueueEmailParams{
+		ID:          uuid.NewString(),
+		Recipient:   msg.To,
+		Subject:     msg.Subject,
+		Body:        msg.HTML,
+		TextBody:    msg.Text,
+		Unsubscribe: msg.Unsubscribe,
+	})
+	return err
+}
+
+// Requeue schedules a pending or dead message for immediate delivery. It
+// returns sql.ErrNoRows if there is no such message or it was already sent.
+func (o *Outbox) Requeue(ctx context.Context, id string) (database.EmailOutbox, error) {
+	msg, err := o.db.RequeueEmail(ctx, id)
+	if err != nil {
+		return database.EmailOutbox{}, err
+	}
+	o.Notify()
+	return msg, nil
+}
+
+// Notify wakes Run to deliver newly queued messages.
+func (o *Outbox) Notify() {
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers queued messages until ctx is cancelled, then makes a last
+// attempt to send whatever is due before returning.
+func (o *Outbox) Run(ctx context.Context) {
+	o.log.Println("Email outbox started.")
+	// Messages claimed by a process that stopped in the middle of sending.
+	if err := o.db.ResetSendingEmails(ctx); err != nil {
+		o.log.Printf("Error resetting email outbox: %v", err)
+	}
+
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		o.deliver(ctx)
+		select {
+		case <-o.wake:
+		case <-ticker.C:
+			before := database.NewNullTime(time.Now().Add(-outboxRetention))
+			if err := o.db.DeleteFinishedEmails(ctx, before); err != nil {
+				o.log.Printf("Error sweeping email outbox: %v", err)
+			}
+		case <-ctx.Done():
+			drainCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), outboxDrainTimeout)
+			o.deliver(drainCtx)
+			cancel()
+			o.log.Println("Email outbox stopped.")
+			return
+		}
+	}
+}
+
+func (o *Outbox) deliver(ctx context.Context) {
+	for ctx.Err() == nil {
+		batch, err := o.db.ClaimDueEmails(ctx, outboxBatchSize)
+		if err != nil {
+			o.log.Printf("Error claiming emails: %v", err)
+			return
+		}
+		for _, msg := range batch {
+			o.attempt(ctx, msg)
+		}
+		if len(batch) < outboxBatchSize {
+			return
+		}
+	}
+}
+
+func (o *Outbox) attempt(ctx context.Context, msg database.EmailOutbox) {
+	// Retries keep the Message-ID and Date, so recipients can tell that a
+	// message arrived twice.
+	err := o.sender.Send(ctx, Message{
+		ID:          msg.ID,
+		Date:        msg.CreatedAt.Time,
+		To:          msg.Recipient,
+		Subject:     msg.Subject,
+		HTML:        msg.Body,
+		Text:        msg.TextBody,
+		Unsubscribe: msg.Unsubscribe,
+	})
+
+	// The outcome has to be recorded even if ctx ended while sending,
+	// otherwise the message would be sent again after a restart.
+	ctx = context.WithoutCancel(ctx)
+	if err == nil {
+		if err := o.db.MarkEmailSent(ctx, msg.ID); err != nil {
+			o.log.Printf("Error marking email %s as sent: %v", msg.ID, err)
+		}
+		return
+	}
+
+	status := "pending"
+	if msg.Attempts >= outboxMaxAttempts {
+		status = "dead"
+		o.log.Printf("Giving up on email %s to %s after %d attempts: %v", msg.ID, msg.Recipient, msg.Attempts, err)
+	} else {
+		o.log.Printf("Failed to send email %s to %s (attempt %d): %v", msg.ID, msg.Recipient, msg.Attempts, err)
+	}
+
+	if err := o.db.MarkEmailFailed(ctx, database.MarkEmailFailedParams{
+		ID:            msg.ID,
+		Status:        status,
+		LastError:     sql.NullString{String: err.Error(), Valid: true},
+		NextAttemptAt: database.NewTime(time.Now().Add(backoff(msg.Attempts))),
+	}); err != nil {
+		o.log.Printf("Error recording failure of email %s: %v", msg.ID, err)
+	}
+}
+
+// backoff returns the delay before the next attempt after the given number
+// of failed attempts.
+func backoff(attempts int64) time.Duration {
+	delay := outboxBaseBackoff
+	for i := int64(1); i < attempts && delay < outboxMaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, outboxMaxBackoff)
+}
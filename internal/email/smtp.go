@@ -0,0 +1,181 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/config"
+)
+
+const smtpTimeout = 30 * time.Second
+
+// TLS modes of SMTPTransport.
+const (
+	// TLSAuto uses implicit TLS on port 465 and STARTTLS elsewhere if the
+	// server offers it.
+	TLSAuto     = "auto"
+	TLSStartTLS = "starttls"
+	TLSImplicit = "implicit"
+	TLSNone     = "none"
+)
+
+// SMTPTransport submits mail to an SMTP server.
+type SMTPTransport struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	TLS      string
+	// Auth is one of plain, login, cram-md5 or none. Without a username no
+	// authentication is attempted.
+	Auth string
+}
+
+func NewSMTPTransport(cfg *config.Config) (*SMTPTransport, error) {
+	t := &SMTPTransport{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUser,
+		Password: cfg.SMTPPass,
+		TLS:      cfg.SMTPTLS,
+		Auth:     cfg.SMTPAuth,
+	}
+	switch t.TLS {
+	case TLSAuto, TLSStartTLS, TLSImplicit, TLSNone:
+	default:
+		return nil, fmt.Errorf("unknown SMTP TLS mode %q", t.TLS)
+	}
+	switch t.Auth {
+	case "plain", "login", "cram-md5", "none":
+	default:
+		return nil, fmt.Errorf("unknown SMTP auth mechanism %q", t.Auth)
+	}
+	return t, nil
+}
+
+func (t *SMTPTransport) Send(ctx context.Context, from string, to []string, msg []byte) error {
+	mode := t.TLS
+	if mode == TLSAuto && t.Port == "465" {
+		mode = TLSImplicit
+	}
+
+	tlsConfig := &tls.Config{ServerName: t.Host}
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	addr := net.JoinHostPort(t.Host, t.Port)
+
+	var conn net.Conn
+	var err error
+	if mode == TLSImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	conn.SetDeadline(deadline)
+
+	c, err := smtp.NewClient(conn, t.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if mode == TLSStartTLS || mode == TLSAuto {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("STARTTLS failed: %w", err)
+			}
+		} else if mode == TLSStartTLS {
+			return errors.New("server does not support STARTTLS")
+		}
+	}
+
+	if auth := t.auth(); auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("server does not support authentication")
+		}
+		if err := c.Auth(auth); err != nil {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+	}
+
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func (t *SMTPTransport) auth() smtp.Auth {
+	if t.Username == "" {
+		return nil
+	}
+	switch t.Auth {
+	case "plain":
+		return smtp.PlainAuth("", t.Username, t.Password, t.Host)
+	case "login":
+		return &loginAuth{username: t.Username, password: t.Password, host: t.Host}
+	case "cram-md5":
+		return smtp.CRAMMD5Auth(t.Username, t.Password)
+	default:
+		return nil
+	}
+}
+
+// loginAuth implements the LOGIN mechanism still required by some servers,
+// e.g. Microsoft 365.
+type loginAuth struct {
+	username, password, host string
+}
+
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	// Like smtp.PlainAuth, never send credentials in the clear to a remote
+	// host.
+	if !server.TLS && a.host != "localhost" && a.host != "127.0.0.1" && a.host != "::1" {
+		return "", nil, errors.New("unencrypted connection")
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("wrong host name")
+	}
+	return "LOGIN", nil, nil
+}
+
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch string(fromServer) {
+	case "Username:":
+		return []byte(a.username), nil
+	case "Password:":
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("unexpected server challenge %q", fromServer)
+	}
+}
@@ -0,0 +1,125 @@
+package email
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// plainText renders the text part of a message from its HTML part, so that
+// both are generated from the same template. Paragraphs are separated by
+// blank lines and links are written out after their text.
+func plainText(body string) (string, error) {
+	doc, err := html.Parse(strings.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	var t textWriter
+	t.walk(doc)
+	return strings.TrimSpace(t.buf.String()) + "\n", nil
+}
+
+type textWriter struct {
+	buf strings.Builder
+	// breaks is the number of line breaks due before the next text.
+	breaks int
+	// space is set if a space is due before the next text on the same line.
+	space bool
+}
+
+func (t *textWriter) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		t.text(n.Data)
+		return
+	case html.ElementNode:
+		switch n.DataAtom {
+		case atom.Head, atom.Style, atom.Script:
+			return
+		case atom.Br:
+			t.lineBreak(1)
+			return
+		case atom.A:
+			t.link(n)
+			return
+		}
+	}
+
+	block := isBlock(n)
+	if block {
+		t.lineBreak(2)
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		t.walk(c)
+	}
+	if block {
+		t.lineBreak(2)
+	}
+}
+
+// link writes the text of a link followed by its target. Links showing
+// their own target are written once.
+func (t *textWriter) link(n *html.Node) {
+	var label textWriter
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		label.walk(c)
+	}
+	text := strings.TrimSpace(label.buf.String())
+	href := ""
+	for _, attr := range n.Attr {
+		if attr.Key == "href" {
+			href = attr.Val
+		}
+	}
+
+	switch {
+	case href == "" || href == text:
+		t.text(text)
+	case text == "":
+		t.text(href)
+	default:
+		t.text(text + ": " + href)
+	}
+}
+
+func (t *textWriter) text(s string) {
+	if s == "" {
+		return
+	}
+	leading := strings.TrimLeft(s, " \t\r\n") != s
+	trailing := strings.TrimRight(s, " \t\r\n") != s
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		t.space = t.space || leading
+		return
+	}
+
+	if t.buf.Len() > 0 {
+		if t.breaks > 0 {
+			t.buf.WriteString(strings.Repeat("\n", t.breaks))
+		} else if t.space || leading {
+			t.buf.WriteByte(' ')
+		}
+	}
+	t.buf.WriteString(strings.Join(words, " "))
+	t.breaks = 0
+	t.space = trailing
+}
+
+func (t *textWriter) lineBreak(n int) {
+	t.breaks = max(t.breaks, n)
+	t.space = false
+}
+
+func isBlock(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	switch n.DataAtom {
+	case atom.P, atom.Div, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6,
+		atom.Table, atom.Tr, atom.Ul, atom.Ol, atom.Li, atom.Blockquote:
+		return true
+	}
+	return false
+}
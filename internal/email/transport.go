@@ -0,0 +1,72 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/mail"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/config"
+)
+
+// Transport delivers a fully formatted message to its recipients.
+type Transport interface {
+	Send(ctx context.Context, from string, to []string, msg []byte) error
+}
+
+// Recorder is implemented by transports that keep what they send, so it can
+// be looked at during development.
+type Recorder interface {
+	Captured() ([]CapturedMail, error)
+}
+
+// CapturedMail is a message kept by a Recorder.
+type CapturedMail struct {
+	ID         string
+	From       string
+	To         []string
+	Subject    string
+	ReceivedAt time.Time
+	Raw        string
+}
+
+var ErrNotRecorded = errors.New("the mail transport does not record messages")
+
+// NewTransport returns the transport selected by cfg.MailTransport.
+func NewTransport(cfg *config.Config) (Transport, error) {
+	switch cfg.MailTransport {
+	case "smtp":
+		return NewSMTPTransport(cfg)
+	case "file":
+		return NewMaildirTransport(cfg.MailDir)
+	case "memory":
+		return NewMemoryTransport(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail transport %q, expected smtp, file or memory", cfg.MailTransport)
+	}
+}
+
+// captureMail extracts the subject of raw for listing. Messages that cannot
+// be parsed are still returned, just without a subject.
+func captureMail(id, from string, to []string, receivedAt time.Time, raw []byte) CapturedMail {
+	captured := CapturedMail{
+		ID:         id,
+		From:       from,
+		To:         to,
+		ReceivedAt: receivedAt,
+		Raw:        string(raw),
+	}
+	if msg, err := mail.ReadMessage(bytes.NewReader(raw)); err == nil {
+		subject := msg.Header.Get("Subject")
+		if decoded, err := new(mime.WordDecoder).DecodeHeader(subject); err == nil {
+			subject = decoded
+		}
+		captured.Subject = subject
+		io.Copy(io.Discard, msg.Body)
+	}
+	return captured
+}
@@ -0,0 +1,134 @@
+package i18n
+
+// catalog maps English messages to their translations.
+var catalog = map[Locale]map[string]string{
+	German: {
+		// Email subjects and layout.
+		"Please confirm your email address":                            "Bitte bestätige deine E-Mail-Adresse",
+		"Please confirm your account again":                            "Bitte bestätige deinen Account erneut",
+		"If the button does not work, open this link in your browser:": "Falls der Button nicht funktionieren sollte, öffne diesen Link in deinem Browser:",
+
+		// API errors.
+		"A user with this email already exists":                             "Es gibt bereits einen Account mit dieser E-Mail-Adresse",
+		"Accounts with this email domain are no longer allowed":             "Accounts mit dieser E-Mail-Domain sind nicht mehr erlaubt",
+		"Active must be 0 or 1":                                             "Active muss 0 oder 1 sein",
+		"At least one scope is required":                                    "Mindestens ein Scope ist erforderlich",
+		"Chunk index out of range":                                          "Chunk-Index außerhalb des gültigen Bereichs",
+		"Could not abort upload":                                            "Der Upload konnte nicht abgebrochen werden",
+		"Could not compute storage usage":                                   "Die Speichernutzung konnte nicht berechnet werden",
+		"Could not create download link":                                    "Der Downloadlink konnte nicht erstellt werden",
+		"Could not create session":                                          "Die Sitzung konnte nicht erstellt werden",
+		"Could not create token":                                            "Der Token konnte nicht erstellt werden",
+		"Could not create user":                                             "Der Account konnte nicht erstellt werden",
+		"Could not decode JSON body":                                        "Der JSON-Body konnte nicht gelesen werden",
+		"Could not delete account":                                          "Der Account konnte nicht gelöscht werden",
+		"Could not delete upload":                                           "Der Upload konnte nicht gelöscht werden",
+		"Could not delete user":                                             "Der Account konnte nicht gelöscht werden",
+		"Could not delete verification policy":                              "Die Bestätigungsregel konnte nicht gelöscht werden",
+		"Could not export data":                                             "Die Daten konnten nicht exportiert werden",
+		"Could not export users":                                            "Die Accounts konnten nicht exportiert werden",
+		"Could not fetch program":                                           "Der Studiengang konnte nicht geladen werden",
+		"Could not fetch programs":                                          "Die Studiengänge konnten nicht geladen werden",
+		"Could not list captured mails":                                     "Die aufgezeichneten E-Mails konnten nicht geladen werden",
+		"Could not list emails":                                             "Die E-Mails konnten nicht geladen werden",
+		"Could not list quarantined uploads":                                "Die Uploads in Quarantäne konnten nicht geladen werden",
+		"Could not list sessions":                                           "Die Sitzungen konnten nicht geladen werden",
+		"Could not list tokens":                                             "Die Tokens konnten nicht geladen werden",
+		"Could not list users":                                              "Die Accounts konnten nicht geladen werden",
+		"Could not list verification policies":                              "Die Bestätigungsregeln konnten nicht geladen werden",
+		"Could not process email data":                                      "Die E-Mail-Daten konnten nicht verarbeitet werden",
+		"Could not process exam data":                                       "Die Klausurdaten konnten nicht verarbeitet werden",
+		"Could not process policy data":                                     "Die Regeldaten konnten nicht verarbeitet werden",
+		"Could not process registration":                                    "Die Registrierung konnte nicht verarbeitet werden",
+		"Could not process session data":                                    "Die Sitzungsdaten konnten nicht verarbeitet werden",
+		"Could not process token data":                                      "Die Tokendaten konnten nicht verarbeitet werden",
+		"Could not process upload data":                                     "Die Uploaddaten konnten nicht verarbeitet werden",
+		"Could not process user data":                                       "Die Accountdaten konnten nicht verarbeitet werden",
+		"Could not publish exam":                                            "Die Klausur konnte nicht veröffentlicht werden",
+		"Could not read request body":                                       "Der Request-Body konnte nicht gelesen werden",
+		"Could not read the chunk":                                          "Der Chunk konnte nicht gelesen werden",
+		"Could not release upload":                                          "Der Upload konnte nicht freigegeben werden",
+		"Could not render email template":                                   "Die E-Mail-Vorlage konnte nicht gerendert werden",
+		"Could not requeue email":                                           "Die E-Mail konnte nicht erneut eingereiht werden",
+		"Could not revoke session":                                          "Die Sitzung konnte nicht beendet werden",
+		"Could not revoke sessions":                                         "Die Sitzungen konnten nicht beendet werden",
+		"Could not revoke token":                                            "Der Token konnte nicht widerrufen werden",
+		"Could not start upload":                                            "Der Upload konnte nicht gestartet werden",
+		"Could not store chunk":                                             "Der Chunk konnte nicht gespeichert werden",
+		"Could not store verification policy":                               "Die Bestätigungsregel konnte nicht gespeichert werden",
+		"Could not update user":                                             "Der Account konnte nicht aktualisiert werden",
+		"Could not verify upload":                                           "Der Upload konnte nicht geprüft werden",
+		"Database error":                                                    "Datenbankfehler",
+		"Email not found":                                                   "E-Mail nicht gefunden",
+		"Exam not found":                                                    "Klausur nicht gefunden",
+		"Files must not be larger than %d MiB":                              "Dateien dürfen nicht größer als %d MiB sein",
+		"Invalid cursor":                                                    "Ungültiger Cursor",
+		"Invalid email or password":                                         "E-Mail-Adresse oder Passwort ist falsch",
+		"Invalid unsubscribe token":                                         "Ungültiger Abmeldetoken",
+		"Invalid verification token":                                        "Ungültiger Bestätigungslink",
+		"Limit must be between 1 and 100":                                   "Limit muss zwischen 1 und 100 liegen",
+		"Limit must be between 1 and 256":                                   "Limit muss zwischen 1 und 256 liegen",
+		"Not all chunks have been uploaded yet":                             "Es wurden noch nicht alle Chunks hochgeladen",
+		"Only PDF files are accepted":                                       "Es werden nur PDF-Dateien akzeptiert",
+		"Only available in development mode":                                "Nur im Entwicklungsmodus verfügbar",
+		"Only pending or dead emails can be requeued":                       "Nur wartende oder aufgegebene E-Mails können erneut eingereiht werden",
+		"Program not found":                                                 "Studiengang nicht gefunden",
+		"Quarantined upload not found":                                      "Upload in Quarantäne nicht gefunden",
+		"Registration is only open for addresses at %s":                     "Die Registrierung ist nur für Adressen bei %s möglich",
+		"Reminders must be 0 or 1":                                          "Reminders muss 0 oder 1 sein",
+		"Role must be one of user, editor or admin":                         "Die Rolle muss user, editor oder admin sein",
+		"Session not found":                                                 "Sitzung nicht gefunden",
+		"Status must be one of pending, sending, sent or dead":              "Der Status muss pending, sending, sent oder dead sein",
+		"The checksum must be a hex encoded SHA-256":                        "Die Prüfsumme muss ein hex-kodierter SHA-256 sein",
+		"The chunk does not match its checksum":                             "Der Chunk passt nicht zu seiner Prüfsumme",
+		"The chunk must have %d bytes":                                      "Der Chunk muss %d Bytes groß sein",
+		"The deleted account placeholder cannot be changed":                 "Der Platzhalter für gelöschte Accounts kann nicht geändert werden",
+		"The exam date must have the form YYYY-MM-DD":                       "Das Klausurdatum muss die Form JJJJ-MM-TT haben",
+		"The file could not be checked for malware, please try again later": "Die Datei konnte nicht auf Schadsoftware geprüft werden, bitte versuche es später erneut",
+		"The file does not match the announced size, checksum or type":      "Die Datei passt nicht zur angekündigten Größe, Prüfsumme oder zum angekündigten Typ",
+		"The file has not been uploaded yet":                                "Die Datei wurde noch nicht hochgeladen",
+		"The file must not be empty":                                        "Die Datei darf nicht leer sein",
+		"The file of this exam is missing":                                  "Die Datei dieser Klausur fehlt",
+		"The file was flagged by the malware scanner and will be reviewed by an admin": "Die Datei wurde vom Virenscanner markiert und wird von einem Admin geprüft",
+		"The last active admin cannot be removed":                                      "Der letzte aktive Admin kann nicht entfernt werden",
+		"The mail transport does not capture emails":                                   "Der Mail-Transport zeichnet keine E-Mails auf",
+		"The password is incorrect":                                                    "Das Passwort ist falsch",
+		"The verification window must not end before the user was verified":            "Die Bestätigung darf nicht vor dem Bestätigungszeitpunkt enden",
+		"There is not enough storage left for this upload":                             "Für diesen Upload ist nicht mehr genug Speicher frei",
+		"This account has been deactivated":                                            "Dieser Account wurde deaktiviert",
+		"This upload would exceed your storage quota of %d MiB":                        "Dieser Upload würde dein Speicherkontingent von %d MiB überschreiten",
+		"Token name must be between 1 and 64 characters":                               "Der Tokenname muss zwischen 1 und 64 Zeichen lang sein",
+		"Token not found":                                    "Token nicht gefunden",
+		"Tokens must expire within 1 to %d days":             "Tokens müssen innerhalb von 1 bis %d Tagen ablaufen",
+		"Unknown email template":                             "Unbekannte E-Mail-Vorlage",
+		"Unknown program or version":                         "Unbekannter Studiengang oder unbekannte PO",
+		"Unknown scope %q":                                   "Unbekannter Scope %q",
+		"Unsupported locale":                                 "Nicht unterstützte Sprache",
+		"Upload not found":                                   "Upload nicht gefunden",
+		"User not found":                                     "Account nicht gefunden",
+		"Verification failed":                                "Die Bestätigung ist fehlgeschlagen",
+		"Verification policy not found":                      "Bestätigungsregel nicht gefunden",
+		"Verified must be 0 or 1":                            "Verified muss 0 oder 1 sein",
+		"You cannot delete yourself":                         "Du kannst deinen eigenen Account hier nicht löschen",
+		"You cannot demote or deactivate yourself":           "Du kannst dich nicht selbst herabstufen oder deaktivieren",
+		"You cannot have more than %d unfinished uploads":    "Du kannst nicht mehr als %d unfertige Uploads haben",
+		"You do not have permission to access this resource": "Du hast keine Berechtigung für diese Ressource",
+		"You need to confirm your email address first. We have sent you a new email.": "Du musst erst deine E-Mail bestätigen. Wir haben dir eine neue E-Mail gesendet.",
+
+		// Errors passed through from authentication and validation.
+		"personal access tokens are not accepted here": "Persönliche Zugriffstokens werden hier nicht akzeptiert",
+		"session cookie not found":                     "Sitzungscookie nicht gefunden",
+		"invalid session":                              "Ungültige Sitzung",
+		"session expired":                              "Die Sitzung ist abgelaufen",
+		"user not found for session":                   "Zur Sitzung gehört kein Account",
+		"database error":                               "Datenbankfehler",
+		"invalid token":                                "Ungültiger Token",
+		"token expired":                                "Der Token ist abgelaufen",
+		"X-CSRF-Token header missing":                  "Der X-CSRF-Token-Header fehlt",
+		"CSRF cookie missing":                          "Das CSRF-Cookie fehlt",
+		"invalid CSRF token":                           "Ungültiger CSRF-Token",
+		"role must be one of user, editor or admin":    "Die Rolle muss user, editor oder admin sein",
+		"verified must be 0 or 1":                      "Verified muss 0 oder 1 sein",
+		"active must be 0 or 1":                        "Active muss 0 oder 1 sein",
+	},
+}
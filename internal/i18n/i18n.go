@@ -0,0 +1,72 @@
+// Package i18n translates user facing text. Messages are looked up by their
+// English wording, so untranslated messages are shown in English.
+package i18n
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+type Locale string
+
+const (
+	German  Locale = "de"
+	English Locale = "en"
+)
+
+// Default is the locale of users who never chose one. Most of them study in
+// Germany.
+const Default = German
+
+var Supported = []Locale{German, English}
+
+var matcher = language.NewMatcher([]language.Tag{language.German, language.English})
+
+// Parse returns the supported locale s, e.g. "de".
+func Parse(s string) (Locale, bool) {
+	for _, locale := range Supported {
+		if string(locale) == s {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// Negotiate picks the supported locale preferred by an Accept-Language
+// header, or fallback if the header names none of them.
+func Negotiate(header string, fallback Locale) Locale {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return fallback
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return fallback
+	}
+	return Supported[index]
+}
+
+// T translates msg into locale.
+func T(locale Locale, msg string) string {
+	if translated, ok := catalog[locale][msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Sprintf translates format into locale before formatting it.
+func Sprintf(locale Locale, format string, args ...any) string {
+	return fmt.Sprintf(T(locale, format), args...)
+}
+
+// FormatDate formats the date of t the way it is written in locale.
+func FormatDate(locale Locale, t time.Time) string {
+	switch locale {
+	case German:
+		return t.Format("02.01.2006")
+	default:
+		return t.Format("2 January 2006")
+	}
+}
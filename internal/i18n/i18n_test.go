@@ -0,0 +1,39 @@
+package i18n
+
+import "testing"
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Locale
+	}{
+		{"", Default},
+		{"de", German},
+		{"de-DE", German},
+		{"de-AT,de;q=0.9", German},
+		{"en", English},
+		{"en-US,en;q=0.9", English},
+		{"en-GB,de;q=0.5", English},
+		{"de;q=0.4, en;q=0.8", English},
+		{"en;q=0.4, de;q=0.8", German},
+		{"fr", Default},
+		{"fr-FR, en;q=0.5", English},
+		{"*", Default},
+		{"en;q=0", Default},
+		{";;;", Default},
+		{"en;q=banana", Default},
+	}
+	for _, tt := range tests {
+		if got := Negotiate(tt.header, Default); got != tt.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+
+	// The fallback is only used if the header names no supported locale.
+	if got := Negotiate("fr", English); got != English {
+		t.Errorf("Negotiate(%q, English) = %q, want en", "fr", got)
+	}
+	if got := Negotiate("de", English); got != German {
+		t.Errorf("Negotiate(%q, English) = %q, want de", "de", got)
+	}
+}
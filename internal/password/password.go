@@ -0,0 +1,61 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownScheme is returned when a stored hash was not produced by any of
+// the schemes known to a Hasher.
+var ErrUnknownScheme = errors.New("password: unknown hash scheme")
+
+// Scheme is a single password hashing algorithm.
+type Scheme interface {
+	// Hash encodes password into a self-describing hash string.
+	Hash(password string) (string, error)
+	// Verify reports whether password matches the encoded hash.
+	Verify(password, encoded string) (bool, error)
+	// Recognizes reports whether encoded was produced by this scheme.
+	Recognizes(encoded string) bool
+	// Outdated reports whether encoded was produced with parameters that
+	// differ from the ones this scheme currently hashes with.
+	Outdated(encoded string) bool
+}
+
+// Hasher hashes new passwords with a preferred scheme and verifies stored
+// hashes of any known scheme, so that the preferred scheme or its parameters
+// can change without invalidating existing hashes.
+type Hasher struct {
+	preferred Scheme
+	legacy    []Scheme
+}
+
+func New(preferred Scheme, legacy ...Scheme) *Hasher {
+	return &Hasher{
+		preferred: preferred,
+		legacy:    legacy,
+	}
+}
+
+func (h *Hasher) Hash(password string) (string, error) {
+	return h.preferred.Hash(password)
+}
+
+// Verify checks password against encoded. If it matches, rehash reports
+// whether the caller should replace the stored hash with a fresh one from
+// Hash because it uses an older scheme or outdated parameters.
+func (h *Hasher) Verify(password, encoded string) (ok bool, rehash bool, err error) {
+	if h.preferred.Recognizes(encoded) {
+		ok, err = h.preferred.Verify(password, encoded)
+		return ok, ok && h.preferred.Outdated(encoded), err
+	}
+
+	for _, scheme := range h.legacy {
+		if scheme.Recognizes(encoded) {
+			ok, err = scheme.Verify(password, encoded)
+			return ok, ok, err
+		}
+	}
+
+	return false, false, fmt.Errorf("%w: %.10q", ErrUnknownScheme, encoded)
+}
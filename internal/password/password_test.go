@@ -0,0 +1,163 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fastArgon2id keeps the tests quick. Only the parameters differ from
+// DefaultArgon2id, the encoding is the same.
+var fastArgon2id = Argon2id{
+	Memory:     64,
+	Time:       1,
+	Threads:    1,
+	SaltLength: 16,
+	KeyLength:  32,
+}
+
+func TestArgon2idRoundTrip(t *testing.T) {
+	encoded, err := fastArgon2id.Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "$argon2id$v=19$m=64,t=1,p=1$"; !strings.HasPrefix(encoded, want) {
+		t.Errorf("Hash = %q, want the prefix %q", encoded, want)
+	}
+
+	h, err := parseArgon2id(encoded)
+	if err != nil {
+		t.Fatalf("parseArgon2id(%q): %v", encoded, err)
+	}
+	if h.version != 19 || h.memory != 64 || h.time != 1 || h.threads != 1 || len(h.salt) != 16 || len(h.key) != 32 {
+		t.Errorf("parseArgon2id(%q) = %+v, want the parameters of fastArgon2id", encoded, h)
+	}
+
+	if !fastArgon2id.Recognizes(encoded) {
+		t.Error("Recognizes = false for its own hash")
+	}
+	if fastArgon2id.Outdated(encoded) {
+		t.Error("Outdated = true for a hash with the current parameters")
+	}
+	if ok, err := fastArgon2id.Verify("correct horse", encoded); !ok || err != nil {
+		t.Errorf("Verify with the right password = %v, %v, want true", ok, err)
+	}
+	if ok, err := fastArgon2id.Verify("correct horse ", encoded); ok || err != nil {
+		t.Errorf("Verify with a wrong password = %v, %v, want false without an error", ok, err)
+	}
+
+	// Every hash gets a salt of its own.
+	again, err := fastArgon2id.Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again == encoded {
+		t.Error("two hashes of the same password are equal")
+	}
+}
+
+func TestParseArgon2idRejects(t *testing.T) {
+	const salt = "c2FsdHNhbHRzYWx0c2FsdA"
+	const key = "a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U"
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"empty", ""},
+		{"too few fields", "$argon2id$v=19$m=64,t=1,p=1$" + salt},
+		{"too many fields", "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$" + key + "$"},
+		{"other variant", "$argon2i$v=19$m=64,t=1,p=1$" + salt + "$" + key},
+		{"no version", "$argon2id$m=64,t=1,p=1$" + salt + "$" + key + "$"},
+		{"missing threads", "$argon2id$v=19$m=64,t=1$" + salt + "$" + key},
+		{"negative memory", "$argon2id$v=19$m=-1,t=1,p=1$" + salt + "$" + key},
+		{"memory overflows", "$argon2id$v=19$m=4294967296,t=1,p=1$" + salt + "$" + key},
+		{"huge memory", "$argon2id$v=19$m=4294967295,t=1,p=1$" + salt + "$" + key},
+		{"memory above the bound", "$argon2id$v=19$m=4194305,t=1,p=1$" + salt + "$" + key},
+		{"memory below the minimum", "$argon2id$v=19$m=7,t=1,p=1$" + salt + "$" + key},
+		{"zero passes", "$argon2id$v=19$m=64,t=0,p=1$" + salt + "$" + key},
+		{"huge passes", "$argon2id$v=19$m=64,t=4294967295,p=1$" + salt + "$" + key},
+		{"zero threads", "$argon2id$v=19$m=64,t=1,p=0$" + salt + "$" + key},
+		{"threads overflow", "$argon2id$v=19$m=64,t=1,p=256$" + salt + "$" + key},
+		{"bad salt", "$argon2id$v=19$m=64,t=1,p=1$!!!$" + key},
+		{"padded salt", "$argon2id$v=19$m=64,t=1,p=1$" + salt + "==$" + key},
+		{"bad key", "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$*"},
+		{"empty key", "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$"},
+	}
+	// The same salt and key parse with valid parameters, so each case fails
+	// for the reason in its name.
+	if _, err := parseArgon2id("$argon2id$v=19$m=64,t=1,p=1$" + salt + "$" + key); err != nil {
+		t.Fatalf("parseArgon2id of a valid hash: %v", err)
+	}
+	for _, tt := range tests {
+		if _, err := parseArgon2id(tt.encoded); !errors.Is(err, errMalformedArgon2id) {
+			t.Errorf("%s: parseArgon2id(%q) error = %v, want %v", tt.name, tt.encoded, err, errMalformedArgon2id)
+		}
+		if ok, err := fastArgon2id.Verify("password", tt.encoded); ok || err == nil {
+			t.Errorf("%s: Verify = %v, %v, want an error", tt.name, ok, err)
+		}
+		if !fastArgon2id.Outdated(tt.encoded) {
+			t.Errorf("%s: Outdated = false, want true", tt.name)
+		}
+	}
+}
+
+func TestHasherRehashesOutdatedArgon2id(t *testing.T) {
+	old := fastArgon2id
+	old.Time = 2
+	encoded, err := old.Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hasher := New(fastArgon2id, Bcrypt{Cost: bcrypt.MinCost})
+	if !fastArgon2id.Outdated(encoded) {
+		t.Error("Outdated = false for a hash with other parameters")
+	}
+	ok, rehash, err := hasher.Verify("correct horse", encoded)
+	if !ok || !rehash || err != nil {
+		t.Errorf("Verify = %v, %v, %v, want a match that needs a rehash", ok, rehash, err)
+	}
+	ok, rehash, err = hasher.Verify("wrong", encoded)
+	if ok || rehash || err != nil {
+		t.Errorf("Verify with a wrong password = %v, %v, %v, want no match and no error", ok, rehash, err)
+	}
+
+	current, err := hasher.Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, rehash, err = hasher.Verify("correct horse", current)
+	if !ok || rehash || err != nil {
+		t.Errorf("Verify of a current hash = %v, %v, %v, want a match without a rehash", ok, rehash, err)
+	}
+}
+
+func TestHasherRehashesBcrypt(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded := string(hash)
+
+	hasher := New(fastArgon2id, Bcrypt{Cost: bcrypt.MinCost})
+	ok, rehash, err := hasher.Verify("correct horse", encoded)
+	if !ok || !rehash || err != nil {
+		t.Errorf("Verify = %v, %v, %v, want a match that needs a rehash", ok, rehash, err)
+	}
+	ok, rehash, err = hasher.Verify("wrong", encoded)
+	if ok || rehash || err != nil {
+		t.Errorf("Verify with a wrong password = %v, %v, %v, want no match and no error", ok, rehash, err)
+	}
+}
+
+func TestHasherUnknownScheme(t *testing.T) {
+	hasher := New(fastArgon2id, Bcrypt{Cost: bcrypt.MinCost})
+	for _, encoded := range []string{"", "plaintext", "$1$md5crypt$hash", "$argon2i$v=19$m=64,t=1,p=1$c2FsdA$a2V5"} {
+		ok, rehash, err := hasher.Verify("plaintext", encoded)
+		if ok || rehash || !errors.Is(err, ErrUnknownScheme) {
+			t.Errorf("Verify(%q) = %v, %v, %v, want %v", encoded, ok, rehash, err, ErrUnknownScheme)
+		}
+	}
+}
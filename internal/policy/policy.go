@@ -0,0 +1,157 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Kind selects how the end of a verification period is computed.
+type Kind string
+
+const (
+	// Semester verifications end at the next of a fixed set of dates.
+	Semester Kind = "semester"
+	// Rolling verifications end a fixed number of days after verifying.
+	Rolling Kind = "rolling"
+	// Never verifications do not expire.
+	Never Kind = "never"
+)
+
+// Wildcard is the domain of the policy applied to domains without a policy
+// of their own.
+const Wildcard = "*"
+
+// Boundary is a yearly recurring cut-off date, evaluated at midnight UTC.
+type Boundary struct {
+	Month time.Month
+	Day   int
+}
+
+func (b Boundary) String() string {
+	return fmt.Sprintf("%02d-%02d", int(b.Month), b.Day)
+}
+
+// in returns the boundary in the given year. February 29 falls on March 1
+// in years that are not leap years.
+func (b Boundary) in(year int) time.Time {
+	return time.Date(year, b.Month, b.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// Policy describes how long users of one email domain stay verified.
+type Policy struct {
+	Domain     string
+	Kind       Kind
+	Boundaries []Boundary
+	Duration   time.Duration
+}
+
+// New validates and builds a policy. boundaries are "MM-DD" dates and only
+// used by semester policies, days is only used by rolling policies.
+func New(domain string, kind Kind, boundaries []string, days int) (Policy, error) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" || strings.ContainsAny(domain, "@ \t") {
+		return Policy{}, fmt.Errorf("invalid domain %q", domain)
+	}
+
+	p := Policy{Domain: domain, Kind: kind}
+	switch kind {
+	case Semester:
+		if len(boundaries) == 0 {
+			return Policy{}, errors.New("semester policies need at least one boundary")
+		}
+		for _, s := range boundaries {
+			b, err := ParseBoundary(s)
+			if err != nil {
+				return Policy{}, err
+			}
+			if !slices.Contains(p.Boundaries, b) {
+				p.Boundaries = append(p.Boundaries, b)
+			}
+		}
+		slices.SortFunc(p.Boundaries, func(a, b Boundary) int {
+			if a.Month != b.Month {
+				return int(a.Month) - int(b.Month)
+			}
+			return a.Day - b.Day
+		})
+	case Rolling:
+		if days < 1 {
+			return Policy{}, errors.New("rolling policies need a duration of at least one day")
+		}
+		p.Duration = time.Duration(days) * 24 * time.Hour
+	case Never:
+	default:
+		return Policy{}, fmt.Errorf("unknown policy kind %q", kind)
+	}
+
+	return p, nil
+}
+
+// ParseBoundary parses a "MM-DD" date such as "03-01".
+func ParseBoundary(s string) (Boundary, error) {
+	var month, day int
+	if _, err := fmt.Sscanf(strings.TrimSpace(s), "%2d-%2d", &month, &day); err != nil {
+		return Boundary{}, fmt.Errorf("invalid boundary %q, expected MM-DD", s)
+	}
+	if month < 1 || month > 12 {
+		return Boundary{}, fmt.Errorf("invalid boundary %q: month out of range", s)
+	}
+	// Validate against a leap year so that February 29 is accepted.
+	if day < 1 || day > time.Date(2000, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day() {
+		return Boundary{}, fmt.Errorf("invalid boundary %q: day out of range", s)
+	}
+	return Boundary{Month: time.Month(month), Day: day}, nil
+}
+
+// Days returns the length of a rolling policy in days.
+func (p Policy) Days() int {
+	return int(p.Duration / (24 * time.Hour))
+}
+
+// Until returns the end of the verification period of a user verified at
+// now. ok is false if the verification does not expire.
+func (p Policy) Until(now time.Time) (until time.Time, ok bool) {
+	now = now.UTC()
+	switch p.Kind {
+	case Semester:
+		for _, year := range []int{now.Year(), now.Year() + 1} {
+			for _, b := range p.Boundaries {
+				if t := b.in(year); t.After(now) {
+					return t, true
+				}
+			}
+		}
+		// Unreachable for a policy built by New, since every boundary
+		// occurs again in the following year.
+		return time.Time{}, false
+	case Rolling:
+		return now.Add(p.Duration), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// Match returns the policy for the domain of email, falling back to the
+// wildcard policy. ok is false if neither exists.
+func Match(policies []Policy, email string) (p Policy, ok bool) {
+	domain := email
+	if at := strings.LastIndexByte(email, '@'); at >= 0 {
+		domain = email[at+1:]
+	}
+	domain = strings.ToLower(domain)
+
+	var fallback Policy
+	var hasFallback bool
+	for _, p := range policies {
+		switch p.Domain {
+		case domain:
+			return p, true
+		case Wildcard:
+			fallback, hasFallback = p, true
+		}
+	}
+	return fallback, hasFallback
+}
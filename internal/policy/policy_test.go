@@ -0,0 +1,152 @@
+package policy
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day, hour, min, sec int) time.Time {
+	return time.Date(year, month, day, hour, min, sec, 0, time.UTC)
+}
+
+func mustNew(t *testing.T, domain string, kind Kind, boundaries []string, days int) Policy {
+	t.Helper()
+	p, err := New(domain, kind, boundaries, days)
+	if err != nil {
+		t.Fatalf("New(%q, %q, %q, %d): %v", domain, kind, boundaries, days, err)
+	}
+	return p
+}
+
+func TestUntil(t *testing.T) {
+	semesters := []string{"09-01", "03-01"}
+	berlin := time.FixedZone("CET", 60*60)
+
+	tests := []struct {
+		name       string
+		kind       Kind
+		boundaries []string
+		days       int
+		now        time.Time
+		want       time.Time
+		ok         bool
+	}{
+		{"before first boundary", Semester, semesters, 0, date(2025, time.January, 15, 12, 0, 0), date(2025, time.March, 1, 0, 0, 0), true},
+		{"just before boundary", Semester, semesters, 0, date(2025, time.February, 28, 23, 59, 59), date(2025, time.March, 1, 0, 0, 0), true},
+		{"on boundary", Semester, semesters, 0, date(2025, time.March, 1, 0, 0, 0), date(2025, time.September, 1, 0, 0, 0), true},
+		{"between boundaries", Semester, semesters, 0, date(2025, time.June, 30, 8, 0, 0), date(2025, time.September, 1, 0, 0, 0), true},
+		{"after last boundary", Semester, semesters, 0, date(2025, time.October, 1, 0, 0, 0), date(2026, time.March, 1, 0, 0, 0), true},
+		{"on last boundary", Semester, semesters, 0, date(2025, time.September, 1, 0, 0, 0), date(2026, time.March, 1, 0, 0, 0), true},
+		{"new year's eve", Semester, semesters, 0, date(2025, time.December, 31, 23, 59, 59), date(2026, time.March, 1, 0, 0, 0), true},
+		{"boundary on new year", Semester, []string{"01-01"}, 0, date(2025, time.December, 31, 23, 59, 59), date(2026, time.January, 1, 0, 0, 0), true},
+		{"on new year boundary", Semester, []string{"01-01"}, 0, date(2026, time.January, 1, 0, 0, 0), date(2027, time.January, 1, 0, 0, 0), true},
+		{"local time past new year in UTC", Semester, []string{"01-01"}, 0, time.Date(2026, time.January, 1, 0, 30, 0, 0, berlin), date(2026, time.January, 1, 0, 0, 0), true},
+		{"leap day in leap year", Semester, []string{"02-29"}, 0, date(2024, time.February, 1, 0, 0, 0), date(2024, time.February, 29, 0, 0, 0), true},
+		{"leap day in common year", Semester, []string{"02-29"}, 0, date(2025, time.February, 1, 0, 0, 0), date(2025, time.March, 1, 0, 0, 0), true},
+		{"leap day passed in common year", Semester, []string{"02-29"}, 0, date(2025, time.March, 1, 0, 0, 0), date(2026, time.March, 1, 0, 0, 0), true},
+		{"rolling", Rolling, nil, 30, date(2025, time.June, 1, 10, 0, 0), date(2025, time.July, 1, 10, 0, 0), true},
+		{"rolling across new year", Rolling, nil, 30, date(2025, time.December, 15, 10, 0, 0), date(2026, time.January, 14, 10, 0, 0), true},
+		{"rolling across leap day", Rolling, nil, 1, date(2024, time.February, 28, 10, 0, 0), date(2024, time.February, 29, 10, 0, 0), true},
+		{"never", Never, nil, 0, date(2025, time.June, 1, 0, 0, 0), time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mustNew(t, "uni.example", tt.kind, tt.boundaries, tt.days)
+			got, ok := p.Until(tt.now)
+			if ok != tt.ok || !got.Equal(tt.want) {
+				t.Errorf("Until(%v) = %v, %v, want %v, %v", tt.now, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestParseBoundary(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Boundary
+		wantErr bool
+	}{
+		{in: "03-01", want: Boundary{time.March, 1}},
+		{in: "12-31", want: Boundary{time.December, 31}},
+		{in: " 09-15 ", want: Boundary{time.September, 15}},
+		{in: "02-29", want: Boundary{time.February, 29}},
+		{in: "02-30", wantErr: true},
+		{in: "04-31", wantErr: true},
+		{in: "00-10", wantErr: true},
+		{in: "13-01", wantErr: true},
+		{in: "01-00", wantErr: true},
+		{in: "march", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseBoundary(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseBoundary(%q) error = %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseBoundary(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	p := mustNew(t, " Uni.Example ", Semester, []string{"09-01", "03-01", "09-01"}, 0)
+	if p.Domain != "uni.example" {
+		t.Errorf("Domain = %q, want uni.example", p.Domain)
+	}
+	want := []Boundary{{time.March, 1}, {time.September, 1}}
+	if !slices.Equal(p.Boundaries, want) {
+		t.Errorf("Boundaries = %v, want %v", p.Boundaries, want)
+	}
+
+	if p := mustNew(t, "uni.example", Rolling, nil, 365); p.Days() != 365 {
+		t.Errorf("Days() = %d, want 365", p.Days())
+	}
+
+	invalid := []struct {
+		name       string
+		domain     string
+		kind       Kind
+		boundaries []string
+		days       int
+	}{
+		{"empty domain", "", Never, nil, 0},
+		{"email as domain", "a@uni.example", Never, nil, 0},
+		{"semester without boundaries", "uni.example", Semester, nil, 0},
+		{"semester with invalid boundary", "uni.example", Semester, []string{"02-30"}, 0},
+		{"rolling without days", "uni.example", Rolling, nil, 0},
+		{"unknown kind", "uni.example", Kind("yearly"), nil, 0},
+	}
+	for _, tt := range invalid {
+		if _, err := New(tt.domain, tt.kind, tt.boundaries, tt.days); err == nil {
+			t.Errorf("%s: New succeeded, want error", tt.name)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	uni := mustNew(t, "uni.example", Never, nil, 0)
+	wildcard := mustNew(t, Wildcard, Rolling, nil, 30)
+
+	tests := []struct {
+		name     string
+		policies []Policy
+		email    string
+		want     string
+		ok       bool
+	}{
+		{"exact domain", []Policy{wildcard, uni}, "a@uni.example", "uni.example", true},
+		{"case insensitive", []Policy{uni}, "A@UNI.Example", "uni.example", true},
+		{"subdomain falls back", []Policy{uni, wildcard}, "a@cs.uni.example", Wildcard, true},
+		{"no match", []Policy{uni}, "a@other.example", "", false},
+		{"no policies", nil, "a@uni.example", "", false},
+	}
+	for _, tt := range tests {
+		p, ok := Match(tt.policies, tt.email)
+		if ok != tt.ok || p.Domain != tt.want {
+			t.Errorf("%s: Match(%q) = %q, %v, want %q, %v", tt.name, tt.email, p.Domain, ok, tt.want, tt.ok)
+		}
+	}
+}
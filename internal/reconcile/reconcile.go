@@ -0,0 +1,217 @@
+// Package reconcile compares the objects in the storage with the rows that
+// refer to them.
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/buckets"
+	"github.com/fachschaftinformatik/web/internal/database"
+)
+
+const interval = 24 * time.Hour
+
+// uploadPrefix is where the chunks of an upload are kept until it is
+// finalized, below a directory named after the upload.
+const uploadPrefix = "uploads/"
+
+// keyStorage is implemented by storages that keep the data keys of their
+// objects in separate objects, which List does not return.
+type keyStorage interface {
+	StaleKeys(ctx context.Context) ([]buckets.ObjectInfo, error)
+	DeleteStaleKey(ctx context.Context, record string) error
+}
+
+type Options struct {
+	// Objects without a row are only considered orphans once they are older
+	// than Grace, so that objects of uploads in progress are left alone.
+	Grace time.Duration
+	// Delete removes orphans, otherwise they are only reported.
+	Delete bool
+	// DryRun reports what would be done without deleting objects or
+	// flagging rows.
+	DryRun bool
+}
+
+type Report struct {
+	// Objects is the number of objects in the storage.
+	Objects int
+	// Orphans are the objects without a row and the data keys without an
+	// object that are older than the grace period. Recent is the number of
+	// those that are still within it.
+	Orphans []buckets.ObjectInfo
+	Recent  int
+	Deleted int
+	// Missing are the rows whose object does not exist.
+	Missing []Missing
+	// Found is the number of rows flagged earlier whose object is back.
+	Found int
+}
+
+type Missing struct {
+	Table string
+	ID    string
+	Key   string
+}
+
+// Run reconciles storage with the database. Objects are listed before the
+// rows are read, so that an object stored in between is not mistaken for an
+// orphan, and missing objects are confirmed with Stat before a row is
+// flagged.
+func Run(ctx context.Context, querier database.Querier, storage buckets.Storage, opts Options) (*Report, error) {
+	objects, err := storage.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	exams, err := querier.ListExamObjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uploads, err := querier.ListExamUploadObjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	quarantined, err := querier.ListQuarantineObjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(exams)+len(uploads)+len(quarantined))
+	for _, exam := range exams {
+		known[exam.Accesskey] = true
+	}
+	for _, key := range quarantined {
+		known[key] = true
+	}
+	pending := make(map[string]bool, len(uploads))
+	for _, upload := range uploads {
+		known[upload.Accesskey] = true
+		pending[upload.ID] = true
+	}
+
+	report := &Report{Objects: len(objects)}
+	stored := make(map[string]bool, len(objects))
+	cutoff := time.Now().Add(-opts.Grace)
+	for _, object := range objects {
+		stored[object.Key] = true
+		if known[object.Key] || pending[uploadOf(object.Key)] {
+			continue
+		}
+		if err := report.orphan(ctx, object, cutoff, opts, storage.Delete); err != nil {
+			return report, err
+		}
+	}
+
+	// Data keys are written before their object, so the grace period also
+	// covers objects that are being stored.
+	if keys, ok := storage.(keyStorage); ok {
+		stale, err := keys.StaleKeys(ctx)
+		if err != nil {
+			return report, err
+		}
+		for _, record := range stale {
+			if err := report.orphan(ctx, record, cutoff, opts, keys.DeleteStaleKey); err != nil {
+				return report, err
+			}
+		}
+	}
+
+	now := database.NewNullTime(time.Now())
+	for _, exam := range exams {
+		if stored[exam.Accesskey] {
+			if exam.MissingSince.Valid {
+				report.Found++
+				if !opts.DryRun {
+					if err := querier.ClearExamMissing(ctx, exam.ID); err != nil {
+						return report, err
+					}
+				}
+			}
+			continue
+		}
+		if _, err := storage.Stat(ctx, exam.Accesskey); !errors.Is(err, buckets.ErrNotFound) {
+			if err != nil {
+				return report, err
+			}
+			continue
+		}
+		report.Missing = append(report.Missing, Missing{Table: "exams", ID: exam.ID, Key: exam.Accesskey})
+		if !opts.DryRun {
+			if err := querier.MarkExamMissing(ctx, database.MarkExamMissingParams{Now: now, ID: exam.ID}); err != nil {
+				return report, err
+			}
+		}
+	}
+	return report, nil
+}
+
+// orphan records an object no row refers to and deletes it with del if
+// opts ask for that. Objects modified after cutoff are only counted.
+func (r *Report) orphan(ctx context.Context, object buckets.ObjectInfo, cutoff time.Time, opts Options, del func(context.Context, string) error) error {
+	if object.LastModified.After(cutoff) {
+		r.Recent++
+		return nil
+	}
+	r.Orphans = append(r.Orphans, object)
+	if opts.Delete && !opts.DryRun {
+		if err := del(ctx, object.Key); err != nil {
+			return err
+		}
+		r.Deleted++
+	}
+	return nil
+}
+
+// uploadOf returns the ID of the upload a chunk belongs to, or "" if key is
+// not a chunk.
+func uploadOf(key string) string {
+	rest, ok := strings.CutPrefix(key, uploadPrefix)
+	if !ok {
+		return ""
+	}
+	id, _, ok := strings.Cut(rest, "/")
+	if !ok {
+		return ""
+	}
+	return id
+}
+
+// Log writes a summary of report, followed by every orphan and missing
+// object.
+func (r *Report) Log(logger *log.Logger) {
+	logger.Printf("Reconciled %d objects: %d orphans (%d deleted, %d within the grace period), %d missing, %d found again.",
+		r.Objects, len(r.Orphans), r.Deleted, r.Recent, len(r.Missing), r.Found)
+	for _, orphan := range r.Orphans {
+		logger.Printf("Orphan %s (%d bytes, last modified %s)", orphan.Key, orphan.Size, orphan.LastModified.UTC().Format(time.RFC3339))
+	}
+	for _, missing := range r.Missing {
+		logger.Printf("Missing %s for %s %s", missing.Key, missing.Table, missing.ID)
+	}
+}
+
+// Start runs the reconciliation once a day.
+func Start(ctx context.Context, querier database.Querier, storage buckets.Storage, opts Options, logger *log.Logger) {
+	logger.Println("Storage reconciliation started.")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := Run(ctx, querier, storage, opts)
+		if err != nil {
+			logger.Printf("Error reconciling storage: %v", err)
+		}
+		if report != nil {
+			report.Log(logger)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			logger.Println("Storage reconciliation stopped.")
+			return
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fachschaftinformatik/web/internal/buckets"
+	"github.com/fachschaftinformatik/web/internal/database"
+)
+
+const userID = "11111111-1111-1111-1111-111111111111"
+
+// fixture is a database and a storage with one object of every kind Run
+// must keep, and strays whose rows are gone.
+type fixture struct {
+	q       database.Querier
+	storage *buckets.MemoryStorage
+	// kept are the objects rows refer to, strays those they do not.
+	kept   []string
+	strays []string
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	ctx := context.Background()
+	dsn := "file:" + filepath.Join(t.TempDir(), "test.db")
+	if err := database.Migrate(dsn, log.New(io.Discard, "", 0)); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	db, err := database.NewConnection(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	f := &fixture{q: database.New(db), storage: buckets.NewMemoryStorage(nil)}
+	if _, err := f.q.CreateUser(ctx, database.CreateUserParams{
+		ID:        userID,
+		Email:     "erika@example.org",
+		Name:      "Erika",
+		Password:  "unused",
+		Role:      "user",
+		Active:    1,
+		Programid: 1,
+		Locale:    "de",
+	}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	if _, err := f.q.CreateExam(ctx, database.CreateExamParams{
+		ID:        "exam",
+		Userid:    userID,
+		Programid: 1,
+		Version:   "PO2023",
+		ExamDate:  "2025-07-01",
+		Accesskey: "exams/exam.pdf",
+		MimeType:  "application/pdf",
+		Nbytes:    4,
+		Checksum:  "unused",
+	}); err != nil {
+		t.Fatalf("CreateExam: %v", err)
+	}
+	// The upload has not been finalized, so only its chunks are stored.
+	if _, err := f.q.CreateExamUpload(ctx, database.CreateExamUploadParams{
+		ID:        "upload",
+		Userid:    userID,
+		Programid: 1,
+		Version:   "PO2023",
+		ExamDate:  "2025-07-01",
+		Accesskey: "exams/upload.pdf",
+		MimeType:  "application/pdf",
+		Nbytes:    8,
+		Checksum:  "unused",
+		ExpiresAt: database.NewTime(time.Now().Add(time.Hour)),
+	}); err != nil {
+		t.Fatalf("CreateExamUpload: %v", err)
+	}
+	if _, err := f.q.QuarantineExamUpload(ctx, database.QuarantineExamUploadParams{
+		ID:        "quarantined",
+		Userid:    userID,
+		Programid: 1,
+		Version:   "PO2023",
+		ExamDate:  "2025-07-01",
+		Accesskey: "quarantine/quarantined.pdf",
+		MimeType:  "application/pdf",
+		Nbytes:    4,
+		Checksum:  "unused",
+		Signature: "Eicar",
+	}); err != nil {
+		t.Fatalf("QuarantineExamUpload: %v", err)
+	}
+
+	f.kept = []string{"exams/exam.pdf", "uploads/upload/000000", "uploads/upload/000001", "quarantine/quarantined.pdf"}
+	f.strays = []string{"exams/deleted.pdf", "uploads/aborted/000000", "quarantine/released.pdf"}
+	for _, key := range slices.Concat(f.kept, f.strays) {
+		f.put(t, key)
+	}
+	return f
+}
+
+func (f *fixture) put(t *testing.T, key string) {
+	t.Helper()
+	if err := f.storage.Put(context.Background(), key, strings.NewReader("data"), 4, "application/pdf"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (f *fixture) exists(t *testing.T, key string) bool {
+	t.Helper()
+	_, err := f.storage.Stat(context.Background(), key)
+	if err != nil && !errors.Is(err, buckets.ErrNotFound) {
+		t.Fatal(err)
+	}
+	return err == nil
+}
+
+func keys(objects []buckets.ObjectInfo) []string {
+	var keys []string
+	for _, object := range objects {
+		keys = append(keys, object.Key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func TestRunDeletesOrphans(t *testing.T) {
+	f := newFixture(t)
+	report, err := Run(context.Background(), f.q, f.storage, Options{Delete: true})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if got, want := keys(report.Orphans), slices.Sorted(slices.Values(f.strays)); !slices.Equal(got, want) {
+		t.Errorf("Orphans = %q, want %q", got, want)
+	}
+	if report.Deleted != len(f.strays) || report.Objects != len(f.kept)+len(f.strays) {
+		t.Errorf("Deleted %d of %d objects, want %d of %d", report.Deleted, report.Objects, len(f.strays), len(f.kept)+len(f.strays))
+	}
+	for _, key := range f.strays {
+		if f.exists(t, key) {
+			t.Errorf("orphan %s was not deleted", key)
+		}
+	}
+	for _, key := range f.kept {
+		if !f.exists(t, key) {
+			t.Errorf("%s was deleted", key)
+		}
+	}
+}
+
+func TestRunKeepsRecentObjects(t *testing.T) {
+	f := newFixture(t)
+	report, err := Run(context.Background(), f.q, f.storage, Options{Grace: time.Hour, Delete: true})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(report.Orphans) != 0 || report.Deleted != 0 || report.Recent != len(f.strays) {
+		t.Errorf("Run = %d orphans, %d deleted, %d recent, want only %d recent", len(report.Orphans), report.Deleted, report.Recent, len(f.strays))
+	}
+	for _, key := range f.strays {
+		if !f.exists(t, key) {
+			t.Errorf("%s was deleted within the grace period", key)
+		}
+	}
+}
+
+func TestRunDryRun(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	if err := f.storage.Delete(ctx, "exams/exam.pdf"); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Run(ctx, f.q, f.storage, Options{Delete: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(report.Orphans) != len(f.strays) || report.Deleted != 0 {
+		t.Errorf("Run = %d orphans, %d deleted, want %d orphans and none deleted", len(report.Orphans), report.Deleted, len(f.strays))
+	}
+	for _, key := range f.strays {
+		if !f.exists(t, key) {
+			t.Errorf("dry run deleted %s", key)
+		}
+	}
+	if len(report.Missing) != 1 {
+		t.Errorf("Missing = %v, want the exam", report.Missing)
+	}
+	exam, err := f.q.GetExam(ctx, "exam")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exam.MissingSince.Valid {
+		t.Error("dry run flagged the exam as missing")
+	}
+}
+
+func TestRunFlagsMissingExams(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	if err := f.storage.Delete(ctx, "exams/exam.pdf"); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Run(ctx, f.q, f.storage, Options{Grace: time.Hour})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	want := []Missing{{Table: "exams", ID: "exam", Key: "exams/exam.pdf"}}
+	if !slices.Equal(report.Missing, want) {
+		t.Errorf("Missing = %v, want %v", report.Missing, want)
+	}
+	exam, err := f.q.GetExam(ctx, "exam")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exam.MissingSince.Valid {
+		t.Fatal("the exam was not flagged as missing")
+	}
+
+	// The flag is cleared once the object is back.
+	f.put(t, "exams/exam.pdf")
+	report, err = Run(ctx, f.q, f.storage, Options{Grace: time.Hour})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(report.Missing) != 0 || report.Found != 1 {
+		t.Errorf("Run = %d missing, %d found, want the exam found", len(report.Missing), report.Found)
+	}
+	if exam, err = f.q.GetExam(ctx, "exam"); err != nil {
+		t.Fatal(err)
+	}
+	if exam.MissingSince.Valid {
+		t.Error("the missing flag was not cleared")
+	}
+}
@@ -0,0 +1,117 @@
+package scan
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	clamdTimeout   = 2 * time.Minute
+	clamdChunkSize = 64 << 10
+)
+
+// Clamd sends files to a clamd daemon with the INSTREAM command. Files larger
+// than the StreamMaxLength of clamd are reported as errors, not as clean.
+type Clamd struct {
+	Network string
+	Addr    string
+}
+
+func (c *Clamd) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	dialer := net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, c.Network, c.Addr)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(clamdTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	// clamd answers as soon as it has an error, e.g. when the stream is too
+	// long, so its reply is read while the file is still being sent.
+	replies := make(chan clamdReply, 1)
+	go func() {
+		reply, err := bufio.NewReader(conn).ReadString(0)
+		replies <- clamdReply{strings.TrimRight(reply, "\x00"), err}
+	}()
+
+	if err := c.send(conn, r); err != nil {
+		select {
+		case reply := <-replies:
+			if reply.text != "" {
+				return Result{}, fmt.Errorf("clamd: %s", reply.text)
+			}
+		case <-time.After(time.Second):
+		}
+		return Result{}, err
+	}
+
+	select {
+	case reply := <-replies:
+		// The connection has the deadline of ctx, so the read may time out
+		// before ctx is done.
+		if reply.text == "" && reply.err != nil {
+			return Result{}, fmt.Errorf("failed to read the reply of clamd: %w", reply.err)
+		}
+		return parseClamdReply(reply.text)
+	case <-ctx.Done():
+		return Result{}, ctx.Err()
+	}
+}
+
+type clamdReply struct {
+	text string
+	err  error
+}
+
+func (c *Clamd) send(conn net.Conn, r io.Reader) error {
+	if _, err := io.WriteString(conn, "zINSTREAM\x00"); err != nil {
+		return err
+	}
+	buf := make([]byte, 4+clamdChunkSize)
+	for {
+		n, err := r.Read(buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf, uint32(n))
+			if _, err := conn.Write(buf[:4+n]); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	// A chunk of length zero ends the stream.
+	_, err := conn.Write([]byte{0, 0, 0, 0})
+	return err
+}
+
+// parseClamdReply parses replies like "stream: OK" or
+// "stream: Eicar-Signature FOUND".
+func parseClamdReply(reply string) (Result, error) {
+	status, ok := strings.CutPrefix(reply, "stream: ")
+	switch {
+	case !ok:
+		return Result{}, fmt.Errorf("unexpected reply from clamd: %q", reply)
+	case status == "OK":
+		return Result{}, nil
+	case strings.HasSuffix(status, " FOUND"):
+		return Result{Infected: true, Signature: strings.TrimSuffix(status, " FOUND")}, nil
+	default:
+		return Result{}, fmt.Errorf("clamd: %s", status)
+	}
+}
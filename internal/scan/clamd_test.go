@@ -0,0 +1,202 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clamdStub accepts connections like clamd and answers each INSTREAM with
+// reply(data), where data is what was streamed. An empty reply is not sent.
+type clamdStub struct {
+	// limit is the StreamMaxLength, streams beyond it are answered with an
+	// error right away, the way clamd does.
+	limit    int
+	reply    func(data []byte) string
+	received chan []byte
+}
+
+func startClamd(t *testing.T, stub *clamdStub) *Clamd {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	stub.received = make(chan []byte, 1)
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go stub.serve(conn)
+		}
+	}()
+	return &Clamd{Network: "tcp", Addr: ln.Addr().String()}
+}
+
+func (s *clamdStub) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	if command, err := r.ReadString(0); err != nil || command != "zINSTREAM\x00" {
+		io.WriteString(conn, "UNKNOWN COMMAND\x00")
+		return
+	}
+
+	var data []byte
+	for {
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return
+		}
+		if size == 0 {
+			break
+		}
+		if s.limit > 0 && len(data)+int(size) > s.limit {
+			io.WriteString(conn, "INSTREAM size limit exceeded. ERROR\x00")
+			return
+		}
+		chunk := make([]byte, size)
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return
+		}
+		data = append(data, chunk...)
+	}
+	s.received <- data
+
+	if reply := s.reply(data); reply != "" {
+		io.WriteString(conn, reply+"\x00")
+		return
+	}
+	// Hold the connection open without answering.
+	io.Copy(io.Discard, conn)
+}
+
+func fileOf(n int) []byte {
+	return bytes.Repeat([]byte("%PDF-1.4\n"), n/9+1)[:n]
+}
+
+func TestClamdScan(t *testing.T) {
+	eicar := []byte(`X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`)
+	reply := func(data []byte) string {
+		switch {
+		case bytes.Contains(data, eicar):
+			return "stream: Eicar-Signature FOUND"
+		case bytes.HasPrefix(data, []byte("broken")):
+			return "stream: Can't allocate memory ERROR"
+		default:
+			return "stream: OK"
+		}
+	}
+
+	tests := []struct {
+		name      string
+		file      []byte
+		infected  bool
+		signature string
+		wantErr   string
+	}{
+		{"clean", fileOf(3*clamdChunkSize + 17), false, "", ""},
+		{"empty", nil, false, "", ""},
+		{"infected", append(fileOf(100), eicar...), true, "Eicar-Signature", ""},
+		{"error reply", []byte("broken file"), false, "", "Can't allocate memory ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &clamdStub{reply: reply}
+			clamd := startClamd(t, stub)
+
+			result, err := clamd.Scan(context.Background(), bytes.NewReader(tt.file))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Scan error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan: %v", err)
+			}
+			if result.Infected != tt.infected || result.Signature != tt.signature {
+				t.Errorf("Scan = %+v, want infected %v with %q", result, tt.infected, tt.signature)
+			}
+			if got := <-stub.received; !bytes.Equal(got, tt.file) {
+				t.Errorf("clamd received %d bytes, want the %d of the file", len(got), len(tt.file))
+			}
+		})
+	}
+}
+
+// Files beyond StreamMaxLength are errors, not clean.
+func TestClamdScanTooLarge(t *testing.T) {
+	clamd := startClamd(t, &clamdStub{
+		limit: 2 * clamdChunkSize,
+		reply: func([]byte) string { return "stream: OK" },
+	})
+
+	result, err := clamd.Scan(context.Background(), bytes.NewReader(fileOf(64*clamdChunkSize)))
+	if err == nil {
+		t.Fatalf("Scan of an oversize file = %+v, want an error", result)
+	}
+	if !strings.Contains(err.Error(), "size limit exceeded") {
+		t.Errorf("Scan error = %v, want the reply of clamd", err)
+	}
+}
+
+func TestClamdScanTimeout(t *testing.T) {
+	clamd := startClamd(t, &clamdStub{reply: func([]byte) string { return "" }})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := clamd.Scan(ctx, bytes.NewReader(fileOf(100)))
+	if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Scan error = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Scan returned after %v", elapsed)
+	}
+}
+
+func TestClamdUnreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	clamd := &Clamd{Network: "tcp", Addr: addr}
+	if _, err := clamd.Scan(context.Background(), strings.NewReader("file")); err == nil {
+		t.Error("Scan without clamd succeeded")
+	}
+}
+
+func TestParseClamdReply(t *testing.T) {
+	tests := []struct {
+		reply    string
+		infected bool
+		wantErr  bool
+	}{
+		{"stream: OK", false, false},
+		{"stream: Win.Test.EICAR_HDB-1 FOUND", true, false},
+		{"stream: Can't allocate memory ERROR", false, true},
+		{"INSTREAM size limit exceeded. ERROR", false, true},
+		{"", false, true},
+	}
+	for _, tt := range tests {
+		result, err := parseClamdReply(tt.reply)
+		if (err != nil) != tt.wantErr || result.Infected != tt.infected {
+			t.Errorf("parseClamdReply(%q) = %+v, %v", tt.reply, result, err)
+		}
+	}
+}
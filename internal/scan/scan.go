@@ -0,0 +1,40 @@
+// Package scan checks uploaded files for malware before they are published.
+package scan
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/fachschaftinformatik/web/internal/config"
+)
+
+// Scanner inspects the content read from r.
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+type Result struct {
+	Infected bool
+	// Signature names what was found in an infected file.
+	Signature string
+}
+
+// New returns the scanner selected by cfg.Scanner.
+func New(cfg *config.Config) (Scanner, error) {
+	switch cfg.Scanner {
+	case "none":
+		return Noop{}, nil
+	case "clamd":
+		return &Clamd{Network: "tcp", Addr: cfg.ClamdAddr}, nil
+	default:
+		return nil, fmt.Errorf("unknown scanner %q, expected none or clamd", cfg.Scanner)
+	}
+}
+
+// Noop accepts every file.
+type Noop struct{}
+
+func (Noop) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	return Result{}, nil
+}
@@ -17,13 +17,25 @@ import (
 	"github.com/fachschaftinformatik/web/internal/email"
 	"github.com/fachschaftinformatik/web/internal/middleware"
 	"github.com/fachschaftinformatik/web/internal/buckets"
+	"github.com/fachschaftinformatik/web/internal/reconcile"
+	"github.com/fachschaftinformatik/web/internal/scan"
 
 	_ "modernc.org/sqlite"
 )
 
 func main() {
 	logger := log.New(os.Stdout, "", log.LstdFlags)
-	cfg := config.New()
+	cfg, err := config.New()
+	if err != nil {
+		logger.Fatalf("Invalid configuration: %v", err)
+	}
+
+	if len(os.Args) > 1 {
+		if err := runCommand(cfg, logger, os.Args[1], os.Args[2:]); err != nil {
+			logger.Fatal(err)
+		}
+		return
+	}
 
 	if err := database.Migrate(cfg.DatabaseUrl, logger); err != nil {
 		logger.Fatalf("Migrations failed: %v", err)
@@ -35,23 +47,42 @@ func main() {
 	}
 	defer sqlDB.Close()
 	logger.Println("Database connection established.")
-	store, err := buckets.NewClient(cfg)
-	if err != nil {
-		logger.Fatalf("Storage client creation failed: %v", err)
-	}
-	
 	startupCtx, cancelStartup := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancelStartup()
-	
-	if err := store.EnsureBucket(startupCtx); err != nil {
-		logger.Fatalf("Failed to ensure bucket exist: %v", err)
+
+	store, err := buckets.New(startupCtx, cfg)
+	if err != nil {
+		logger.Fatalf("Storage creation failed: %v", err)
 	}
-	logger.Println("Connection established and buckets exist")
+	logger.Printf("Using %s storage.", cfg.Storage)
 
-	querier := database.New(sqlDB)
-	emailSender := email.NewSender(cfg)
-	authServer := auth.NewServer(querier, logger, cfg, emailSender, store)
-	handler := middleware.Logging(logger)(api.Handler(authServer))
+	querier := database.NewStore(sqlDB)
+	mailTransport, err := email.NewTransport(cfg)
+	if err != nil {
+		logger.Fatalf("Mail transport creation failed: %v", err)
+	}
+	emailSender, err := email.NewSender(cfg, mailTransport)
+	if err != nil {
+		logger.Fatalf("Email sender creation failed: %v", err)
+	}
+	if cfg.DevMode {
+		logger.Println("Development mode is on, /dev/mails serves captured emails without authentication.")
+	}
+	outbox := email.NewOutbox(querier, emailSender, logger)
+	scanner, err := scan.New(cfg)
+	if err != nil {
+		logger.Fatalf("Scanner creation failed: %v", err)
+	}
+	authServer := auth.NewServer(querier, logger, cfg, emailSender, outbox, store, scanner)
+	var handler http.Handler = api.Handler(authServer)
+	// Backends without an S3 API of their own serve their presigned URLs.
+	if signed, ok := store.(http.Handler); ok {
+		mux := http.NewServeMux()
+		mux.Handle("/storage/", http.StripPrefix("/storage", signed))
+		mux.Handle("/", handler)
+		handler = mux
+	}
+	handler = middleware.Logging(logger)(handler)
 	httpServer := &http.Server{
 		Addr:         ":" + cfg.HTTPPort,
 		Handler:      handler,
@@ -64,6 +95,17 @@ func main() {
 	defer cancel()
 
 	go auth.StartSessionSweeper(ctx, querier, logger)
+	go auth.StartVerificationSweeper(ctx, querier, emailSender, outbox, cfg, logger)
+	go auth.StartUploadSweeper(ctx, querier, store, logger)
+	go reconcile.Start(ctx, querier, store, reconcile.Options{
+		Grace:  time.Duration(cfg.OrphanGrace) * time.Hour,
+		Delete: cfg.DeleteOrphans,
+	}, logger)
+	outboxDone := make(chan struct{})
+	go func() {
+		outbox.Run(ctx)
+		close(outboxDone)
+	}()
 	go func() {
 		logger.Printf("Server starting on port %s", cfg.HTTPPort)
 		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -78,9 +120,16 @@ func main() {
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer shutdownCancel()
 
-	cancel()
 	if err := httpServer.Shutdown(shutdownCtx); err != nil {
 		logger.Printf("Server forced to shutdown: %v", err)
 	}
+	// Stop the background jobs only once no request can queue mail anymore,
+	// then give the outbox a chance to send what is still pending.
+	cancel()
+	select {
+	case <-outboxDone:
+	case <-shutdownCtx.Done():
+		logger.Println("Email outbox did not drain in time.")
+	}
 	logger.Println("Server exiting.")
 }